@@ -6,22 +6,68 @@ import (
 	"io/ioutil"
 	"regexp"
 
+	"github.com/forbearing/k8s/dynamic"
+	"github.com/forbearing/k8s/types"
 	utilerrors "github.com/forbearing/k8s/util/errors"
-	"github.com/sirupsen/logrus"
+	"github.com/forbearing/k8s/util/status"
+	"github.com/forbearing/k8s/util/tracing"
+	utilwait "github.com/forbearing/k8s/util/wait"
+	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
 )
 
 // ApplyF work like "kubectl apply -f filename.yaml -n test",
 // The namespace defined in yaml have higher precedence than namespace specified here.
+// The logger stored in ctx by logr.NewContext() is used to output logs.
+// If ctx carries a trace span, ApplyF creates a child span and every k8s
+// resource in the yaml file is traced as a child span of it.
+// The warnings returned by the API server are logged and collected by the
+// collector stored in ctx, see types.CollectWarnings. Use ApplyFWithResult to
+// get the applied k8s resources and the warnings.
+// With the WaitReady option, ApplyF waits until the status of all applied k8s
+// resources is Current, it returns *status.FailedError if any of them failed.
 func ApplyF(ctx context.Context, kubeconfig, filename string, namespace string, opts ...Options) error {
-	handler, err := New(ctx, kubeconfig, namespace)
+	_, err := ApplyFWithResult(ctx, kubeconfig, filename, namespace, opts...)
+	return err
+}
+
+// ApplyResult is the result of ApplyFWithResult.
+type ApplyResult struct {
+	// Objects are the k8s resources applied.
+	Objects []*unstructured.Unstructured
+	// Warnings are the warnings returned by the API server while applying
+	// the k8s resources, such like the deprecated API versions.
+	Warnings []types.Warning
+}
+
+// ApplyFWithResult works like ApplyF, and returns the k8s resources applied
+// and the warnings returned by the API server. The result is also returned
+// with the error, it contains the k8s resources applied before the error
+// occurred, eg:
+//
+//	result, err := k8s.ApplyFWithResult(ctx, "", filename, "test")
+//	for _, w := range result.Warnings {
+//	    ...
+//	}
+func ApplyFWithResult(ctx context.Context, kubeconfig, filename string, namespace string, opts ...Options) (result *ApplyResult, err error) {
+	ctx, span := tracing.StartSpan(ctx, nil, "ApplyF", tracing.FilenameKey.String(filename))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	result = &ApplyResult{}
+	ctx, collector := types.CollectWarnings(ctx)
+	defer func() { result.Warnings = collector.Warnings() }()
+
+	logger := logr.FromContextOrDiscard(ctx)
+	handler, err := New(ctx, kubeconfig, namespace, handlerOptions(logger, opts)...)
 	if err != nil {
-		return err
+		return result, err
 	}
 
 	yamlData, err := ioutil.ReadFile(filename)
 	if err != nil {
-		return err
+		return result, err
 	}
 	// Remove all comments from yaml documents.
 	removeComments := regexp.MustCompile(`#.*`)
@@ -38,7 +84,8 @@ func ApplyF(ctx context.Context, kubeconfig, filename string, namespace string,
 		// If the k8s resource is namespace scope and no namespace is defined in yaml file, then
 		// dynaimc hanler will create the k8s resource is the namespace specified in dynamic.New().
 		// (namespace defined in yaml file have higher precedence than specified in dynamic.New())
-		_, err = handler.Apply(item)
+		var obj *unstructured.Unstructured
+		obj, err = handler.Apply(item)
 		for _, opt := range opts {
 			switch opt {
 			case IgnoreAlreadyExists:
@@ -56,14 +103,84 @@ func ApplyF(ctx context.Context, kubeconfig, filename string, namespace string,
 		// ignore these errors.
 		// A "Invalid" error will occurrs when you update the pod/job/persistentvolume resource.
 		if err != nil && (apierrors.IsAlreadyExists(err) || apierrors.IsInvalid(err)) {
-			logrus.Error(err)
+			logger.Error(err, "failed to apply k8s resource", objectKeysAndValues("apply", item)...)
 			continue
 		}
 		// Unexpected error, return it.
 		if err != nil {
-			return err
+			return result, err
+		}
+		logger.V(1).Info("k8s resource applied", objectKeysAndValues("apply", item)...)
+		if obj != nil {
+			result.Objects = append(result.Objects, obj)
 		}
 	}
 
+	for _, opt := range opts {
+		if opt == WaitReady {
+			return result, waitReady(ctx, handler, result.Objects)
+		}
+	}
+	return result, nil
+}
+
+// waitReady waits until the status of all k8s objects is Current.
+func waitReady(ctx context.Context, handler *dynamic.Handler, objs []*unstructured.Unstructured) error {
+	logger := logr.FromContextOrDiscard(ctx)
+	for _, obj := range objs {
+		h := handler.WithContext(ctx).WithGVK(obj.GroupVersionKind())
+		// the namespace of the namespaced k8s object defaults to the handler
+		// namespace, it's ignored for the cluster scope k8s object.
+		if namespace := obj.GetNamespace(); len(namespace) != 0 {
+			h = h.WithNamespace(namespace)
+		}
+		err := h.WaitFor(obj.GetName(), status.ForCurrent(), utilwait.WithProgress(func(p utilwait.Progress) {
+			logger.V(1).Info("waiting for k8s resource to be ready", "gvk", obj.GroupVersionKind().String(),
+				"namespace", obj.GetNamespace(), "name", obj.GetName(), "progress", p.String())
+		}))
+		if err != nil {
+			return err
+		}
+		logger.V(1).Info("k8s resource ready", "gvk", obj.GroupVersionKind().String(),
+			"namespace", obj.GetNamespace(), "name", obj.GetName())
+	}
 	return nil
 }
+
+// objectKeysAndValues returns the structured log key/values of the k8s object
+// defined in the yaml document.
+func objectKeysAndValues(verb string, data []byte) []interface{} {
+	obj := &unstructured.Unstructured{}
+	jsonData, err := utilyaml.ToJSON(data)
+	if err != nil {
+		return []interface{}{"verb", verb}
+	}
+	if err = obj.UnmarshalJSON(jsonData); err != nil {
+		return []interface{}{"verb", verb}
+	}
+	return []interface{}{
+		"gvk", obj.GroupVersionKind().String(),
+		"namespace", obj.GetNamespace(),
+		"name", obj.GetName(),
+		"verb", verb,
+	}
+}
+
+// handlerOptions returns the handler options used by ApplyF and DeleteF.
+func handlerOptions(logger logr.Logger, opts []Options) []types.HandlerOption {
+	handlerOpts := []types.HandlerOption{
+		tracing.HandlerOption(nil),
+		types.WithWarningHandler(func(op *types.Operation, warnings []types.Warning) {
+			for _, w := range warnings {
+				logger.Info("API server warning", "gvk", op.GVK.String(), "namespace", op.Namespace,
+					"name", op.Name, "verb", string(op.Verb), "warning", w.Text)
+			}
+		}),
+	}
+	for _, opt := range opts {
+		if opt == FailOnDeprecation {
+			handlerOpts = append(handlerOpts, types.WithFailOnDeprecation())
+		}
+	}
+	return handlerOpts
+}
@@ -0,0 +1,149 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// newFakeAPIServer returns the API server that serves the discovery of
+// apps/v1 deployments, and creates or updates the deployments in memory like
+// the kubernetes API server: the namespace is defaulted and the uid and
+// resourceVersion are set.
+func newFakeAPIServer(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	objects := make(map[string]*unstructured.Unstructured)
+	writeJSON := func(w http.ResponseWriter, code int, obj interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(obj)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/api":
+			writeJSON(w, http.StatusOK, &metav1.APIVersions{Versions: []string{"v1"}})
+			return
+		case "/apis":
+			writeJSON(w, http.StatusOK, &metav1.APIGroupList{Groups: []metav1.APIGroup{{
+				Name:             "apps",
+				Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: "apps/v1", Version: "v1"}},
+				PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "apps/v1", Version: "v1"},
+			}}})
+			return
+		case "/api/v1":
+			writeJSON(w, http.StatusOK, &metav1.APIResourceList{GroupVersion: "v1"})
+			return
+		case "/apis/apps/v1":
+			writeJSON(w, http.StatusOK, &metav1.APIResourceList{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{{
+				Name: "deployments", Namespaced: true, Kind: "Deployment",
+				Verbs: metav1.Verbs{"create", "delete", "get", "list", "patch", "update", "watch"},
+			}}})
+			return
+		}
+
+		// /apis/apps/v1/namespaces/{namespace}/deployments[/{name}]
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/apis/apps/v1/namespaces/"), "/")
+		if len(parts) < 2 || parts[1] != "deployments" {
+			http.NotFound(w, r)
+			return
+		}
+		namespace := parts[0]
+		obj := &unstructured.Unstructured{}
+		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+			data, _ := ioutil.ReadAll(r.Body)
+			if err := obj.UnmarshalJSON(data); err != nil {
+				t.Errorf("unmarshal request body: %v", err)
+				return
+			}
+		}
+		key := namespace + "/" + obj.GetName()
+		switch r.Method {
+		case http.MethodPost:
+			if _, ok := objects[key]; ok {
+				writeJSON(w, http.StatusConflict, &metav1.Status{
+					Status: metav1.StatusFailure, Reason: metav1.StatusReasonAlreadyExists, Code: http.StatusConflict,
+					Message: fmt.Sprintf("deployments.apps %q already exists", obj.GetName()),
+				})
+				return
+			}
+			obj.SetNamespace(namespace)
+			obj.SetUID(types.UID("uid-" + obj.GetName()))
+			obj.SetResourceVersion("1")
+			objects[key] = obj
+			writeJSON(w, http.StatusCreated, obj.Object)
+		case http.MethodPut:
+			obj.SetNamespace(namespace)
+			obj.SetUID(objects[key].GetUID())
+			obj.SetResourceVersion("2")
+			objects[key] = obj
+			writeJSON(w, http.StatusOK, obj.Object)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestApplyFWithResult(t *testing.T) {
+	server := newFakeAPIServer(t)
+	defer server.Close()
+
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	config := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: fake
+  cluster:
+    server: %s
+contexts:
+- name: fake
+  context:
+    cluster: fake
+current-context: fake
+`, server.URL)
+	if err := os.WriteFile(kubeconfig, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// the namespace of the deployment isn't defined in the yaml file.
+	filename := filepath.Join(dir, "nginx.yaml")
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+spec:
+  replicas: 1
+`
+	if err := os.WriteFile(filename, []byte(manifest), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the deployment is created by the first apply and updated by the second.
+	for _, resourceVersion := range []string{"1", "2"} {
+		result, err := ApplyFWithResult(context.TODO(), kubeconfig, filename, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Objects) != 1 {
+			t.Fatalf("expected 1 applied object, got %d", len(result.Objects))
+		}
+		obj := result.Objects[0]
+		if obj.GetUID() != "uid-nginx" || obj.GetNamespace() != "test" || obj.GetResourceVersion() != resourceVersion {
+			t.Errorf("expected the object returned by the API server, got %s/%s with uid %q and resourceVersion %q",
+				obj.GetNamespace(), obj.GetName(), obj.GetUID(), obj.GetResourceVersion())
+		}
+	}
+}
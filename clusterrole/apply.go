@@ -1,8 +1,10 @@
 package clusterrole
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,14 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*rbacv1.ClusterRole, e
 
 // applyCR
 func (h *Handler) applyCR(cr *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
-	_, err := h.createCR(cr)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateCR(cr)
-	}
-	return cr, err
+	op := &types.Operation{Verb: types.VerbApply, Name: cr.Name, Object: cr}
+	return h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.ClusterRole, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createCR(cr)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateCR(cr)
+		}
+		return cr, err
+	})
 }
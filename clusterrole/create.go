@@ -1,10 +1,12 @@
 package clusterrole
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,8 @@ func (h *Handler) CreateFromMap(u map[string]interface{}) (*rbacv1.ClusterRole,
 func (h *Handler) createCR(cr *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
 	cr.ResourceVersion = ""
 	cr.UID = ""
-	return h.clientset.RbacV1().ClusterRoles().Create(h.ctx, cr, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Name: cr.Name, Object: cr}
+	return h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.ClusterRole, error) {
+		return h.clientset.RbacV1().ClusterRoles().Create(ctx, cr, h.Options.CreateOptions)
+	})
 }
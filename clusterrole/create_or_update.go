@@ -0,0 +1,130 @@
+package clusterrole
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing clusterrole into it's desired state.
+type MutateFn func(cr *rbacv1.ClusterRole) error
+
+// CreateOrUpdate gets the clusterrole from the kubernetes API server. If the
+// clusterrole does not exist, it will be created after mutateFn is called.
+// If the clusterrole exists, mutateFn is called on the live clusterrole and the
+// clusterrole will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live clusterrole, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The cr must contain the name of the clusterrole, cr will be overwritten
+// by the live clusterrole returned by the kubernetes API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(cr *rbacv1.ClusterRole, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getCR(cr)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, cr); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createCR(cr)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*cr = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*cr = *existing.DeepCopy()
+	if err := mutate(mutateFn, cr); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, cr) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateCRWithResourceVersion(cr)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*cr = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the clusterrole already exists,
+// only the difference between the live clusterrole and the mutated clusterrole
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(cr *rbacv1.ClusterRole, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getCR(cr)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, cr); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createCR(cr)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*cr = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*cr = *existing.DeepCopy()
+	if err := mutate(mutateFn, cr); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(cr); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, rbacv1.ClusterRole{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*cr = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, cr *rbacv1.ClusterRole) error {
+	name := cr.GetName()
+	if mutateFn != nil {
+		if err := mutateFn(cr); err != nil {
+			return err
+		}
+	}
+	if cr.GetName() != name {
+		return fmt.Errorf("MutateFn cannot mutate clusterrole name")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package clusterrole
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes clusterrole by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.RbacV1().ClusterRoles().Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.ClusterRole, error) {
+		return nil, h.clientset.RbacV1().ClusterRoles().Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes clusterrole from yaml or json file.
@@ -100,5 +106,9 @@ func (h *Handler) DeleteFromMap(u map[string]interface{}) error {
 
 // deleteCR
 func (h *Handler) deleteCR(cr *rbacv1.ClusterRole) error {
-	return h.clientset.RbacV1().ClusterRoles().Delete(h.ctx, cr.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: cr.Name, Object: cr}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.ClusterRole, error) {
+		return nil, h.clientset.RbacV1().ClusterRoles().Delete(ctx, cr.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*rbacv1.ClusterRole, error) {
 
 // GetByName gets clusterrole by name.
 func (h *Handler) GetByName(name string) (*rbacv1.ClusterRole, error) {
-	return h.clientset.RbacV1().ClusterRoles().Get(h.ctx, name, h.Options.GetOptions)
+	cr, err := h.clientset.RbacV1().ClusterRoles().Get(h.ctx, name, h.Options.GetOptions)
+	return cr, utilerrors.Wrap("get", GVK, "", name, err)
 }
 
 // GetFromFile gets clusterrole from yaml or json file.
@@ -102,5 +104,6 @@ func (h *Handler) GetFromMap(u map[string]interface{}) (*rbacv1.ClusterRole, err
 // It's necessary to get a new clusterrole resource from a old clusterrole resource,
 // because old clusterrole usually don't have clusterrole.Status field.
 func (h *Handler) getCR(cr *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
-	return h.clientset.RbacV1().ClusterRoles().Get(h.ctx, cr.Name, h.Options.GetOptions)
+	newCR, err := h.clientset.RbacV1().ClusterRoles().Get(h.ctx, cr.Name, h.Options.GetOptions)
+	return newCR, utilerrors.Wrap("get", GVK, "", cr.Name, err)
 }
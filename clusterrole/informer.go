@@ -3,7 +3,7 @@ package clusterrole
 import (
 	"time"
 
-	"github.com/sirupsen/logrus"
+	"github.com/forbearing/k8s/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/informers/internalinterfaces"
@@ -96,11 +96,15 @@ func (h *Handler) RunInformer(
 		DeleteFunc: deleteFunc,
 	})
 
+	types.ObserveInformer(h.Informer(), h.observer, GVK)
+
 	// method 1, recommended
 	h.InformerFactory().Start(stopCh)
-	logrus.Info("Waiting for informer caches to sync")
-	if ok := cache.WaitForCacheSync(stopCh, h.Informer().HasSynced); !ok {
-		logrus.Error("failed to wait for caches to sync")
+	h.logger.Info("waiting for informer caches to sync")
+	ok := cache.WaitForCacheSync(stopCh, h.Informer().HasSynced)
+	h.observer.InformerSynced(GVK, ok)
+	if !ok {
+		h.logger.Error(nil, "failed to wait for caches to sync")
 	}
 
 	//// method 2
@@ -1,10 +1,12 @@
 package clusterrole
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,15 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*rbacv1.ClusterRole,
 func (h *Handler) updateCR(cr *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
 	cr.ResourceVersion = ""
 	cr.UID = ""
-	return h.clientset.RbacV1().ClusterRoles().Update(h.ctx, cr, h.Options.UpdateOptions)
+	return h.updateCRWithResourceVersion(cr)
+}
+
+// updateCRWithResourceVersion updates the clusterrole without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the clusterrole has been changed since it was read.
+func (h *Handler) updateCRWithResourceVersion(cr *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+	op := &types.Operation{Verb: types.VerbUpdate, Name: cr.Name, Object: cr}
+	return h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.ClusterRole, error) {
+		return h.clientset.RbacV1().ClusterRoles().Update(ctx, cr, h.Options.UpdateOptions)
+	})
 }
@@ -1,8 +1,10 @@
 package clusterrolebinding
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,14 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*rbacv1.ClusterRoleBin
 
 // applyCRB
 func (h *Handler) applyCRB(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
-	_, err := h.createCRB(crb)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateCRB(crb)
-	}
-	return crb, err
+	op := &types.Operation{Verb: types.VerbApply, Name: crb.Name, Object: crb}
+	return h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.ClusterRoleBinding, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createCRB(crb)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateCRB(crb)
+		}
+		return crb, err
+	})
 }
@@ -1,10 +1,12 @@
 package clusterrolebinding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,8 @@ func (h *Handler) CreateFromMap(u map[string]interface{}) (*rbacv1.ClusterRoleBi
 func (h *Handler) createCRB(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
 	crb.ResourceVersion = ""
 	crb.UID = ""
-	return h.clientset.RbacV1().ClusterRoleBindings().Create(h.ctx, crb, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Name: crb.Name, Object: crb}
+	return h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.ClusterRoleBinding, error) {
+		return h.clientset.RbacV1().ClusterRoleBindings().Create(ctx, crb, h.Options.CreateOptions)
+	})
 }
@@ -0,0 +1,130 @@
+package clusterrolebinding
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing clusterrolebinding into it's desired state.
+type MutateFn func(crb *rbacv1.ClusterRoleBinding) error
+
+// CreateOrUpdate gets the clusterrolebinding from the kubernetes API server. If the
+// clusterrolebinding does not exist, it will be created after mutateFn is called.
+// If the clusterrolebinding exists, mutateFn is called on the live clusterrolebinding and the
+// clusterrolebinding will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live clusterrolebinding, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The crb must contain the name of the clusterrolebinding, crb will be overwritten
+// by the live clusterrolebinding returned by the kubernetes API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(crb *rbacv1.ClusterRoleBinding, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getCRB(crb)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, crb); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createCRB(crb)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*crb = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*crb = *existing.DeepCopy()
+	if err := mutate(mutateFn, crb); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, crb) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateCRBWithResourceVersion(crb)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*crb = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the clusterrolebinding already exists,
+// only the difference between the live clusterrolebinding and the mutated clusterrolebinding
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(crb *rbacv1.ClusterRoleBinding, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getCRB(crb)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, crb); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createCRB(crb)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*crb = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*crb = *existing.DeepCopy()
+	if err := mutate(mutateFn, crb); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(crb); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, rbacv1.ClusterRoleBinding{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*crb = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, crb *rbacv1.ClusterRoleBinding) error {
+	name := crb.GetName()
+	if mutateFn != nil {
+		if err := mutateFn(crb); err != nil {
+			return err
+		}
+	}
+	if crb.GetName() != name {
+		return fmt.Errorf("MutateFn cannot mutate clusterrolebinding name")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package clusterrolebinding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes clusterrolebinding by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.RbacV1().ClusterRoleBindings().Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.ClusterRoleBinding, error) {
+		return nil, h.clientset.RbacV1().ClusterRoleBindings().Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes clusterrolebinding from yaml or json file.
@@ -100,5 +106,9 @@ func (h *Handler) DeleteFromMap(u map[string]interface{}) error {
 
 // deleteCRB
 func (h *Handler) deleteCRB(crb *rbacv1.ClusterRoleBinding) error {
-	return h.clientset.RbacV1().ClusterRoleBindings().Delete(h.ctx, crb.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: crb.Name, Object: crb}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.ClusterRoleBinding, error) {
+		return nil, h.clientset.RbacV1().ClusterRoleBindings().Delete(ctx, crb.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
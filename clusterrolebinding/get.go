@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*rbacv1.ClusterRoleBinding, error) {
 
 // GetByName gets clusterrolebinding by name.
 func (h *Handler) GetByName(name string) (*rbacv1.ClusterRoleBinding, error) {
-	return h.clientset.RbacV1().ClusterRoleBindings().Get(h.ctx, name, h.Options.GetOptions)
+	crb, err := h.clientset.RbacV1().ClusterRoleBindings().Get(h.ctx, name, h.Options.GetOptions)
+	return crb, utilerrors.Wrap("get", GVK, "", name, err)
 }
 
 // GetFromFile gets clusterrolebinding from yaml or json file.
@@ -102,5 +104,6 @@ func (h *Handler) GetFromMap(u map[string]interface{}) (*rbacv1.ClusterRoleBindi
 // It's necessary to get a new clusterrolebinding resource from a old clusterrolebinding resource,
 // because old clusterrolebinding usually don't have clusterrolebinding.Status field.
 func (h *Handler) getCRB(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
-	return h.clientset.RbacV1().ClusterRoleBindings().Get(h.ctx, crb.Name, h.Options.GetOptions)
+	newCRB, err := h.clientset.RbacV1().ClusterRoleBindings().Get(h.ctx, crb.Name, h.Options.GetOptions)
+	return newCRB, utilerrors.Wrap("get", GVK, "", crb.Name, err)
 }
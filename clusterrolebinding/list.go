@@ -1,6 +1,7 @@
 package clusterrolebinding
 
 import (
+	"github.com/forbearing/k8s/util/selector"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/fields"
 )
@@ -14,13 +15,12 @@ func (h *Handler) List() ([]*rbacv1.ClusterRoleBinding, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*rbacv1.ClusterRoleBinding, error) {
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.LabelSelector = labels
-	crbList, err := h.clientset.RbacV1().ClusterRoleBindings().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
 		return nil, err
 	}
-	return extractList(crbList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.LabelSelector = labels
+	return h.listAll(*listOptions)
 }
 
 // ListByField list clusterrolebindings by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -29,26 +29,15 @@ func (h *Handler) ListByField(field string) ([]*rbacv1.ClusterRoleBinding, error
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	crbList, err := h.clientset.RbacV1().ClusterRoleBindings().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(crbList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(*listOptions)
 }
 
 // ListAll list all clusterrolebindings in the k8s cluster.
 func (h *Handler) ListAll() ([]*rbacv1.ClusterRoleBinding, error) {
 	return h.ListByLabel("")
 }
-
-// extractList
-func extractList(crbList *rbacv1.ClusterRoleBindingList) []*rbacv1.ClusterRoleBinding {
-	var objList []*rbacv1.ClusterRoleBinding
-	for i := range crbList.Items {
-		objList = append(objList, &crbList.Items[i])
-	}
-	return objList
-}
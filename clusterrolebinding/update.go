@@ -1,10 +1,12 @@
 package clusterrolebinding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,15 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*rbacv1.ClusterRoleBi
 func (h *Handler) updateCRB(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
 	crb.ResourceVersion = ""
 	crb.UID = ""
-	return h.clientset.RbacV1().ClusterRoleBindings().Update(h.ctx, crb, h.Options.UpdateOptions)
+	return h.updateCRBWithResourceVersion(crb)
+}
+
+// updateCRBWithResourceVersion updates the clusterrolebinding without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the clusterrolebinding has been changed since it was read.
+func (h *Handler) updateCRBWithResourceVersion(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+	op := &types.Operation{Verb: types.VerbUpdate, Name: crb.Name, Object: crb}
+	return h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.ClusterRoleBinding, error) {
+		return h.clientset.RbacV1().ClusterRoleBindings().Update(ctx, crb, h.Options.UpdateOptions)
+	})
 }
@@ -0,0 +1,27 @@
+package clusterrolebinding
+
+import (
+	"time"
+
+	utilwait "github.com/forbearing/k8s/util/wait"
+)
+
+// WaitFor blocks until the clusterrolebinding satisfies the condition, it works like
+// "kubectl wait --for=condition=Ready clusterrolebinding/name". It watches the clusterrolebinding and
+// returns when the condition is satisfied, the timeout expired or the
+// handler context is done, see package util/wait.
+func (h *Handler) WaitFor(name string, cond utilwait.Condition, opts ...utilwait.Option) error {
+	return h.waitFor(utilwait.Target{Name: name}, cond, opts...)
+}
+
+// WaitForByLabel blocks until all clusterrolebindings selected by the labels satisfy the
+// condition, it works like "kubectl wait --for=delete clusterrolebinding -l app=nginx".
+func (h *Handler) WaitForByLabel(labels string, cond utilwait.Condition, opts ...utilwait.Option) error {
+	return h.waitFor(utilwait.Target{LabelSelector: labels}, cond, opts...)
+}
+
+func (h *Handler) waitFor(target utilwait.Target, cond utilwait.Condition, opts ...utilwait.Option) (err error) {
+	start := time.Now()
+	defer func() { h.observer.WaitDone(GVK, cond.Name, time.Since(start), err) }()
+	return utilwait.For(h.ctx, h.dynamicClient.Resource(GVR), target, cond, opts...)
+}
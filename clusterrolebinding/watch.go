@@ -1,7 +1,8 @@
 package clusterrolebinding
 
 import (
-	log "github.com/sirupsen/logrus"
+	utilerrors "github.com/forbearing/k8s/util/errors"
+	"github.com/forbearing/k8s/util/selector"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/watch"
@@ -52,6 +53,9 @@ func (h *Handler) WatchByName(name string, addFunc, modifyFunc, deleteFunc func(
 //  * If Event.Type is Error: *api.Status is recommended; other types may make sense
 //    depending on context.
 func (h *Handler) WatchByLabel(labels string, addFunc, modifyFunc, deleteFunc func(obj interface{})) error {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
+		return err
+	}
 	return h.watchClusterRole(metav1.ListOptions{LabelSelector: labels, TimeoutSeconds: new(int64)},
 		addFunc, modifyFunc, deleteFunc)
 }
@@ -72,6 +76,9 @@ func (h *Handler) WatchByField(field string, addFunc, modifyFunc, deleteFunc fun
 	if err != nil {
 		return err
 	}
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
+		return err
+	}
 	listOptions := metav1.ListOptions{FieldSelector: fieldSelector.String(), TimeoutSeconds: new(int64)}
 	return h.watchClusterRole(listOptions, addFunc, modifyFunc, deleteFunc)
 }
@@ -85,7 +92,7 @@ func (h *Handler) watchClusterRole(listOptions metav1.ListOptions,
 	// reconnect to kubernetes API server.
 	for {
 		if watcher, err = h.clientset.RbacV1().ClusterRoles().Watch(h.ctx, listOptions); err != nil {
-			return err
+			return utilerrors.Wrap("watch", GVK, "", "", err)
 		}
 		// kubernetes retains the resource event history, which includes this
 		// initial event, so that when our program first start, we are automatically
@@ -100,13 +107,14 @@ func (h *Handler) watchClusterRole(listOptions metav1.ListOptions,
 			case watch.Deleted:
 				deleteFunc(event.Object)
 			case watch.Bookmark:
-				log.Debug("watch clusterrolebinding: bookmark")
+				h.logger.V(1).Info("watch bookmark", "verb", "watch")
 			case watch.Error:
-				log.Debug("watch clusterrolebinding: error")
+				h.logger.V(1).Info("watch error", "verb", "watch")
 			}
 		}
 		// If event channel is closed, it means the server has closed the connection
-		log.Debug("watch clusterrolebinding: reconnect to kubernetes")
+		h.logger.V(1).Info("watch closed, reconnect to kubernetes", "verb", "watch")
+		h.observer.WatchReconnected(GVK)
 		watcher.Stop()
 	}
 }
@@ -1,8 +1,10 @@
 package configmap
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*corev1.ConfigMap, err
 
 // applyConfigmap
 func (h *Handler) applyConfigmap(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
-	_, err := h.createConfigmap(cm)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateConfigmap(cm)
+	namespace := cm.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return cm, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: cm.Name, Object: cm}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.ConfigMap, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createConfigmap(cm)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateConfigmap(cm)
+		}
+		return cm, err
+	})
 }
@@ -1,10 +1,12 @@
 package configmap
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createConfigmap(cm *corev1.ConfigMap) (*corev1.ConfigMap, erro
 	}
 	cm.ResourceVersion = ""
 	cm.UID = ""
-	return h.clientset.CoreV1().ConfigMaps(namespace).Create(h.ctx, cm, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: cm.Name, Object: cm}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.ConfigMap, error) {
+		return h.clientset.CoreV1().ConfigMaps(namespace).Create(ctx, cm, h.Options.CreateOptions)
+	})
 }
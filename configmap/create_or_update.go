@@ -0,0 +1,131 @@
+package configmap
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing configmap into it's desired state.
+type MutateFn func(cm *corev1.ConfigMap) error
+
+// CreateOrUpdate gets the configmap from the kubernetes API server. If the
+// configmap does not exist, it will be created after mutateFn is called.
+// If the configmap exists, mutateFn is called on the live configmap and the
+// configmap will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live configmap, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The cm must contain the name and namespace(optional) of the configmap,
+// cm will be overwritten by the live configmap returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(cm *corev1.ConfigMap, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getConfigmap(cm)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, cm); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createConfigmap(cm)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*cm = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*cm = *existing.DeepCopy()
+	if err := mutate(mutateFn, cm); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, cm) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateConfigmapWithResourceVersion(cm)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*cm = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the configmap already exists,
+// only the difference between the live configmap and the mutated configmap
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(cm *corev1.ConfigMap, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getConfigmap(cm)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, cm); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createConfigmap(cm)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*cm = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*cm = *existing.DeepCopy()
+	if err := mutate(mutateFn, cm); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(cm); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, corev1.ConfigMap{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*cm = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, cm *corev1.ConfigMap) error {
+	name, namespace := cm.GetName(), cm.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(cm); err != nil {
+			return err
+		}
+	}
+	if cm.GetName() != name || cm.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate configmap name and/or configmap namespace")
+	}
+	return nil
+}
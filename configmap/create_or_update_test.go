@@ -0,0 +1,204 @@
+package configmap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/forbearing/k8s/types"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// fakeServer is the kubernetes API server that stores the configmaps in
+// memory, the update is rejected with a Conflict error if the resourceVersion
+// is set and doesn't match the stored configmap.
+type fakeServer struct {
+	mu         sync.Mutex
+	configmaps map[string]*corev1.ConfigMap
+	updates    int
+}
+
+// bump simulates a concurrent write to the stored configmap.
+func (s *fakeServer) bump(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cm := s.configmaps[name]
+	cm.Data["concurrent"] = "true"
+	cm.ResourceVersion = next(cm.ResourceVersion)
+}
+
+func next(resourceVersion string) string {
+	rv, _ := strconv.Atoi(resourceVersion)
+	return strconv.Itoa(rv + 1)
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON := func(code int, obj interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(obj)
+	}
+	writeStatus := func(err *k8serrors.StatusError) {
+		status := err.Status()
+		status.TypeMeta = metav1.TypeMeta{Kind: "Status", APIVersion: "v1"}
+		writeJSON(int(status.Code), &status)
+	}
+	resource := schema.GroupResource{Resource: "configmaps"}
+
+	// /api/v1/namespaces/test/configmaps[/{name}]
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/v1/namespaces/test/configmaps"), "/")
+	cm := &corev1.ConfigMap{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(cm); err != nil {
+			writeStatus(k8serrors.NewBadRequest(err.Error()))
+			return
+		}
+		name = cm.Name
+	}
+	stored, exists := s.configmaps[name]
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			writeStatus(k8serrors.NewNotFound(resource, name))
+			return
+		}
+		writeJSON(http.StatusOK, stored)
+	case http.MethodPost:
+		if exists {
+			writeStatus(k8serrors.NewAlreadyExists(resource, name))
+			return
+		}
+		cm.Namespace, cm.ResourceVersion = "test", "1"
+		s.configmaps[name] = cm
+		writeJSON(http.StatusCreated, cm)
+	case http.MethodPut:
+		if !exists {
+			writeStatus(k8serrors.NewNotFound(resource, name))
+			return
+		}
+		if len(cm.ResourceVersion) != 0 && cm.ResourceVersion != stored.ResourceVersion {
+			writeStatus(k8serrors.NewConflict(resource, name, nil))
+			return
+		}
+		cm.Namespace, cm.ResourceVersion = "test", next(stored.ResourceVersion)
+		s.configmaps[name] = cm
+		s.updates++
+		writeJSON(http.StatusOK, cm)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	setData := func(value string) MutateFn {
+		return func(cm *corev1.ConfigMap) error {
+			if cm.Data == nil {
+				cm.Data = make(map[string]string)
+			}
+			cm.Data["key"] = value
+			return nil
+		}
+	}
+	tests := []struct {
+		name     string
+		cmName   string
+		mutateFn MutateFn
+		// concurrent writes the configmap after CreateOrUpdate has read it.
+		concurrent bool
+		expected   types.OperationResult
+		updates    int
+		conflict   bool
+	}{
+		{
+			name:     "created",
+			cmName:   "redis",
+			mutateFn: setData("value"),
+			expected: types.OperationResultCreated,
+		},
+		{
+			name:     "unchanged",
+			cmName:   "nginx",
+			mutateFn: setData("value"),
+			expected: types.OperationResultUnchanged,
+		},
+		{
+			name:     "updated",
+			cmName:   "nginx",
+			mutateFn: setData("new"),
+			expected: types.OperationResultUpdated,
+			updates:  1,
+		},
+		{
+			name:       "conflict",
+			cmName:     "nginx",
+			mutateFn:   setData("new"),
+			concurrent: true,
+			expected:   types.OperationResultUnchanged,
+			conflict:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeServer{configmaps: map[string]*corev1.ConfigMap{
+				"nginx": {
+					ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "test", ResourceVersion: "1"},
+					Data:       map[string]string{"key": "value"},
+				},
+			}}
+			httpServer := httptest.NewServer(server)
+			defer httpServer.Close()
+			handler := &Handler{
+				ctx:       context.TODO(),
+				namespace: "test",
+				logger:    logr.Discard(),
+				observer:  types.NopObserver{},
+				clientset: kubernetes.NewForConfigOrDie(&rest.Config{Host: httpServer.URL}),
+				Options:   &types.HandlerOptions{},
+			}
+
+			mutateFn := test.mutateFn
+			if test.concurrent {
+				mutateFn = func(cm *corev1.ConfigMap) error {
+					server.bump(cm.Name)
+					return test.mutateFn(cm)
+				}
+			}
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: test.cmName}}
+			result, err := handler.CreateOrUpdate(cm, mutateFn)
+			if test.conflict {
+				if !k8serrors.IsConflict(err) {
+					t.Fatalf("expected Conflict error, got %v", err)
+				}
+				// the concurrent write isn't overwritten.
+				if server.configmaps["nginx"].Data["concurrent"] != "true" {
+					t.Errorf("the concurrent write is overwritten")
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, result)
+			}
+			if server.updates != test.updates {
+				t.Errorf("expected %d updates, got %d", test.updates, server.updates)
+			}
+			if !test.conflict && cm.Data["key"] != server.configmaps[test.cmName].Data["key"] {
+				t.Errorf("expected the configmap returned by the API server, got %v", cm.Data)
+			}
+		})
+	}
+}
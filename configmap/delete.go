@@ -1,10 +1,12 @@
 package configmap
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes configmap by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.CoreV1().ConfigMaps(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*corev1.ConfigMap, error) {
+		return nil, h.clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes configmap from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteConfigmap(cm *corev1.ConfigMap) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().ConfigMaps(namespace).Delete(h.ctx, cm.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: cm.Name, Object: cm}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*corev1.ConfigMap, error) {
+		return nil, h.clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, cm.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*corev1.ConfigMap, error) {
 
 // GetByName gets configmap by name.
 func (h *Handler) GetByName(name string) (*corev1.ConfigMap, error) {
-	return h.clientset.CoreV1().ConfigMaps(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	cm, err := h.clientset.CoreV1().ConfigMaps(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return cm, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets configmap from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getConfigmap(cm *corev1.ConfigMap) (*corev1.ConfigMap, error)
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().ConfigMaps(namespace).Get(h.ctx, cm.Name, h.Options.GetOptions)
+	newCM, err := h.clientset.CoreV1().ConfigMaps(namespace).Get(h.ctx, cm.Name, h.Options.GetOptions)
+	return newCM, utilerrors.Wrap("get", GVK, namespace, cm.Name, err)
 }
@@ -1,6 +1,7 @@
 package configmap
 
 import (
+	"github.com/forbearing/k8s/util/selector"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -15,13 +16,12 @@ func (h *Handler) List() ([]*corev1.ConfigMap, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*corev1.ConfigMap, error) {
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.LabelSelector = labels
-	cmList, err := h.clientset.CoreV1().ConfigMaps(h.namespace).List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
 		return nil, err
 	}
-	return extractList(cmList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.LabelSelector = labels
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByField list configmaps by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -30,14 +30,12 @@ func (h *Handler) ListByField(field string) ([]*corev1.ConfigMap, error) {
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	cmList, err := h.clientset.CoreV1().ConfigMaps(h.namespace).List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(cmList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByNamespace list all configmaps in the specified namespace.
@@ -49,12 +47,3 @@ func (h *Handler) ListByNamespace(namespace string) ([]*corev1.ConfigMap, error)
 func (h *Handler) ListAll() ([]*corev1.ConfigMap, error) {
 	return h.WithNamespace(metav1.NamespaceAll).ListByLabel("")
 }
-
-// extractList
-func extractList(cmList *corev1.ConfigMapList) []*corev1.ConfigMap {
-	var objList []*corev1.ConfigMap
-	for i := range cmList.Items {
-		objList = append(objList, &cmList.Items[i])
-	}
-	return objList
-}
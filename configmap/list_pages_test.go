@@ -0,0 +1,130 @@
+package configmap
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type listResponse struct {
+	names []string
+	token string
+	err   error
+}
+
+// newPagesClient returns the fake configmap client that returns the list
+// responses in order by the reactor.
+func newPagesClient(t *testing.T, responses []listResponse) (*fake.Clientset, *int) {
+	clientset := fake.NewSimpleClientset()
+	requests := new(int)
+	clientset.PrependReactor("list", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		*requests++
+		if *requests > len(responses) {
+			t.Errorf("unexpected list request %d", *requests)
+			return true, nil, errors.New("unexpected list request")
+		}
+		resp := responses[*requests-1]
+		if resp.err != nil {
+			return true, nil, resp.err
+		}
+		cmList := &corev1.ConfigMapList{ListMeta: metav1.ListMeta{Continue: resp.token}}
+		for _, name := range resp.names {
+			cmList.Items = append(cmList.Items, corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"}})
+		}
+		return true, cmList, nil
+	})
+	return clientset, requests
+}
+
+func TestListPages(t *testing.T) {
+	expired := k8serrors.NewResourceExpired("continue token expired")
+	stop := errors.New("stop")
+	tests := []struct {
+		name      string
+		responses []listResponse
+		// stopAt is the page on which fn returns an error, zero means never.
+		stopAt int
+		pages  [][]string
+		err    error
+	}{
+		{
+			name: "multiple pages",
+			responses: []listResponse{
+				{names: []string{"a", "b"}, token: "1"},
+				{names: []string{"c", "d"}, token: "2"},
+				{names: []string{"e"}},
+			},
+			pages: [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+		},
+		{
+			name: "continue token expired",
+			responses: []listResponse{
+				{names: []string{"a", "b"}, token: "1"},
+				{err: expired},
+				// relist from the beginning, a and b are skipped.
+				{names: []string{"a", "b", "c"}, token: "3"},
+				{names: []string{"d"}},
+			},
+			pages: [][]string{{"a", "b"}, {"c"}, {"d"}},
+		},
+		{
+			name:      "expired without continue token",
+			responses: []listResponse{{err: expired}},
+			err:       expired,
+		},
+		{
+			name: "fn returns error",
+			responses: []listResponse{
+				{names: []string{"a", "b"}, token: "1"},
+				{names: []string{"c", "d"}, token: "2"},
+				{names: []string{"e"}},
+			},
+			stopAt: 2,
+			pages:  [][]string{{"a", "b"}, {"c", "d"}},
+			err:    stop,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clientset, requests := newPagesClient(t, test.responses)
+			p := &pager{
+				ctx:         context.TODO(),
+				namespace:   "test",
+				client:      clientset.CoreV1().ConfigMaps("test"),
+				listOptions: metav1.ListOptions{Limit: 2},
+			}
+
+			var pages [][]string
+			err := p.forEach(func(page []*corev1.ConfigMap) error {
+				var names []string
+				for _, cm := range page {
+					names = append(names, cm.Name)
+				}
+				pages = append(pages, names)
+				if len(pages) == test.stopAt {
+					return stop
+				}
+				return nil
+			})
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if !reflect.DeepEqual(pages, test.pages) {
+				t.Errorf("expected pages %v, got %v", test.pages, pages)
+			}
+			// no more list requests after fn returns an error.
+			if test.stopAt != 0 && *requests != test.stopAt {
+				t.Errorf("expected %d list requests, got %d", test.stopAt, *requests)
+			}
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package configmap
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*corev1.ConfigMap, er
 
 // updateConfigmap
 func (h *Handler) updateConfigmap(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	cm.ResourceVersion = ""
+	cm.UID = ""
+	return h.updateConfigmapWithResourceVersion(cm)
+}
+
+// updateConfigmapWithResourceVersion updates the configmap without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the configmap has been changed since it was read.
+func (h *Handler) updateConfigmapWithResourceVersion(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 	namespace := cm.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	cm.ResourceVersion = ""
-	cm.UID = ""
-	return h.clientset.CoreV1().ConfigMaps(namespace).Update(h.ctx, cm, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: cm.Name, Object: cm}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.ConfigMap, error) {
+		return h.clientset.CoreV1().ConfigMaps(namespace).Update(ctx, cm, h.Options.UpdateOptions)
+	})
 }
@@ -1,8 +1,10 @@
 package cronjob
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	batchv1 "k8s.io/api/batch/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*batchv1.CronJob, erro
 
 // applyCronjob
 func (h *Handler) applyCronjob(cj *batchv1.CronJob) (*batchv1.CronJob, error) {
-	_, err := h.createCronjob(cj)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateCronjob(cj)
+	namespace := cj.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return cj, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: cj.Name, Object: cj}
+	return h.intercept(op, func(ctx context.Context, _ string) (*batchv1.CronJob, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createCronjob(cj)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateCronjob(cj)
+		}
+		return cj, err
+	})
 }
@@ -1,10 +1,12 @@
 package cronjob
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createCronjob(cj *batchv1.CronJob) (*batchv1.CronJob, error) {
 	}
 	cj.ResourceVersion = ""
 	cj.UID = ""
-	return h.clientset.BatchV1().CronJobs(namespace).Create(h.ctx, cj, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: cj.Name, Object: cj}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*batchv1.CronJob, error) {
+		return h.clientset.BatchV1().CronJobs(namespace).Create(ctx, cj, h.Options.CreateOptions)
+	})
 }
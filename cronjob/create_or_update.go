@@ -0,0 +1,131 @@
+package cronjob
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing cronjob into it's desired state.
+type MutateFn func(cj *batchv1.CronJob) error
+
+// CreateOrUpdate gets the cronjob from the kubernetes API server. If the
+// cronjob does not exist, it will be created after mutateFn is called.
+// If the cronjob exists, mutateFn is called on the live cronjob and the
+// cronjob will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live cronjob, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The cj must contain the name and namespace(optional) of the cronjob,
+// cj will be overwritten by the live cronjob returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(cj *batchv1.CronJob, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getCronjob(cj)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, cj); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createCronjob(cj)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*cj = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*cj = *existing.DeepCopy()
+	if err := mutate(mutateFn, cj); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, cj) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateCronjobWithResourceVersion(cj)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*cj = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the cronjob already exists,
+// only the difference between the live cronjob and the mutated cronjob
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(cj *batchv1.CronJob, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getCronjob(cj)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, cj); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createCronjob(cj)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*cj = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*cj = *existing.DeepCopy()
+	if err := mutate(mutateFn, cj); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(cj); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, batchv1.CronJob{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*cj = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, cj *batchv1.CronJob) error {
+	name, namespace := cj.GetName(), cj.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(cj); err != nil {
+			return err
+		}
+	}
+	if cj.GetName() != name || cj.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate cronjob name and/or cronjob namespace")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package cronjob
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes cronjob by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.BatchV1().CronJobs(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*batchv1.CronJob, error) {
+		return nil, h.clientset.BatchV1().CronJobs(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes cronjob from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteCronjob(cj *batchv1.CronJob) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.BatchV1().CronJobs(namespace).Delete(h.ctx, cj.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: cj.Name, Object: cj}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*batchv1.CronJob, error) {
+		return nil, h.clientset.BatchV1().CronJobs(namespace).Delete(ctx, cj.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*batchv1.CronJob, error) {
 
 // GetByName gets cronjob by name.
 func (h *Handler) GetByName(name string) (*batchv1.CronJob, error) {
-	return h.clientset.BatchV1().CronJobs(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	cj, err := h.clientset.BatchV1().CronJobs(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return cj, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets cronjob from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getCronjob(cj *batchv1.CronJob) (*batchv1.CronJob, error) {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.BatchV1().CronJobs(namespace).Get(h.ctx, cj.Name, h.Options.GetOptions)
+	newCJ, err := h.clientset.BatchV1().CronJobs(namespace).Get(h.ctx, cj.Name, h.Options.GetOptions)
+	return newCJ, utilerrors.Wrap("get", GVK, namespace, cj.Name, err)
 }
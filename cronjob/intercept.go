@@ -0,0 +1,70 @@
+package cronjob
+
+import (
+	"context"
+
+	"github.com/forbearing/k8s/types"
+	utilerrors "github.com/forbearing/k8s/util/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// WithInterceptors deep copies a new handler and appends the interceptors,
+// the interceptors run around every Create/Update/Apply/Patch/Delete call
+// to the kubernetes API server, see types.Interceptor.
+func (h *Handler) WithInterceptors(interceptors ...types.Interceptor) *Handler {
+	handler := h.DeepCopy()
+	handler.interceptors = append(handler.interceptors, interceptors...)
+	return handler
+}
+
+// WithStrictNamespace deep copies a new handler that only creates/updates/applies/
+// patches/deletes cronjobs in the allowed namespaces, default to the handler
+// namespace. The mode decides whether the cronjob in other namespace is rejected
+// or rewritten to the handler namespace, see types.WithStrictNamespace.
+func (h *Handler) WithStrictNamespace(mode types.NamespaceMode, allowed ...string) *Handler {
+	handler := h.DeepCopy()
+	handler.strictNamespace = types.StrictNamespaceConfig{Mode: mode, Allowed: allowed}
+	return handler
+}
+
+// intercept runs the interceptors around the call to the kubernetes API server,
+// the error returned is wrapped into *utilerrors.OperationError.
+func (h *Handler) intercept(op *types.Operation, call func(ctx context.Context, namespace string) (*batchv1.CronJob, error)) (*batchv1.CronJob, error) {
+	var result *batchv1.CronJob
+	op.GVK = GVK
+	op.DryRun = h.Options.IsDryRun(op.Verb)
+	interceptors := h.interceptors
+	if h.strictNamespace.Mode != types.NamespaceModeDefault {
+		// the strict namespace check runs before all other interceptors.
+		strict := types.StrictNamespace(h.strictNamespace.Mode, h.namespace, h.strictNamespace.Allowed...)
+		interceptors = append([]types.Interceptor{strict}, interceptors...)
+	}
+	// the warnings are collected after all other interceptors.
+	interceptors = append(interceptors[:len(interceptors):len(interceptors)], types.Warnings(h.warnings))
+	err := types.Intercept(h.ctx, interceptors, op, func(ctx context.Context, op *types.Operation) (err error) {
+		if result, err = call(ctx, op.Namespace); result != nil {
+			op.Result = result
+		}
+		return err
+	})
+	return result, utilerrors.Wrap(string(op.Verb), op.GVK, op.Namespace, op.Name, err)
+}
+
+// interceptPatch runs the interceptors around the patch call.
+func (h *Handler) interceptPatch(namespace, name string, patchType k8stypes.PatchType, patchData []byte,
+	call func(ctx context.Context, namespace string) (*batchv1.CronJob, error)) (*batchv1.CronJob, error) {
+	op := &types.Operation{Verb: types.VerbPatch, Namespace: namespace, Name: name, PatchType: patchType, Patch: patchData}
+	return h.intercept(op, call)
+}
+
+// withoutInterceptors returns a copy of the handler that uses ctx and doesn't
+// run the interceptors and warning handler, apply uses it to create or update
+// the cronjob.
+func (h *Handler) withoutInterceptors(ctx context.Context) *Handler {
+	handler := h.DeepCopy()
+	handler.ctx = ctx
+	handler.interceptors = nil
+	handler.warnings = types.WarningConfig{}
+	return handler
+}
@@ -1,6 +1,7 @@
 package cronjob
 
 import (
+	"github.com/forbearing/k8s/util/selector"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -15,13 +16,12 @@ func (h *Handler) List() ([]*batchv1.CronJob, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*batchv1.CronJob, error) {
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.LabelSelector = labels
-	cjList, err := h.clientset.BatchV1().CronJobs(h.namespace).List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
 		return nil, err
 	}
-	return extractList(cjList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.LabelSelector = labels
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByField list cronjobs by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -30,14 +30,12 @@ func (h *Handler) ListByField(field string) ([]*batchv1.CronJob, error) {
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	cjList, err := h.clientset.BatchV1().CronJobs(h.namespace).List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(cjList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByNamespace list all cronjobs in the specified namespace.
@@ -49,12 +47,3 @@ func (h *Handler) ListByNamespace(namespace string) ([]*batchv1.CronJob, error)
 func (h *Handler) ListAll() ([]*batchv1.CronJob, error) {
 	return h.WithNamespace(metav1.NamespaceAll).ListByLabel("")
 }
-
-// extractList
-func extractList(cjList *batchv1.CronJobList) []*batchv1.CronJob {
-	var objList []*batchv1.CronJob
-	for i := range cjList.Items {
-		objList = append(objList, &cjList.Items[i])
-	}
-	return objList
-}
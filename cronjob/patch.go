@@ -1,6 +1,7 @@
 package cronjob
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -117,8 +118,10 @@ func (h *Handler) strategicMergePatch(original *batchv1.CronJob, patchData []byt
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.BatchV1().CronJobs(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*batchv1.CronJob, error) {
+		return h.clientset.BatchV1().CronJobs(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonMergePatch use the "JSON Merge Patch" patch type to patch cronjob.
@@ -140,8 +143,10 @@ func (h *Handler) jsonMergePatch(original *batchv1.CronJob, patchData []byte) (*
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.BatchV1().CronJobs(namespace).
-		Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*batchv1.CronJob, error) {
+		return h.clientset.BatchV1().CronJobs(namespace).
+			Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonPatch use "JSON Patch" patch type to patch cronjob.
@@ -156,8 +161,10 @@ func (h *Handler) jsonPatch(original *batchv1.CronJob, patchData []byte) (*batch
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.BatchV1().CronJobs(namespace).Patch(h.ctx,
-		original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.JSONPatchType, patchData, func(ctx context.Context, namespace string) (*batchv1.CronJob, error) {
+		return h.clientset.BatchV1().CronJobs(namespace).Patch(ctx,
+			original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // diffMergePatch will tak the difference data between original and modified cronjob object,
@@ -190,9 +197,13 @@ func (h *Handler) diffMergePatch(original, modified *batchv1.CronJob, patchOptio
 		namespace = h.namespace
 	}
 	if len(patchOptions) != 0 && patchOptions[0] == types.MergePatchType {
-		return h.clientset.BatchV1().CronJobs(namespace).
-			Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*batchv1.CronJob, error) {
+			return h.clientset.BatchV1().CronJobs(namespace).
+				Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		})
 	}
-	return h.clientset.BatchV1().CronJobs(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*batchv1.CronJob, error) {
+		return h.clientset.BatchV1().CronJobs(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
@@ -1,10 +1,12 @@
 package cronjob
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,12 +94,22 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*batchv1.CronJob, err
 
 // updateCronjob
 func (h *Handler) updateCronjob(cj *batchv1.CronJob) (*batchv1.CronJob, error) {
+	cj.ResourceVersion = ""
+	cj.UID = ""
+	return h.updateCronjobWithResourceVersion(cj)
+}
+
+// updateCronjobWithResourceVersion updates the cronjob without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the cronjob has been changed since it was read.
+func (h *Handler) updateCronjobWithResourceVersion(cj *batchv1.CronJob) (*batchv1.CronJob, error) {
 	namespace := cj.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
 	//// resourceVersion cann't be set, the resourceVersion field is empty.
-	cj.ResourceVersion = ""
-	cj.UID = ""
-	return h.clientset.BatchV1().CronJobs(namespace).Update(h.ctx, cj, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: cj.Name, Object: cj}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*batchv1.CronJob, error) {
+		return h.clientset.BatchV1().CronJobs(namespace).Update(ctx, cj, h.Options.UpdateOptions)
+	})
 }
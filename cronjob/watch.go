@@ -1,7 +1,8 @@
 package cronjob
 
 import (
-	log "github.com/sirupsen/logrus"
+	utilerrors "github.com/forbearing/k8s/util/errors"
+	"github.com/forbearing/k8s/util/selector"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/watch"
@@ -70,6 +71,9 @@ func (h *Handler) WatchByName(name string, addFunc, modifyFunc, deleteFunc func(
 //  * If Event.Type is Error: *api.Status is recommended; other types may make sense
 //    depending on context.
 func (h *Handler) WatchByLabel(labels string, addFunc, modifyFunc, deleteFunc func(obj interface{})) error {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
+		return err
+	}
 	return h.watchCronJob(metav1.ListOptions{LabelSelector: labels, TimeoutSeconds: new(int64)},
 		addFunc, modifyFunc, deleteFunc)
 }
@@ -90,6 +94,9 @@ func (h *Handler) WatchByField(field string, addFunc, modifyFunc, deleteFunc fun
 	if err != nil {
 		return err
 	}
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
+		return err
+	}
 	listOptions := metav1.ListOptions{FieldSelector: fieldSelector.String(), TimeoutSeconds: new(int64)}
 	return h.watchCronJob(listOptions, addFunc, modifyFunc, deleteFunc)
 }
@@ -103,7 +110,7 @@ func (h *Handler) watchCronJob(listOptions metav1.ListOptions,
 	// reconnect to kubernetes API server.
 	for {
 		if watcher, err = h.clientset.BatchV1().CronJobs(h.namespace).Watch(h.ctx, listOptions); err != nil {
-			return err
+			return utilerrors.Wrap("watch", GVK, h.namespace, "", err)
 		}
 		// kubernetes retains the resource event history, which includes this
 		// initial event, so that when our program first start, we are automatically
@@ -118,13 +125,14 @@ func (h *Handler) watchCronJob(listOptions metav1.ListOptions,
 			case watch.Deleted:
 				deleteFunc(event.Object)
 			case watch.Bookmark:
-				log.Debug("watch cronjob: bookmark")
+				h.logger.V(1).Info("watch bookmark", "namespace", h.namespace, "verb", "watch")
 			case watch.Error:
-				log.Debug("watch cronjob: error")
+				h.logger.V(1).Info("watch error", "namespace", h.namespace, "verb", "watch")
 			}
 		}
 		// If event channel is closed, it means the server has closed the connection
-		log.Debug("watch cronjob: reconnect to kubernetes")
+		h.logger.V(1).Info("watch closed, reconnect to kubernetes", "namespace", h.namespace, "verb", "watch")
+		h.observer.WatchReconnected(GVK)
 		watcher.Stop()
 	}
 }
@@ -1,8 +1,10 @@
 package daemonset
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*appsv1.DaemonSet, err
 
 // applyDaemonset
 func (h *Handler) applyDaemonset(ds *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
-	_, err := h.createDaemonset(ds)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateDaemonset(ds)
+	namespace := ds.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return ds, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: ds.Name, Object: ds}
+	return h.intercept(op, func(ctx context.Context, _ string) (*appsv1.DaemonSet, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createDaemonset(ds)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateDaemonset(ds)
+		}
+		return ds, err
+	})
 }
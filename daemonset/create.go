@@ -1,10 +1,12 @@
 package daemonset
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createDaemonset(ds *appsv1.DaemonSet) (*appsv1.DaemonSet, erro
 	}
 	ds.ResourceVersion = ""
 	ds.UID = ""
-	return h.clientset.AppsV1().DaemonSets(namespace).Create(h.ctx, ds, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: ds.Name, Object: ds}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.DaemonSet, error) {
+		return h.clientset.AppsV1().DaemonSets(namespace).Create(ctx, ds, h.Options.CreateOptions)
+	})
 }
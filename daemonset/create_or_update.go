@@ -0,0 +1,131 @@
+package daemonset
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing daemonset into it's desired state.
+type MutateFn func(ds *appsv1.DaemonSet) error
+
+// CreateOrUpdate gets the daemonset from the kubernetes API server. If the
+// daemonset does not exist, it will be created after mutateFn is called.
+// If the daemonset exists, mutateFn is called on the live daemonset and the
+// daemonset will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live daemonset, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The ds must contain the name and namespace(optional) of the daemonset,
+// ds will be overwritten by the live daemonset returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(ds *appsv1.DaemonSet, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getDaemonset(ds)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, ds); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createDaemonset(ds)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*ds = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*ds = *existing.DeepCopy()
+	if err := mutate(mutateFn, ds); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, ds) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateDaemonsetWithResourceVersion(ds)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*ds = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the daemonset already exists,
+// only the difference between the live daemonset and the mutated daemonset
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(ds *appsv1.DaemonSet, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getDaemonset(ds)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, ds); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createDaemonset(ds)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*ds = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*ds = *existing.DeepCopy()
+	if err := mutate(mutateFn, ds); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(ds); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, appsv1.DaemonSet{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*ds = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, ds *appsv1.DaemonSet) error {
+	name, namespace := ds.GetName(), ds.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(ds); err != nil {
+			return err
+		}
+	}
+	if ds.GetName() != name || ds.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate daemonset name and/or daemonset namespace")
+	}
+	return nil
+}
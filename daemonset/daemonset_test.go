@@ -84,7 +84,7 @@ func myerr(t *testing.T, name string, err error) {
 		t.Logf("%s success.", name)
 	}
 }
-func outputPods(t *testing.T, podList []corev1.Pod) {
+func outputPods(t *testing.T, podList []*corev1.Pod) {
 	var pl []string
 	for _, p := range podList {
 		pl = append(pl, p.Name)
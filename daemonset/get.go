@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*appsv1.DaemonSet, error) {
 
 // GetByName gets daemonset by name.
 func (h *Handler) GetByName(name string) (*appsv1.DaemonSet, error) {
-	return h.clientset.AppsV1().DaemonSets(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	ds, err := h.clientset.AppsV1().DaemonSets(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return ds, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets daemonset from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getDaemonset(ds *appsv1.DaemonSet) (*appsv1.DaemonSet, error)
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().DaemonSets(namespace).Get(h.ctx, ds.Name, h.Options.GetOptions)
+	newDS, err := h.clientset.AppsV1().DaemonSets(namespace).Get(h.ctx, ds.Name, h.Options.GetOptions)
+	return newDS, utilerrors.Wrap("get", GVK, namespace, ds.Name, err)
 }
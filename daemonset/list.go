@@ -1,6 +1,7 @@
 package daemonset
 
 import (
+	"github.com/forbearing/k8s/util/selector"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -15,13 +16,12 @@ func (h *Handler) List() ([]*appsv1.DaemonSet, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*appsv1.DaemonSet, error) {
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.LabelSelector = labels
-	dsList, err := h.clientset.AppsV1().DaemonSets(h.namespace).List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
 		return nil, err
 	}
-	return extractList(dsList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.LabelSelector = labels
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByField list daemonsets by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -30,14 +30,12 @@ func (h *Handler) ListByField(field string) ([]*appsv1.DaemonSet, error) {
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	dsList, err := h.clientset.AppsV1().DaemonSets(h.namespace).List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(dsList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByNamespace list all daemonsets in the specified namespace.
@@ -49,12 +47,3 @@ func (h *Handler) ListByNamespace(namespace string) ([]*appsv1.DaemonSet, error)
 func (h *Handler) ListAll() ([]*appsv1.DaemonSet, error) {
 	return h.WithNamespace(metav1.NamespaceAll).ListByLabel("")
 }
-
-// extractList
-func extractList(dsList *appsv1.DaemonSetList) []*appsv1.DaemonSet {
-	var objList []*appsv1.DaemonSet
-	for i := range dsList.Items {
-		objList = append(objList, &dsList.Items[i])
-	}
-	return objList
-}
@@ -0,0 +1,23 @@
+package daemonset
+
+import (
+	"github.com/forbearing/k8s/util/logs"
+	"github.com/forbearing/k8s/util/selector"
+)
+
+// StreamLogs streams the logs of all pods and containers of the daemonset like
+// stern, the lines are prefixed with the pod and container names, see package
+// util/logs. It follows the new pods if opts.Follow is true, eg:
+//
+//	err := handler.WithContext(ctx).StreamLogs(name, &logs.Options{Follow: true, TailLines: &lines})
+func (h *Handler) StreamLogs(name string, opts *logs.Options) error {
+	ds, err := h.Get(name)
+	if err != nil {
+		return err
+	}
+	podSelector, err := selector.ForObject(ds)
+	if err != nil {
+		return err
+	}
+	return logs.Stream(h.ctx, h.clientset, ds.Namespace, podSelector, opts)
+}
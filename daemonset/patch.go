@@ -1,6 +1,7 @@
 package daemonset
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -117,8 +118,10 @@ func (h *Handler) strategicMergePatch(original *appsv1.DaemonSet, patchData []by
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().DaemonSets(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*appsv1.DaemonSet, error) {
+		return h.clientset.AppsV1().DaemonSets(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonMergePatch use the "JSON Merge Patch" patch type to patch daemonset.
@@ -140,8 +143,10 @@ func (h *Handler) jsonMergePatch(original *appsv1.DaemonSet, patchData []byte) (
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().DaemonSets(namespace).
-		Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*appsv1.DaemonSet, error) {
+		return h.clientset.AppsV1().DaemonSets(namespace).
+			Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonPatch use "JSON Patch" patch type to patch daemonset.
@@ -156,8 +161,10 @@ func (h *Handler) jsonPatch(original *appsv1.DaemonSet, patchData []byte) (*apps
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().DaemonSets(namespace).Patch(h.ctx,
-		original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.JSONPatchType, patchData, func(ctx context.Context, namespace string) (*appsv1.DaemonSet, error) {
+		return h.clientset.AppsV1().DaemonSets(namespace).Patch(ctx,
+			original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // diffMergePatch will tak the difference data between original and modified daemonset object,
@@ -190,9 +197,13 @@ func (h *Handler) diffMergePatch(original, modified *appsv1.DaemonSet, patchOpti
 		namespace = h.namespace
 	}
 	if len(patchOptions) != 0 && patchOptions[0] == types.MergePatchType {
-		return h.clientset.AppsV1().DaemonSets(namespace).
-			Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*appsv1.DaemonSet, error) {
+			return h.clientset.AppsV1().DaemonSets(namespace).
+				Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		})
 	}
-	return h.clientset.AppsV1().DaemonSets(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*appsv1.DaemonSet, error) {
+		return h.clientset.AppsV1().DaemonSets(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
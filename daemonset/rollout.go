@@ -0,0 +1,189 @@
+package daemonset
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/forbearing/k8s/util/podtemplate"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ChangeCauseAnnotation records the change cause of the revision.
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+	// RestartedAtAnnotation is the pod template annotation set by Restart,
+	// it's the same as "kubectl rollout restart".
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// Revision is a revision of the daemonset rollout history, it's recorded
+// by the ControllerRevision owned by the daemonset.
+type Revision struct {
+	Revision    int64
+	ChangeCause string
+	// ControllerRevision is the name of the ControllerRevision of the revision.
+	ControllerRevision string
+	Template           corev1.PodTemplateSpec
+
+	// data is the patch stored in the ControllerRevision, undo applies it.
+	data []byte
+}
+
+// RolloutHistory returns the rollout history of the daemonset sorted by
+// revision, it works like "kubectl rollout history daemonset/name".
+func (h *Handler) RolloutHistory(name string) ([]Revision, error) {
+	ds, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return h.rolloutHistory(ds)
+}
+
+func (h *Handler) rolloutHistory(ds *appsv1.DaemonSet) ([]Revision, error) {
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	crList, err := h.clientset.AppsV1().ControllerRevisions(ds.Namespace).List(h.ctx,
+		metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return revisionsOf(ds, crList.Items)
+}
+
+// revisionsOf returns the revisions recorded by the ControllerRevisions owned
+// by the daemonset, sorted by revision.
+func revisionsOf(ds *appsv1.DaemonSet, crs []appsv1.ControllerRevision) ([]Revision, error) {
+	var revisions []Revision
+	for i := range crs {
+		cr := &crs[i]
+		if owner := metav1.GetControllerOf(cr); owner == nil || owner.UID != ds.UID {
+			continue
+		}
+		// the data of the ControllerRevision is a strategic merge patch that
+		// replaces the pod template of the daemonset.
+		patch := &struct {
+			Spec struct {
+				Template corev1.PodTemplateSpec `json:"template"`
+			} `json:"spec"`
+		}{}
+		if err := json.Unmarshal(cr.Data.Raw, patch); err != nil {
+			return nil, fmt.Errorf("controllerrevision %q: %w", cr.Name, err)
+		}
+		revisions = append(revisions, Revision{
+			Revision:           cr.Revision,
+			ChangeCause:        cr.Annotations[ChangeCauseAnnotation],
+			ControllerRevision: cr.Name,
+			Template:           patch.Spec.Template,
+			data:               cr.Data.Raw,
+		})
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision < revisions[j].Revision })
+	return revisions, nil
+}
+
+// RolloutDiff returns what changed in the pod template between the two
+// revisions, zero revision means the latest revision, eg:
+//
+//	diff, err := handler.RolloutDiff("fluentd", 1, 0)
+func (h *Handler) RolloutDiff(name string, from, to int64) (*podtemplate.Diff, error) {
+	revisions, err := h.RolloutHistory(name)
+	if err != nil {
+		return nil, err
+	}
+	fromRevision, err := findRevision(revisions, from)
+	if err != nil {
+		return nil, fmt.Errorf("daemonset %q: %w", name, err)
+	}
+	toRevision, err := findRevision(revisions, to)
+	if err != nil {
+		return nil, fmt.Errorf("daemonset %q: %w", name, err)
+	}
+	return podtemplate.Compare(&fromRevision.Template, &toRevision.Template), nil
+}
+
+// RolloutUndo rolls back the daemonset to the revision, zero revision means
+// the previous revision. It works like "kubectl rollout undo daemonset/name
+// --to-revision=revision". The daemonset is returned unchanged if the pod
+// template of the revision is the same as the current one.
+func (h *Handler) RolloutUndo(name string, revision int64) (*appsv1.DaemonSet, error) {
+	ds, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := h.rolloutHistory(ds)
+	if err != nil {
+		return nil, err
+	}
+	target, err := undoRevision(revisions, revision)
+	if err != nil {
+		return nil, fmt.Errorf("daemonset %q: %w", name, err)
+	}
+	if apiequality.Semantic.DeepEqual(&ds.Spec.Template, &target.Template) {
+		return ds, nil
+	}
+	return h.Patch(ds, target.data, types.StrategicMergePatchType)
+}
+
+// undoRevision returns the revision to roll back to, zero revision means the
+// previous revision.
+func undoRevision(revisions []Revision, revision int64) (*Revision, error) {
+	if revision == 0 {
+		// the previous revision is the one before the latest revision.
+		if len(revisions) < 2 {
+			return nil, fmt.Errorf("no rollout history found")
+		}
+		revision = revisions[len(revisions)-2].Revision
+	}
+	return findRevision(revisions, revision)
+}
+
+// findRevision returns the revision, zero revision means the latest revision.
+func findRevision(revisions []Revision, revision int64) (*Revision, error) {
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("no rollout history found")
+	}
+	if revision == 0 {
+		return &revisions[len(revisions)-1], nil
+	}
+	for i := range revisions {
+		if revisions[i].Revision == revision {
+			return &revisions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find the specified revision %d", revision)
+}
+
+// Restart restarts the pods of the daemonset by setting the restartedAt
+// annotation of the pod template, it works like "kubectl rollout restart".
+func (h *Handler) Restart(name string) (*appsv1.DaemonSet, error) {
+	ds, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return h.patch(ds, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{RestartedAtAnnotation: time.Now().Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+}
+
+// patch marshals the patch and patches the daemonset by strategic merge patch.
+func (h *Handler) patch(ds *appsv1.DaemonSet, patch interface{}) (*appsv1.DaemonSet, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return h.Patch(ds, data, types.StrategicMergePatchType)
+}
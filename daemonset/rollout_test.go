@@ -0,0 +1,114 @@
+package daemonset
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTemplate(image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "fluentd"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "fluentd", Image: image}}},
+	}
+}
+
+// newControllerRevision returns the ControllerRevision like the daemonset
+// controller, the data is a strategic merge patch that replaces the pod template.
+func newControllerRevision(t *testing.T, owner types.UID, revision int64, template corev1.PodTemplateSpec) appsv1.ControllerRevision {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data["$patch"] = "replace"
+	raw, err := json.Marshal(map[string]interface{}{"spec": map[string]interface{}{"template": data}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	controller := true
+	return appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "fluentd-" + template.Spec.Containers[0].Image,
+			Namespace:       "test",
+			Annotations:     map[string]string{ChangeCauseAnnotation: "set image " + template.Spec.Containers[0].Image},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "fluentd", UID: owner, Controller: &controller}},
+		},
+		Data:     runtime.RawExtension{Raw: raw},
+		Revision: revision,
+	}
+}
+
+func TestRolloutUndoPatch(t *testing.T) {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "fluentd", Namespace: "test", UID: "fluentd-uid"},
+		Spec:       appsv1.DaemonSetSpec{Template: newTemplate("fluentd:v3")},
+	}
+	// the fields that don't exist in the pod template of the revision are removed by undo.
+	ds.Spec.Template.Annotations = map[string]string{RestartedAtAnnotation: "2022-07-01T00:00:00Z"}
+	crs := []appsv1.ControllerRevision{
+		newControllerRevision(t, ds.UID, 3, ds.Spec.Template),
+		newControllerRevision(t, ds.UID, 1, newTemplate("fluentd:v1")),
+		newControllerRevision(t, ds.UID, 2, newTemplate("fluentd:v2")),
+		// the ControllerRevision owned by other daemonset is ignored.
+		newControllerRevision(t, "other-uid", 4, newTemplate("fluentd:v4")),
+	}
+	revisions, err := revisionsOf(ds, crs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(revisions))
+	}
+	for i, revision := range revisions {
+		if revision.Revision != int64(i+1) {
+			t.Fatalf("expected revisions sorted, got revision %d at %d", revision.Revision, i)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		revisions []Revision
+		revision  int64
+		image     string
+		wantErr   bool
+	}{
+		{name: "previous revision", revisions: revisions, image: "fluentd:v2"},
+		{name: "specified revision", revisions: revisions, revision: 1, image: "fluentd:v1"},
+		{name: "specified revision not found", revisions: revisions, revision: 5, wantErr: true},
+		{name: "no previous revision", revisions: revisions[2:], wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target, err := undoRevision(test.revisions, test.revision)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got revision %d", target.Revision)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the fake clientset applies the strategic merge patch stored in the
+			// ControllerRevision like the kubernetes API server.
+			client := fake.NewSimpleClientset(ds).AppsV1().DaemonSets("test")
+			patched, err := client.Patch(context.TODO(), "fluentd", types.StrategicMergePatchType, target.data, metav1.PatchOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := newTemplate(test.image)
+			if !apiequality.Semantic.DeepEqual(patched.Spec.Template, expected) {
+				t.Errorf("expected pod template %v, got %v", expected, patched.Spec.Template)
+			}
+		})
+	}
+}
@@ -1,140 +1,72 @@
 package daemonset
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
-	log "github.com/sirupsen/logrus"
+	utilwait "github.com/forbearing/k8s/util/wait"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // IsReady check if the daemonset is ready.
-// ref: https://github.com/kubernetes/kubernetes/blob/a1128e380c2cf1c2d7443694673d9f1dd63eb518/staging/src/k8s.io/kubectl/pkg/polymorphichelpers/rollout_status.go#L95
 func (h *Handler) IsReady(name string) bool {
-	checkGeneration := func(ds *appsv1.DaemonSet) bool {
-		if ds.Generation != ds.Status.ObservedGeneration {
-			return false
-		}
-		return true
-	}
-	checkReplicas := func(ds *appsv1.DaemonSet) bool {
-		if ds.Status.DesiredNumberScheduled != ds.Status.UpdatedNumberScheduled {
-			return false
-		}
-		if ds.Status.DesiredNumberScheduled != ds.Status.CurrentNumberScheduled {
-			return false
-		}
-		if ds.Status.DesiredNumberScheduled != ds.Status.NumberAvailable {
-			return false
-		}
-		if ds.Status.DesiredNumberScheduled != ds.Status.NumberReady {
-			return false
-		}
-		return true
-	}
-
 	ds, err := h.Get(name)
 	if err != nil {
 		return false
 	}
-	if checkGeneration(ds) && checkReplicas(ds) {
-		return true
-	}
-	return false
+	ready, _, _ := readiness(ds)
+	return ready
 }
 
-// WaitReady waiting for the daemonset to be in the ready status.
-func (h *Handler) WaitReady(name string) error {
-	if h.IsReady(name) {
-		return nil
+// WaitReady waits for the daemonset to be in the ready status. It watches the
+// daemonset and honours the handler context, use WithContext() to pass the
+// per-call context, and utilwait.WithTimeout/WithProgress to set the timeout
+// and to report why the daemonset is not ready yet, eg:
+//
+//	err := handler.WithContext(ctx).WaitReady(name, utilwait.WithTimeout(5*time.Minute))
+//
+// WaitReady returns the NotFound error immediately if the daemonset doesn't
+// exist. Without utilwait.WithTimeout it waits until the handler context is
+// done, so always pass a timeout unless the context has a deadline.
+//
+// The *utilwait.TimeoutError returned contains the reason why the daemonset is
+// not ready.
+func (h *Handler) WaitReady(name string, opts ...utilwait.Option) error {
+	// the wait by name waits for the daemonset to be created, get it first so a
+	// missing daemonset doesn't block until timeout.
+	if _, err := h.Get(name); err != nil {
+		return err
 	}
-
-	errCh := make(chan error, 1)
-	chkCh := make(chan struct{}, 1)
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL, syscall.SIGQUIT)
-	ctxCheck, cancelCheck := context.WithCancel(h.ctx)
-	ctxWatch, cancelWatch := context.WithCancel(h.ctx)
-	defer cancelCheck()
-	defer cancelWatch()
-
-	// the goroutine used to check whether daemonset is ready and exists.
-	// if daemonset already ready, return nil.
-	// if daemonset does not exist, return error.
-	// if daemonset exists but not ready, return nothing.
-	go func(context.Context) {
-		<-chkCh
-		for i := 0; i < 6; i++ {
-			// daemonset is already ready, return nil
-			if h.IsReady(name) {
-				errCh <- nil
-				return
-			}
-			// daemonset no longer exists, return err
-			_, err := h.Get(name)
-			if k8serrors.IsNotFound(err) {
-				errCh <- err
-				return
-			}
-			// daemonset exists but not ready, return nothing.
-			if err == nil {
-				return
-			}
-			log.Error(err)
-			time.Sleep(time.Second * 10)
+	cond := utilwait.Condition{Name: "ready", Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		ds := &appsv1.DaemonSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), ds); err != nil {
+			return false, "", err
 		}
-	}(ctxCheck)
-
-	// this goroutine used to watch daemonset.
-	go func(ctx context.Context) {
-		for {
-			timeout := int64(0)
-			listOptions := metav1.SingleObject(metav1.ObjectMeta{Name: name, Namespace: h.namespace})
-			listOptions.TimeoutSeconds = &timeout
-			watcher, err := h.clientset.AppsV1().DaemonSets(h.namespace).Watch(h.ctx, listOptions)
-			if err != nil {
-				errCh <- err
-				return
-			}
-			chkCh <- struct{}{}
-			for event := range watcher.ResultChan() {
-				switch event.Type {
-				case watch.Modified:
-					if h.IsReady(name) {
-						watcher.Stop()
-						errCh <- nil
-						return
-					}
-				case watch.Deleted:
-					watcher.Stop()
-					errCh <- fmt.Errorf("daemonset/%s was deleted", name)
-					return
-				case watch.Bookmark:
-					log.Debug("watch daemonset: bookmark")
-				case watch.Error:
-					log.Debug("watch daemonset: error")
-				}
-			}
-			// If event channel is closed, it means the kube-apiserver has closed the connection.
-			log.Debug("watch daemonset: reconnect to kubernetes")
-			watcher.Stop()
-		}
-	}(ctxWatch)
+		return readiness(ds)
+	}}
+	return h.waitFor(utilwait.Target{Name: name}, cond, opts...)
+}
 
-	select {
-	case sig := <-sigCh:
-		return fmt.Errorf("cancelled by signal: %s", sig.String())
-	case err := <-errCh:
-		return err
+// readiness returns whether the daemonset is ready and the reason if it's not.
+func readiness(ds *appsv1.DaemonSet) (bool, string, error) {
+	if ds.Generation != ds.Status.ObservedGeneration {
+		return false, "waiting for the daemonset spec update to be observed", nil
+	}
+	desired := ds.Status.DesiredNumberScheduled
+	switch {
+	case ds.Status.UpdatedNumberScheduled != desired:
+		return false, fmt.Sprintf("%d/%d pods updated", ds.Status.UpdatedNumberScheduled, desired), nil
+	case ds.Status.CurrentNumberScheduled != desired:
+		return false, fmt.Sprintf("%d/%d pods scheduled", ds.Status.CurrentNumberScheduled, desired), nil
+	case ds.Status.NumberReady != desired:
+		return false, fmt.Sprintf("%d/%d pods ready", ds.Status.NumberReady, desired), nil
+	case ds.Status.NumberAvailable != desired:
+		return false, fmt.Sprintf("%d/%d pods available", ds.Status.NumberAvailable, desired), nil
 	}
+	return true, "", nil
 }
 
 //// WaitReady wait the daemonset to be th ready status.
@@ -1,10 +1,12 @@
 package daemonset
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*appsv1.DaemonSet, er
 
 // updateDaemonset
 func (h *Handler) updateDaemonset(ds *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
+	ds.ResourceVersion = ""
+	ds.UID = ""
+	return h.updateDaemonsetWithResourceVersion(ds)
+}
+
+// updateDaemonsetWithResourceVersion updates the daemonset without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the daemonset has been changed since it was read.
+func (h *Handler) updateDaemonsetWithResourceVersion(ds *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
 	namespace := ds.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	ds.ResourceVersion = ""
-	ds.UID = ""
-	return h.clientset.AppsV1().DaemonSets(namespace).Update(h.ctx, ds, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: ds.Name, Object: ds}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.DaemonSet, error) {
+		return h.clientset.AppsV1().DaemonSets(namespace).Update(ctx, ds, h.Options.UpdateOptions)
+	})
 }
@@ -7,14 +7,24 @@ import (
 	"regexp"
 
 	utilerrors "github.com/forbearing/k8s/util/errors"
-	"github.com/sirupsen/logrus"
+	"github.com/forbearing/k8s/util/tracing"
+	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // DeleteF work like "kubectl delete -f filename.yaml -n test",
 // The namespace defined in yaml have higher precedence than namespace specified here.
-func DeleteF(ctx context.Context, kubeconfig, filename string, namespace string, opts ...Options) error {
-	handler, err := New(ctx, kubeconfig, namespace)
+// The logger stored in ctx by logr.NewContext() is used to output logs.
+// If ctx carries a trace span, DeleteF creates a child span and every k8s
+// resource in the yaml file is traced as a child span of it.
+// The warnings returned by the API server are logged and collected by the
+// collector stored in ctx, see types.CollectWarnings.
+func DeleteF(ctx context.Context, kubeconfig, filename string, namespace string, opts ...Options) (err error) {
+	ctx, span := tracing.StartSpan(ctx, nil, "DeleteF", tracing.FilenameKey.String(filename))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	logger := logr.FromContextOrDiscard(ctx)
+	handler, err := New(ctx, kubeconfig, namespace, handlerOptions(logger, opts)...)
 	if err != nil {
 		return err
 	}
@@ -55,13 +65,14 @@ func DeleteF(ctx context.Context, kubeconfig, filename string, namespace string,
 		// You can call DeleteF() with IgnoreNotFound option to ignore the "NotFound" error.
 		// A "NotFound" error will occurrs when you delete k8s resource that no longer exist in cluster.
 		if err != nil && apierrors.IsNotFound(err) {
-			logrus.Error(err)
+			logger.Error(err, "failed to delete k8s resource", objectKeysAndValues("delete", item)...)
 			continue
 		}
 		// Unexpected error, return it.
 		if err != nil {
 			return err
 		}
+		logger.V(1).Info("k8s resource deleted", objectKeysAndValues("delete", item)...)
 	}
 
 	return nil
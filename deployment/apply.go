@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -96,11 +96,20 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*appsv1.Deployment, er
 
 // applyDeployment
 func (h *Handler) applyDeployment(deploy *appsv1.Deployment) (*appsv1.Deployment, error) {
-	_, err := h.createDeployment(deploy)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateDeployment(deploy)
+	namespace := deploy.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return deploy, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: deploy.Name, Object: deploy}
+	return h.intercept(op, func(ctx context.Context, _ string) (*appsv1.Deployment, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createDeployment(deploy)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateDeployment(deploy)
+		}
+		return deploy, err
+	})
 }
 
 // Don't Use This Method, Just for Testzng, May Be Removed.
@@ -155,8 +164,7 @@ func (h *Handler) __Apply(filename string) (deploy *appsv1.Deployment, err error
 		// json serializer runtime.Serializer --> runtime.Object, *schema.GroupVersionKind
 		object, gvk, err := serializeryaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme).Decode(rawObject.Raw, nil, nil)
 		if err != nil {
-			log.Error("NewDecodingSerializer error")
-			log.Error(err)
+			h.logger.Error(err, "NewDecodingSerializer failed", "verb", "apply")
 			return nil, err
 		}
 		// runtime.Object --> map[string]interface{}
@@ -172,8 +180,7 @@ func (h *Handler) __Apply(filename string) (deploy *appsv1.Deployment, err error
 		// DiscoveryInterface / DiscoveryClient --> []*APIGroupResources
 		apiGroupResources, err := restmapper.GetAPIGroupResources(h.clientset.Discovery())
 		if err != nil {
-			log.Error("GetAPIGroupResources error")
-			log.Error(err)
+			h.logger.Error(err, "GetAPIGroupResources failed", "verb", "apply")
 			return nil, err
 		}
 
@@ -186,8 +193,7 @@ func (h *Handler) __Apply(filename string) (deploy *appsv1.Deployment, err error
 		// RESTMapping identifies a preferred resource mapping for the provided group kind.
 		restMapping, err := restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 		if err != nil {
-			log.Error("RESTMapping error")
-			log.Error(err)
+			h.logger.Error(err, "RESTMapping failed", "verb", "apply")
 			return nil, err
 		}
 
@@ -207,15 +213,13 @@ func (h *Handler) __Apply(filename string) (deploy *appsv1.Deployment, err error
 			_, err = dri.Update(context.Background(), unstructuredObj, metav1.UpdateOptions{})
 		}
 		if err != nil {
-			log.Error("DynamicResourceInterface Apply error")
-			log.Error(err)
+			h.logger.Error(err, "DynamicResourceInterface Apply failed", "verb", "apply")
 			return nil, err
 		}
 	}
 
 	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj.UnstructuredContent(), deploy); err != nil {
-		log.Error("FromUnstructured error")
-		log.Error(err)
+		h.logger.Error(err, "FromUnstructured failed", "verb", "apply")
 		return nil, err
 	}
 	return deploy, nil
@@ -1,10 +1,12 @@
 package deployment
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -109,5 +111,8 @@ func (h *Handler) createDeployment(deploy *appsv1.Deployment) (*appsv1.Deploymen
 	// "resourceVersion should not be set on objects to be created" will be returned.
 	deploy.ResourceVersion = ""
 	deploy.UID = ""
-	return h.clientset.AppsV1().Deployments(namespace).Create(h.ctx, deploy, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: deploy.Name, Object: deploy}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+		return h.clientset.AppsV1().Deployments(namespace).Create(ctx, deploy, h.Options.CreateOptions)
+	})
 }
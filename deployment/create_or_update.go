@@ -0,0 +1,131 @@
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing deployment into it's desired state.
+type MutateFn func(deploy *appsv1.Deployment) error
+
+// CreateOrUpdate gets the deployment from the kubernetes API server. If the
+// deployment does not exist, it will be created after mutateFn is called.
+// If the deployment exists, mutateFn is called on the live deployment and the
+// deployment will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live deployment, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The deploy must contain the name and namespace(optional) of the deployment,
+// deploy will be overwritten by the live deployment returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(deploy *appsv1.Deployment, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getDeployment(deploy)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, deploy); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createDeployment(deploy)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*deploy = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*deploy = *existing.DeepCopy()
+	if err := mutate(mutateFn, deploy); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, deploy) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateDeploymentWithResourceVersion(deploy)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*deploy = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the deployment already exists,
+// only the difference between the live deployment and the mutated deployment
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(deploy *appsv1.Deployment, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getDeployment(deploy)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, deploy); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createDeployment(deploy)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*deploy = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*deploy = *existing.DeepCopy()
+	if err := mutate(mutateFn, deploy); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(deploy); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, appsv1.Deployment{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*deploy = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, deploy *appsv1.Deployment) error {
+	name, namespace := deploy.GetName(), deploy.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(deploy); err != nil {
+			return err
+		}
+	}
+	if deploy.GetName() != name || deploy.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate deployment name and/or deployment namespace")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package deployment
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes deployment by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.AppsV1().Deployments(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+		return nil, h.clientset.AppsV1().Deployments(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes deployment from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteDeployment(deploy *appsv1.Deployment) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().Deployments(namespace).Delete(h.ctx, deploy.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: deploy.Name, Object: deploy}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+		return nil, h.clientset.AppsV1().Deployments(namespace).Delete(ctx, deploy.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/forbearing/k8s/types"
 	"github.com/forbearing/k8s/util/client"
+	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -24,6 +25,12 @@ type Handler struct {
 	ctx        context.Context
 	kubeconfig string
 	namespace  string
+	logger     logr.Logger
+	observer   types.Observer
+
+	interceptors    []types.Interceptor
+	strictNamespace types.StrictNamespaceConfig
+	warnings        types.WarningConfig
 
 	config          *rest.Config
 	httpClient      *http.Client
@@ -44,8 +51,8 @@ type Handler struct {
 
 // NewOrDie simply call New() to get a deployment handler.
 // panic if there is any error occurs.
-func NewOrDie(ctx context.Context, kubeconfig, namespace string) *Handler {
-	handler, err := New(ctx, kubeconfig, namespace)
+func NewOrDie(ctx context.Context, kubeconfig, namespace string, opts ...types.HandlerOption) *Handler {
+	handler, err := New(ctx, kubeconfig, namespace, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -58,7 +65,7 @@ func NewOrDie(ctx context.Context, kubeconfig, namespace string) *Handler {
 // * KUBECONFIG environment variable pointing at a file.
 // * $HOME/.kube/config if exists.
 // * In-cluster config if running in cluster.
-func New(ctx context.Context, kubeconfig, namespace string) (*Handler, error) {
+func New(ctx context.Context, kubeconfig, namespace string, opts ...types.HandlerOption) (*Handler, error) {
 	var (
 		err             error
 		config          *rest.Config
@@ -78,6 +85,12 @@ func New(ctx context.Context, kubeconfig, namespace string) (*Handler, error) {
 	if config, err = client.RESTConfig(kubeconfig); err != nil {
 		return nil, err
 	}
+	// the logger stored in ctx is used if no logger option is passed.
+	handlerConfig := types.NewHandlerConfig(ctx, opts...)
+	// wrap the http transport, such like collecting metrics.
+	for _, wrap := range handlerConfig.TransportWrappers {
+		config.Wrap(wrap)
+	}
 	// setup APIPath, GroupVersion and NegotiatedSerializer before initializing a RESTClient
 	config.APIPath = "api"
 	config.GroupVersion = &appsv1.SchemeGroupVersion
@@ -119,6 +132,11 @@ func New(ctx context.Context, kubeconfig, namespace string) (*Handler, error) {
 
 	return &Handler{
 		ctx:             ctx,
+		logger:          handlerConfig.Logger.WithValues("gvk", GVK.String()),
+		observer:        handlerConfig.Observer,
+		interceptors:    handlerConfig.Interceptors,
+		strictNamespace: handlerConfig.StrictNamespace,
+		warnings:        handlerConfig.Warnings,
 		kubeconfig:      kubeconfig,
 		namespace:       namespace,
 		config:          config,
@@ -140,6 +158,15 @@ func (h *Handler) WithNamespace(namespace string) *Handler {
 	return handler
 }
 
+// WithContext deep copies a new handler that uses ctx to send requests to the
+// kubernetes API server, such like the per-call deadline and trace span,
+// eg: handler.WithContext(ctx).Create(filename).
+func (h *Handler) WithContext(ctx context.Context) *Handler {
+	handler := h.DeepCopy()
+	handler.ctx = ctx
+	return handler
+}
+
 // WithDryRun deep copies a new handler and prints the create/update/apply/delete
 // operations, without sending it to apiserver.
 func (h *Handler) WithDryRun() *Handler {
@@ -157,6 +184,11 @@ func (in *Handler) DeepCopy() *Handler {
 	}
 	return &Handler{
 		ctx:              in.ctx,
+		logger:           in.logger,
+		observer:         in.observer,
+		interceptors:     append([]types.Interceptor(nil), in.interceptors...),
+		strictNamespace:  in.strictNamespace,
+		warnings:         in.warnings,
 		kubeconfig:       in.kubeconfig,
 		namespace:        in.namespace,
 		config:           in.config,
@@ -191,6 +223,10 @@ func (h *Handler) SetTimeout(timeout int64) {
 	defer h.l.Unlock()
 	h.Options.ListOptions.TimeoutSeconds = &timeout
 }
+
+// SetLimit sets the number of deployments requested from the kubernetes API
+// server per page. List methods always follow the continue token and return
+// all deployments, ListPages() and ListIter() return them page by page.
 func (h *Handler) SetLimit(limit int64) {
 	h.l.Lock()
 	defer h.l.Unlock()
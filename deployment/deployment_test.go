@@ -143,8 +143,8 @@ func testCreateDeployment(t *testing.T) {
 	handler.DeleteFromFile(filename)
 
 	handler.Delete(name2)
-	_, err = handler.CreateFromRaw(rawData2)
-	myerr(t, "CreateFromRaw", err)
+	_, err = handler.CreateFromMap(rawData2)
+	myerr(t, "CreateFromMap", err)
 	handler.Delete(name2)
 }
 
@@ -172,8 +172,8 @@ func testUpdateDeployment(t *testing.T) {
 	_, err = handler.UpdateFromBytes(data)
 	myerr(t, "UpdateFromBytes", err)
 
-	_, err = handler.UpdateFromRaw(rawData1)
-	myerr(t, "UpdateFromRaw", err)
+	_, err = handler.UpdateFromMap(rawData1)
+	myerr(t, "UpdateFromMap", err)
 	handler.Delete(deploy.Name)
 }
 
@@ -210,11 +210,11 @@ func testApplyDeployment(t *testing.T) {
 	_, err = handler.ApplyFromBytes(data)
 	myerr(t, "ApplyFromBytes", err)
 
-	deploy, err = handler.ApplyFromRaw(rawData2)
-	myerr(t, "ApplyFromRaw", err)
+	deploy, err = handler.ApplyFromMap(rawData2)
+	myerr(t, "ApplyFromMap", err)
 	fmt.Println(deploy.Name)
-	deploy, err = handler.ApplyFromRaw(rawData2)
-	myerr(t, "ApplyFromRaw", err)
+	deploy, err = handler.ApplyFromMap(rawData2)
+	myerr(t, "ApplyFromMap", err)
 	handler.Delete(deploy.Name)
 
 }
@@ -288,7 +288,7 @@ func testListDeployment(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	deployList1, err := handler.List(label)
+	deployList1, err := handler.List()
 	myerr(t, "List", err)
 	outputDeploy(t, deployList1)
 
@@ -330,7 +330,7 @@ func testWatchDeployment(t *testing.T) {
 		timer := time.NewTimer(time.Second * 10)
 
 		go func(ctx context.Context) {
-			err = handler.Watch(deploy.Name, addFunc, modifyFunc, deleteFunc, nil)
+			err = handler.Watch(addFunc, modifyFunc, deleteFunc)
 			myerr(t, "Watch", err)
 		}(ctx)
 		go func(ctx context.Context) {
@@ -350,7 +350,7 @@ func testWatchDeployment(t *testing.T) {
 		timer := time.NewTimer(time.Second * 10)
 
 		go func(ctx context.Context) {
-			err = handler.WatchByName(deploy.Name, addFunc, modifyFunc, deleteFunc, nil)
+			err = handler.WatchByName(deploy.Name, addFunc, modifyFunc, deleteFunc)
 			myerr(t, "Watch", err)
 		}(ctx)
 		go func(ctx context.Context) {
@@ -370,7 +370,7 @@ func testWatchDeployment(t *testing.T) {
 		timer := time.NewTimer(time.Second * 10)
 
 		go func(ctx context.Context) {
-			err = handler.WatchByLabel(label, addFunc, modifyFunc, deleteFunc, nil)
+			err = handler.WatchByLabel(label, addFunc, modifyFunc, deleteFunc)
 			myerr(t, "Watch", err)
 		}(ctx)
 		go func(ctx context.Context) {
@@ -435,21 +435,21 @@ func myerr(t *testing.T, name string, err error) {
 		t.Logf("%s success.", name)
 	}
 }
-func outputDeploy(t *testing.T, deployList *appsv1.DeploymentList) {
+func outputDeploy(t *testing.T, deployList []*appsv1.Deployment) {
 	var dl []string
-	for _, deploy := range deployList.Items {
+	for _, deploy := range deployList {
 		dl = append(dl, deploy.Name)
 	}
 	t.Log(dl)
 }
-func outputRS(t *testing.T, rsList []appsv1.ReplicaSet) {
+func outputRS(t *testing.T, rsList []*appsv1.ReplicaSet) {
 	var rl []string
 	for _, r := range rsList {
 		rl = append(rl, r.Name)
 	}
 	t.Log(rl)
 }
-func outputPods(t *testing.T, podList []corev1.Pod) {
+func outputPods(t *testing.T, podList []*corev1.Pod) {
 	var pl []string
 	for _, p := range podList {
 		pl = append(pl, p.Name)
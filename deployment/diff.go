@@ -0,0 +1,85 @@
+package deployment
+
+import (
+	"fmt"
+
+	"github.com/forbearing/k8s/util/podtemplate"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// RevisionDiff returns what changed in the pod template between the two
+// revisions of the deployment, such like images, env, resources, probes and
+// volumes. The object can be the deployment name, *appsv1.Deployment or
+// appsv1.Deployment. Zero "to" means the current revision, zero "from" means
+// the revision before "to", eg:
+//
+//	diff, err := handler.RevisionDiff("nginx", 0, 0) // current vs previous
+//	fmt.Println(diff)
+func (h *Handler) RevisionDiff(object interface{}, from, to int64) (*podtemplate.Diff, error) {
+	var deploy *appsv1.Deployment
+	switch val := object.(type) {
+	case string:
+		var err error
+		if deploy, err = h.Get(val); err != nil {
+			return nil, err
+		}
+	case *appsv1.Deployment:
+		deploy = val
+	case appsv1.Deployment:
+		deploy = &val
+	default:
+		return nil, ErrInvalidToolsType
+	}
+
+	revisions, err := h.rolloutHistory(deploy)
+	if err != nil {
+		return nil, err
+	}
+	if to == 0 {
+		if to, err = revisionOf(deploy.Annotations); err != nil {
+			return nil, err
+		}
+	}
+	toRevision, err := findRevision(revisions, to, "")
+	if err != nil {
+		return nil, fmt.Errorf("deployment %q: %w", deploy.Name, err)
+	}
+	var fromRevision *Revision
+	if from == 0 {
+		// revisions are sorted, find the latest one before "to".
+		for i := range revisions {
+			if revisions[i].Revision < toRevision.Revision {
+				fromRevision = &revisions[i]
+			}
+		}
+		if fromRevision == nil {
+			return nil, fmt.Errorf("deployment %q: no revision before revision %d", deploy.Name, toRevision.Revision)
+		}
+	} else if fromRevision, err = findRevision(revisions, from, ""); err != nil {
+		return nil, fmt.Errorf("deployment %q: %w", deploy.Name, err)
+	}
+	return podtemplate.Compare(&fromRevision.Template, &toRevision.Template), nil
+}
+
+// PredictRollout reports whether applying the deployment would trigger a new
+// rollout, and returns what would change in the pod template. The object can
+// be any type accepted by Apply(). The deployment is applied with server-side
+// dry run, so the defaults set by the kubernetes API server and admission
+// webhooks don't cause false changes. A deployment that doesn't exist always
+// triggers a rollout. A paused deployment starts the rollout once it's resumed.
+func (h *Handler) PredictRollout(object interface{}) (bool, *podtemplate.Diff, error) {
+	modified, err := h.WithDryRun().Apply(object)
+	if err != nil {
+		return false, nil, err
+	}
+	current, err := h.WithNamespace(modified.Namespace).Get(modified.Name)
+	if apierrors.IsNotFound(err) {
+		return true, podtemplate.Compare(nil, &modified.Spec.Template), nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	diff := podtemplate.Compare(&current.Spec.Template, &modified.Spec.Template)
+	return !diff.Empty(), diff, nil
+}
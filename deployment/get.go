@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*appsv1.Deployment, error) {
 
 // GetByName gets deployment by name.
 func (h *Handler) GetByName(name string) (*appsv1.Deployment, error) {
-	return h.clientset.AppsV1().Deployments(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	deploy, err := h.clientset.AppsV1().Deployments(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return deploy, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets deployment from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getDeployment(deploy *appsv1.Deployment) (*appsv1.Deployment,
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().Deployments(namespace).Get(h.ctx, deploy.Name, h.Options.GetOptions)
+	newDeploy, err := h.clientset.AppsV1().Deployments(namespace).Get(h.ctx, deploy.Name, h.Options.GetOptions)
+	return newDeploy, utilerrors.Wrap("get", GVK, namespace, deploy.Name, err)
 }
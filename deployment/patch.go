@@ -1,6 +1,7 @@
 package deployment
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -143,8 +144,10 @@ func (h *Handler) strategicMergePatch(original *appsv1.Deployment, patchData []b
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().Deployments(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+		return h.clientset.AppsV1().Deployments(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonMergePatch use the "JSON Merge Patch" patch type to patch deployment.
@@ -166,8 +169,10 @@ func (h *Handler) jsonMergePatch(original *appsv1.Deployment, patchData []byte)
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().Deployments(namespace).
-		Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+		return h.clientset.AppsV1().Deployments(namespace).
+			Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonPatch use "JSON Patch" patch type to patch deployment.
@@ -182,8 +187,10 @@ func (h *Handler) jsonPatch(original *appsv1.Deployment, patchData []byte) (*app
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().Deployments(namespace).Patch(h.ctx,
-		original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.JSONPatchType, patchData, func(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+		return h.clientset.AppsV1().Deployments(namespace).Patch(ctx,
+			original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // diffMergePatch will tak the difference data between original and modified deployment object,
@@ -216,9 +223,13 @@ func (h *Handler) diffMergePatch(original, modified *appsv1.Deployment, patchOpt
 		namespace = h.namespace
 	}
 	if len(patchOptions) != 0 && patchOptions[0] == types.MergePatchType {
-		return h.clientset.AppsV1().Deployments(namespace).
-			Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+			return h.clientset.AppsV1().Deployments(namespace).
+				Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		})
 	}
-	return h.clientset.AppsV1().Deployments(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+		return h.clientset.AppsV1().Deployments(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
@@ -0,0 +1,33 @@
+package deployment
+
+import (
+	"github.com/forbearing/k8s/util/portforward"
+	"github.com/forbearing/k8s/util/selector"
+)
+
+// ForwardPorts forwards the local ports to a running and ready pod of the
+// deployment in background, it returns after the ports are forwarded. Set
+// opts.Reconnect to forward to another pod when the pod is replaced, such
+// like during a rollout, see package util/portforward. The forwarding stops
+// when the handler context is done or the forwarder is closed, eg:
+//
+//	f, err := handler.ForwardPorts("nginx", []string{"8080:http"}, &portforward.Options{Reconnect: true})
+//	defer f.Close()
+func (h *Handler) ForwardPorts(name string, ports []string, opts *portforward.Options) (*portforward.Forwarder, error) {
+	deploy, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	podSelector, err := selector.ForObject(deploy)
+	if err != nil {
+		return nil, err
+	}
+	f, err := portforward.Forward(h.ctx, h.config, h.clientset, deploy.Namespace, &portforward.Target{Selector: podSelector}, ports, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.WaitReady(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,274 @@
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// RevisionAnnotation is the revision annotation of the deployment and its
+	// replicasets, it's maintained by the deployment controller.
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+	// ChangeCauseAnnotation records the change cause of the revision.
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+	// RestartedAtAnnotation is the pod template annotation set by Restart,
+	// it's the same as "kubectl rollout restart".
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// Revision is a revision of the deployment rollout history.
+type Revision struct {
+	Revision    int64
+	ChangeCause string
+	// ReplicaSet is the name of the replicaset of the revision.
+	ReplicaSet string
+	Template   corev1.PodTemplateSpec
+}
+
+// RolloutStatus is the rollout status of the deployment, it works like
+// "kubectl rollout status".
+type RolloutStatus struct {
+	Revision          int64
+	Replicas          int32
+	UpdatedReplicas   int32
+	ReadyReplicas     int32
+	AvailableReplicas int32
+	Paused            bool
+	// Done is true if the rollout is complete.
+	Done bool
+	// Message describes the rollout progress, such like
+	// `Waiting for deployment "nginx" rollout to finish: 1 of 3 updated replicas are available...`
+	Message string
+}
+
+// RolloutHistory returns the rollout history of the deployment sorted by
+// revision, it works like "kubectl rollout history deployment/name".
+func (h *Handler) RolloutHistory(name string) ([]Revision, error) {
+	deploy, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return h.rolloutHistory(deploy)
+}
+
+func (h *Handler) rolloutHistory(deploy *appsv1.Deployment) ([]Revision, error) {
+	rsList, err := h.getRS(deploy)
+	if err != nil {
+		return nil, err
+	}
+	var revisions []Revision
+	for _, rs := range rsList {
+		revision, err := revisionOf(rs.Annotations)
+		if err != nil {
+			return nil, fmt.Errorf("replicaset %q: %w", rs.Name, err)
+		}
+		template := *rs.Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		revisions = append(revisions, Revision{
+			Revision:    revision,
+			ChangeCause: rs.Annotations[ChangeCauseAnnotation],
+			ReplicaSet:  rs.Name,
+			Template:    template,
+		})
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision < revisions[j].Revision })
+	return revisions, nil
+}
+
+// RolloutUndo rolls back the deployment to the revision, zero revision means
+// the previous revision. It works like "kubectl rollout undo deployment/name
+// --to-revision=revision". The deployment is returned unchanged if the pod
+// template of the revision is the same as the current one.
+func (h *Handler) RolloutUndo(name string, revision int64) (*appsv1.Deployment, error) {
+	deploy, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if deploy.Spec.Paused {
+		return nil, fmt.Errorf("deployment %q is paused, resume it before rollback", name)
+	}
+	revisions, err := h.rolloutHistory(deploy)
+	if err != nil {
+		return nil, err
+	}
+	target, err := findRevision(revisions, revision, deploy.Annotations[RevisionAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("deployment %q: %w", name, err)
+	}
+
+	current := deploy.Spec.Template.DeepCopy()
+	delete(current.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	if apiequality.Semantic.DeepEqual(current, &target.Template) {
+		return deploy, nil
+	}
+	return h.patch(deploy, undoPatch(deploy, target), types.JSONPatchType)
+}
+
+// undoPatch returns the JSON patch that rolls back the deployment to the revision.
+func undoPatch(deploy *appsv1.Deployment, target *Revision) []map[string]interface{} {
+	// replace the whole pod template like kubectl, a merge patch would keep
+	// the fields that don't exist in the pod template of the revision.
+	patch := []map[string]interface{}{
+		{"op": "replace", "path": "/spec/template", "value": target.Template},
+	}
+	if len(target.ChangeCause) != 0 {
+		// the annotations must exist before adding the annotation to it.
+		if deploy.Annotations == nil {
+			patch = append(patch, map[string]interface{}{"op": "add", "path": "/metadata/annotations",
+				"value": map[string]string{ChangeCauseAnnotation: target.ChangeCause}})
+		} else {
+			patch = append(patch, map[string]interface{}{"op": "add", "path": "/metadata/annotations/kubernetes.io~1change-cause",
+				"value": target.ChangeCause})
+		}
+	}
+	return patch
+}
+
+// findRevision returns the revision, zero revision means the revision before
+// the current revision.
+func findRevision(revisions []Revision, revision int64, currentRevision string) (*Revision, error) {
+	if revision == 0 {
+		current, _ := strconv.ParseInt(currentRevision, 10, 64)
+		// revisions are sorted, the previous revision is the latest one
+		// except the current revision.
+		for i := len(revisions) - 1; i >= 0; i-- {
+			if revisions[i].Revision != current {
+				return &revisions[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no rollout history found")
+	}
+	for i := range revisions {
+		if revisions[i].Revision == revision {
+			return &revisions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find the specified revision %d", revision)
+}
+
+// Pause pauses the rollout of the deployment, it works like "kubectl rollout pause".
+func (h *Handler) Pause(name string) (*appsv1.Deployment, error) {
+	return h.setPaused(name, true)
+}
+
+// Resume resumes the paused rollout of the deployment, it works like "kubectl rollout resume".
+func (h *Handler) Resume(name string) (*appsv1.Deployment, error) {
+	return h.setPaused(name, false)
+}
+
+func (h *Handler) setPaused(name string, paused bool) (*appsv1.Deployment, error) {
+	deploy, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if deploy.Spec.Paused == paused {
+		return deploy, nil
+	}
+	return h.patch(deploy, map[string]interface{}{
+		"spec": map[string]interface{}{"paused": paused},
+	}, types.MergePatchType)
+}
+
+// Restart restarts the pods of the deployment by setting the restartedAt
+// annotation of the pod template, it works like "kubectl rollout restart".
+func (h *Handler) Restart(name string) (*appsv1.Deployment, error) {
+	deploy, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if deploy.Spec.Paused {
+		return nil, fmt.Errorf("deployment %q is paused, resume it before restart", name)
+	}
+	return h.patch(deploy, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{RestartedAtAnnotation: time.Now().Format(time.RFC3339)},
+				},
+			},
+		},
+	}, types.StrategicMergePatchType)
+}
+
+// RolloutStatus returns the rollout status of the deployment, it works like
+// "kubectl rollout status". It returns error if the deployment exceeded its
+// progress deadline.
+func (h *Handler) RolloutStatus(name string) (*RolloutStatus, error) {
+	deploy, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return rolloutStatus(deploy)
+}
+
+// rolloutStatus computes the rollout status.
+// ref: https://github.com/kubernetes/kubectl/blob/master/pkg/polymorphichelpers/rollout_status.go
+func rolloutStatus(deploy *appsv1.Deployment) (*RolloutStatus, error) {
+	revision, _ := revisionOf(deploy.Annotations)
+	rs := &RolloutStatus{
+		Revision:          revision,
+		Replicas:          deploy.Status.Replicas,
+		UpdatedReplicas:   deploy.Status.UpdatedReplicas,
+		ReadyReplicas:     deploy.Status.ReadyReplicas,
+		AvailableReplicas: deploy.Status.AvailableReplicas,
+		Paused:            deploy.Spec.Paused,
+	}
+	if deploy.Generation > deploy.Status.ObservedGeneration {
+		rs.Message = "Waiting for deployment spec update to be observed..."
+		return rs, nil
+	}
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			return rs, fmt.Errorf("deployment %q exceeded its progress deadline", deploy.Name)
+		}
+	}
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	switch {
+	case rs.UpdatedReplicas < replicas:
+		rs.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...",
+			deploy.Name, rs.UpdatedReplicas, replicas)
+	case rs.Replicas > rs.UpdatedReplicas:
+		rs.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...",
+			deploy.Name, rs.Replicas-rs.UpdatedReplicas)
+	case rs.AvailableReplicas < rs.UpdatedReplicas:
+		rs.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...",
+			deploy.Name, rs.AvailableReplicas, rs.UpdatedReplicas)
+	default:
+		rs.Done = true
+		rs.Message = fmt.Sprintf("deployment %q successfully rolled out", deploy.Name)
+	}
+	return rs, nil
+}
+
+// revisionOf returns the revision in the annotations, zero if not found.
+func revisionOf(annotations map[string]string) (int64, error) {
+	v, ok := annotations[RevisionAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	revision, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid revision annotation %q", v)
+	}
+	return revision, nil
+}
+
+// patch marshals the patch and patches the deployment.
+func (h *Handler) patch(deploy *appsv1.Deployment, patch interface{}, patchType types.PatchType) (*appsv1.Deployment, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return h.Patch(deploy, data, patchType)
+}
@@ -0,0 +1,193 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTemplate(image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nginx"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: image}}},
+	}
+}
+
+func TestFindRevision(t *testing.T) {
+	revisions := []Revision{{Revision: 1}, {Revision: 2}, {Revision: 3}}
+	tests := []struct {
+		name      string
+		revisions []Revision
+		revision  int64
+		current   string
+		expected  int64
+		wantErr   bool
+	}{
+		{name: "previous revision", revisions: revisions, current: "3", expected: 2},
+		// the current revision isn't the latest one after a rollback.
+		{name: "previous revision after rollback", revisions: revisions, current: "2", expected: 3},
+		{name: "no current revision", revisions: revisions, expected: 3},
+		{name: "only current revision", revisions: []Revision{{Revision: 1}}, current: "1", wantErr: true},
+		{name: "no history", current: "1", wantErr: true},
+		{name: "specified revision", revisions: revisions, revision: 1, current: "3", expected: 1},
+		{name: "specified revision not found", revisions: revisions, revision: 5, current: "3", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			revision, err := findRevision(test.revisions, test.revision, test.current)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got revision %d", revision.Revision)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if revision.Revision != test.expected {
+				t.Errorf("expected revision %d, got %d", test.expected, revision.Revision)
+			}
+		})
+	}
+}
+
+func TestUndoPatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		changeCause string
+		expected    map[string]string
+	}{
+		{
+			name:        "no annotations",
+			changeCause: "kubectl set image nginx=nginx:1.21",
+			expected:    map[string]string{ChangeCauseAnnotation: "kubectl set image nginx=nginx:1.21"},
+		},
+		{
+			name:        "existing annotations",
+			annotations: map[string]string{RevisionAnnotation: "3", ChangeCauseAnnotation: "kubectl set image nginx=nginx:1.23"},
+			changeCause: "kubectl set image nginx=nginx:1.21",
+			expected:    map[string]string{RevisionAnnotation: "3", ChangeCauseAnnotation: "kubectl set image nginx=nginx:1.21"},
+		},
+		{
+			name:        "no change cause",
+			annotations: map[string]string{RevisionAnnotation: "3"},
+			expected:    map[string]string{RevisionAnnotation: "3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deploy := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "test", Annotations: test.annotations},
+				Spec:       appsv1.DeploymentSpec{Template: newTemplate("nginx:1.23")},
+			}
+			deploy.Spec.Template.Annotations = map[string]string{RestartedAtAnnotation: "2022-07-01T00:00:00Z"}
+			target := &Revision{Revision: 1, ChangeCause: test.changeCause, Template: newTemplate("nginx:1.21")}
+
+			data, err := json.Marshal(undoPatch(deploy, target))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the fake clientset applies the JSON patch like the kubernetes API
+			// server, adding an annotation to the nil annotations fails.
+			client := fake.NewSimpleClientset(deploy).AppsV1().Deployments("test")
+			patched, err := client.Patch(context.TODO(), "nginx", types.JSONPatchType, data, metav1.PatchOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(patched.Annotations, test.expected) {
+				t.Errorf("expected annotations %v, got %v", test.expected, patched.Annotations)
+			}
+			// the whole pod template is replaced, the annotations that don't
+			// exist in the revision are removed.
+			if !reflect.DeepEqual(patched.Spec.Template, target.Template) {
+				t.Errorf("expected pod template %v, got %v", target.Template, patched.Spec.Template)
+			}
+		})
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	replicas := int32(3)
+	newDeploy := func(generation, observedGeneration int64, status appsv1.DeploymentStatus) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Generation: generation, Annotations: map[string]string{RevisionAnnotation: "2"}},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: func() appsv1.DeploymentStatus {
+				status.ObservedGeneration = observedGeneration
+				return status
+			}(),
+		}
+	}
+	tests := []struct {
+		name    string
+		deploy  *appsv1.Deployment
+		done    bool
+		message string
+		wantErr bool
+	}{
+		{
+			name:    "spec update not observed",
+			deploy:  newDeploy(2, 1, appsv1.DeploymentStatus{}),
+			message: "Waiting for deployment spec update to be observed...",
+		},
+		{
+			name: "progress deadline exceeded",
+			deploy: newDeploy(2, 2, appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+			}}),
+			wantErr: true,
+		},
+		{
+			name:    "replicas not updated",
+			deploy:  newDeploy(2, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1}),
+			message: `Waiting for deployment "nginx" rollout to finish: 1 out of 3 new replicas have been updated...`,
+		},
+		{
+			name:    "old replicas pending termination",
+			deploy:  newDeploy(2, 2, appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 3}),
+			message: `Waiting for deployment "nginx" rollout to finish: 1 old replicas are pending termination...`,
+		},
+		{
+			name:    "updated replicas not available",
+			deploy:  newDeploy(2, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}),
+			message: `Waiting for deployment "nginx" rollout to finish: 2 of 3 updated replicas are available...`,
+		},
+		{
+			name:    "rolled out",
+			deploy:  newDeploy(2, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3}),
+			done:    true,
+			message: `deployment "nginx" successfully rolled out`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, err := rolloutStatus(test.deploy)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Revision != 2 {
+				t.Errorf("expected revision 2, got %d", status.Revision)
+			}
+			if status.Done != test.done || status.Message != test.message {
+				t.Errorf("expected done %t with message %q, got %t with message %q", test.done, test.message, status.Done, status.Message)
+			}
+		})
+	}
+}
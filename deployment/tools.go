@@ -1,151 +1,86 @@
 package deployment
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
-	log "github.com/sirupsen/logrus"
+	utilwait "github.com/forbearing/k8s/util/wait"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // IsReady check if the deployment is ready.
 // ref: https://github.com/kubernetes/kubernetes/blob/a1128e380c2cf1c2d7443694673d9f1dd63eb518/staging/src/k8s.io/kubectl/pkg/polymorphichelpers/rollout_status.go#L59
 func (h *Handler) IsReady(name string) bool {
-	checkGeneration := func(deploy *appsv1.Deployment) bool {
-		if deploy.Generation != deploy.Status.ObservedGeneration {
-			return false
-		}
-		return true
-	}
-	checkReplicas := func(deploy *appsv1.Deployment) bool {
-		if deploy.Spec.Replicas == nil {
-			return false
-		}
-		if *deploy.Spec.Replicas != deploy.Status.ReadyReplicas {
-			return false
-		}
-		if *deploy.Spec.Replicas != deploy.Status.AvailableReplicas {
-			return false
-		}
-		if *deploy.Spec.Replicas != deploy.Status.UpdatedReplicas {
-			return false
-		}
-		if *deploy.Spec.Replicas != deploy.Status.Replicas {
-			return false
-		}
-		return true
-	}
-	checkCondition := func(deploy *appsv1.Deployment) bool {
-		for _, cond := range deploy.Status.Conditions {
-			if cond.Type == appsv1.DeploymentAvailable && cond.Status == corev1.ConditionTrue {
-				return true
-			}
-		}
-		return false
-	}
-
 	deploy, err := h.Get(name)
 	if err != nil {
 		return false
 	}
-	if checkGeneration(deploy) && checkReplicas(deploy) && checkCondition(deploy) {
-		return true
-	}
-	return false
+	ready, _, _ := readiness(deploy)
+	return ready
 }
 
-// WaitReady waiting for the deployment to be in the ready status.
-func (h *Handler) WaitReady(name string) error {
-	if h.IsReady(name) {
-		return nil
+// WaitReady waits for the deployment to be in the ready status. It watches the
+// deployment and honours the handler context, use WithContext() to pass the
+// per-call context, and utilwait.WithTimeout/WithProgress to set the timeout
+// and to report why the deployment is not ready yet, eg:
+//
+//	err := handler.WithContext(ctx).WaitReady(name, utilwait.WithTimeout(5*time.Minute))
+//
+// WaitReady returns the NotFound error immediately if the deployment doesn't
+// exist. Without utilwait.WithTimeout it waits until the handler context is
+// done, so always pass a timeout unless the context has a deadline.
+//
+// The *utilwait.TimeoutError returned contains the reason why the deployment is
+// not ready. It returns error immediately if the deployment exceeded its progress deadline.
+func (h *Handler) WaitReady(name string, opts ...utilwait.Option) error {
+	// the wait by name waits for the deployment to be created, get it first so a
+	// missing deployment doesn't block until timeout.
+	if _, err := h.Get(name); err != nil {
+		return err
 	}
-
-	errCh := make(chan error, 1)
-	chkCh := make(chan struct{}, 1)
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL, syscall.SIGQUIT)
-	ctxCheck, cancelCheck := context.WithCancel(h.ctx)
-	ctxWatch, cancelWatch := context.WithCancel(h.ctx)
-	defer cancelCheck()
-	defer cancelWatch()
-
-	// this goroutine used to check whether deployment is ready and exists.
-	// if deployment already ready, return nil.
-	// if deployment does not exist, return error.
-	// if deployment exists but not ready, return nothing.
-	go func(context.Context) {
-		<-chkCh
-		for i := 0; i < 6; i++ {
-			// deployment is already ready, return nil
-			if h.IsReady(name) {
-				errCh <- nil
-				return
-			}
-			// deployment no longer exists, return err
-			_, err := h.Get(name)
-			if k8serrors.IsNotFound(err) {
-				errCh <- err
-				return
-			}
-			// deployment exists but not ready, return nothing.
-			if err == nil {
-				return
-			}
-			log.Error(err)
-			time.Sleep(time.Second * 10)
+	cond := utilwait.Condition{Name: "ready", Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		deploy := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), deploy); err != nil {
+			return false, "", err
 		}
-	}(ctxCheck)
+		return readiness(deploy)
+	}}
+	return h.waitFor(utilwait.Target{Name: name}, cond, opts...)
+}
 
-	// this goroutine used to watch deployment.
-	go func(ctx context.Context) {
-		for {
-			timeout := int64(0)
-			listOptions := metav1.SingleObject(metav1.ObjectMeta{Name: name, Namespace: h.namespace})
-			listOptions.TimeoutSeconds = &timeout
-			watcher, err := h.clientset.AppsV1().Deployments(h.namespace).Watch(h.ctx, listOptions)
-			if err != nil {
-				errCh <- err
-				return
-			}
-			chkCh <- struct{}{}
-			for event := range watcher.ResultChan() {
-				switch event.Type {
-				case watch.Modified:
-					if h.IsReady(name) {
-						watcher.Stop()
-						errCh <- nil
-						return
-					}
-				case watch.Deleted:
-					watcher.Stop()
-					errCh <- fmt.Errorf("deployment/%s was deleted", name)
-					return
-				case watch.Bookmark:
-					log.Debug("watch deployment: bookmark")
-				case watch.Error:
-					log.Debug("watch deployment: error")
-				}
-			}
-			// If event channel is closed, it means the kube-apiserver has closed the connection.
-			log.Debug("watch deployment: reconnect to kubernetes")
-			watcher.Stop()
+// readiness returns whether the deployment is ready and the reason if it's not.
+func readiness(deploy *appsv1.Deployment) (bool, string, error) {
+	if deploy.Generation != deploy.Status.ObservedGeneration {
+		return false, "waiting for the deployment spec update to be observed", nil
+	}
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			return false, "", fmt.Errorf("deployment %q exceeded its progress deadline", deploy.Name)
+		}
+	}
+	if deploy.Spec.Replicas == nil {
+		return false, "spec.replicas is not set", nil
+	}
+	replicas := *deploy.Spec.Replicas
+	switch {
+	case deploy.Status.UpdatedReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas updated", deploy.Status.UpdatedReplicas, replicas), nil
+	case deploy.Status.Replicas != replicas:
+		return false, fmt.Sprintf("%d old replicas pending termination", deploy.Status.Replicas-deploy.Status.UpdatedReplicas), nil
+	case deploy.Status.ReadyReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas ready", deploy.Status.ReadyReplicas, replicas), nil
+	case deploy.Status.AvailableReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas available", deploy.Status.AvailableReplicas, replicas), nil
+	}
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentAvailable && cond.Status == corev1.ConditionTrue {
+			return true, "", nil
 		}
-	}(ctxWatch)
-
-	select {
-	case sig := <-sigCh:
-		return fmt.Errorf("cancelled by signal: %s", sig.String())
-	case err := <-errCh:
-		return err
 	}
+	return false, "condition Available is not True", nil
 }
 
 //// WaitReady waiting for the deployment to be in the ready state.
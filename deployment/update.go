@@ -1,10 +1,12 @@
 package deployment
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -95,12 +97,22 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*appsv1.Deployment, e
 
 // updateDeployment
 func (h *Handler) updateDeployment(deploy *appsv1.Deployment) (*appsv1.Deployment, error) {
+	// resourceVersion cann't be set, the resourceVersion field is empty.
+	deploy.ResourceVersion = ""
+	deploy.UID = ""
+	return h.updateDeploymentWithResourceVersion(deploy)
+}
+
+// updateDeploymentWithResourceVersion updates the deployment without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the deployment has been changed since it was read.
+func (h *Handler) updateDeploymentWithResourceVersion(deploy *appsv1.Deployment) (*appsv1.Deployment, error) {
 	namespace := deploy.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	// resourceVersion cann't be set, the resourceVersion field is empty.
-	deploy.ResourceVersion = ""
-	deploy.UID = ""
-	return h.clientset.AppsV1().Deployments(namespace).Update(h.ctx, deploy, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: deploy.Name, Object: deploy}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+		return h.clientset.AppsV1().Deployments(namespace).Update(ctx, deploy, h.Options.UpdateOptions)
+	})
 }
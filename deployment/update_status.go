@@ -1,10 +1,12 @@
 package deployment
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -99,5 +101,8 @@ func (h *Handler) updateDeploymentStatus(deploy *appsv1.Deployment) (*appsv1.Dep
 	// resourceVersion cann't be set, the resourceVersion field is empty.
 	deploy.UID = ""
 	deploy.ResourceVersion = ""
-	return h.clientset.AppsV1().Deployments(namespace).UpdateStatus(h.ctx, deploy, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: deploy.Name, Subresource: "status", Object: deploy}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+		return h.clientset.AppsV1().Deployments(namespace).UpdateStatus(ctx, deploy, h.Options.UpdateOptions)
+	})
 }
@@ -1,9 +1,12 @@
 package dynamic
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
+	utilrestmapper "github.com/forbearing/k8s/util/restmapper"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -77,9 +80,22 @@ func (h *Handler) ApplyFromMap(obj map[string]interface{}) (*unstructured.Unstru
 
 // applyUnstructured
 func (h *Handler) applyUnstructured(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	_, err := h.createUnstructured(obj)
-	if errors.IsAlreadyExists(err) {
-		return h.Update(obj)
+	var err error
+	if h.gvk, err = utilrestmapper.FindGVK(h.restMapper, obj); err != nil {
+		return nil, err
+	}
+	if h.isNamespaced, err = utilrestmapper.IsNamespaced(h.restMapper, h.gvk); err != nil {
+		return nil, err
 	}
-	return obj, err
+
+	op := &types.Operation{Verb: types.VerbApply, Namespace: h.objectNamespace(obj), Name: obj.GetName(), Object: obj}
+	return h.intercept(op, func(ctx context.Context, _ string) (*unstructured.Unstructured, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		created, err := handler.createUnstructured(obj)
+		if errors.IsAlreadyExists(err) {
+			return handler.Update(obj)
+		}
+		return created, err
+	})
 }
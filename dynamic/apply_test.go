@@ -0,0 +1,46 @@
+package dynamic
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// setUID sets the uid of the created k8s objects like the API server.
+func setUID(handler *Handler) {
+	handler.dynamicClient.(*fake.FakeDynamicClient).PrependReactor("create", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		obj.SetUID(k8stypes.UID(action.GetNamespace() + "-" + obj.GetName()))
+		return false, nil, nil
+	})
+}
+
+func TestApplyWithoutNamespace(t *testing.T) {
+	other := newDeployment(1)
+	other.SetNamespace("other")
+	other.SetUID("other-nginx")
+	handler := newFakeHandler(other)
+	setUID(handler)
+
+	data := []byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+spec:
+  replicas: 1
+`)
+	obj, err := handler.Apply(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the deployment is created in the handler namespace, not the same-named
+	// deployment in the other namespace.
+	if obj.GetNamespace() != "test" || obj.GetUID() != "test-nginx" {
+		t.Fatalf("expected deployment test/nginx created, got %s/%s with uid %q", obj.GetNamespace(), obj.GetName(), obj.GetUID())
+	}
+}
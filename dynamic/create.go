@@ -1,6 +1,7 @@
 package dynamic
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 
@@ -94,12 +95,9 @@ func (h *Handler) createUnstructured(obj *unstructured.Unstructured) (*unstructu
 
 	obj.SetUID("")
 	obj.SetResourceVersion("")
-	if h.isNamespaced {
-		namespace := obj.GetNamespace()
-		if len(namespace) == 0 {
-			namespace = h.namespace
-		}
-		return h.dynamicClient.Resource(h.gvr).Namespace(namespace).Create(h.ctx, obj, h.Options.CreateOptions)
-	}
-	return h.dynamicClient.Resource(h.gvr).Create(h.ctx, obj, h.Options.CreateOptions)
+	namespace := h.objectNamespace(obj)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: obj.GetName(), Object: obj}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*unstructured.Unstructured, error) {
+		return h.resource(namespace).Create(ctx, obj, h.Options.CreateOptions)
+	})
 }
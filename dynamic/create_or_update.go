@@ -0,0 +1,134 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/forbearing/k8s/types"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing k8s object into it's desired state.
+type MutateFn func(obj *unstructured.Unstructured) error
+
+// CreateOrUpdate gets the k8s object from the kubernetes API server. If the
+// k8s object does not exist, it will be created after mutateFn is called.
+// If the k8s object exists, mutateFn is called on the live object and the
+// k8s object will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live k8s object, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The obj must contain apiVersion, kind, name and namespace(optional), it's
+// not necessary to call WithGVK(). obj will be overwritten by the live object
+// returned by the kubernetes API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(obj *unstructured.Unstructured, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getUnstructured(obj)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, obj); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createUnstructured(obj)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		obj.Object = created.Object
+		return types.OperationResultCreated, nil
+	}
+
+	obj.Object = existing.DeepCopy().Object
+	if err := mutate(mutateFn, obj); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, obj) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateUnstructuredWithResourceVersion(obj)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	obj.Object = updated.Object
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the k8s object already exists,
+// only the difference between the live object and the mutated object will be
+// sent to the kubernetes API server by "JSON Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(obj *unstructured.Unstructured, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getUnstructured(obj)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, obj); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createUnstructured(obj)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		obj.Object = created.Object
+		return types.OperationResultCreated, nil
+	}
+
+	obj.Object = existing.DeepCopy().Object
+	if err := mutate(mutateFn, obj); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(obj); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	// the unstructured object has no patch strategy, so "JSON Merge Patch" is
+	// used, the lists are replaced as a whole.
+	if patchData, err = jsonpatch.CreateMergePatch(existingJson, modifiedJson); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.patchUnstructured(existing, patchData, k8stypes.MergePatchType)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	obj.Object = patched.Object
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, obj *unstructured.Unstructured) error {
+	name, namespace := obj.GetName(), obj.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(obj); err != nil {
+			return err
+		}
+	}
+	if obj.GetName() != name || obj.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate object name and/or object namespace")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package dynamic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/forbearing/k8s/types"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+var deploymentGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+func newFakeHandler(objects ...runtime.Object) *Handler {
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(deploymentGVK, meta.RESTScopeNamespace)
+	return &Handler{
+		ctx:           context.TODO(),
+		namespace:     "test",
+		logger:        logr.Discard(),
+		observer:      types.NopObserver{},
+		dynamicClient: fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...),
+		restMapper:    restMapper,
+		Options:       &types.HandlerOptions{},
+	}
+}
+
+func newDeployment(replicas int64) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(deploymentGVK)
+	obj.SetName("nginx")
+	obj.SetNamespace("test")
+	unstructured.SetNestedField(obj.Object, replicas, "spec", "replicas")
+	unstructured.SetNestedSlice(obj.Object, []interface{}{
+		map[string]interface{}{"name": "nginx", "image": "nginx:1.21"},
+	}, "spec", "template", "spec", "containers")
+	return obj
+}
+
+func TestCreateOrPatch(t *testing.T) {
+	handler := newFakeHandler(newDeployment(1))
+
+	setImage := func(image string) MutateFn {
+		return func(obj *unstructured.Unstructured) error {
+			containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+			containers[0].(map[string]interface{})["image"] = image
+			return unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+		}
+	}
+	tests := []struct {
+		name     string
+		obj      *unstructured.Unstructured
+		mutateFn MutateFn
+		expected types.OperationResult
+		image    string
+	}{
+		{
+			name:     "unchanged",
+			obj:      newDeployment(1),
+			mutateFn: setImage("nginx:1.21"),
+			expected: types.OperationResultUnchanged,
+			image:    "nginx:1.21",
+		},
+		{
+			name:     "patched",
+			obj:      newDeployment(1),
+			mutateFn: setImage("nginx:1.23"),
+			expected: types.OperationResultUpdated,
+			image:    "nginx:1.23",
+		},
+		{
+			name: "created",
+			obj: func() *unstructured.Unstructured {
+				obj := newDeployment(1)
+				obj.SetName("redis")
+				return obj
+			}(),
+			mutateFn: setImage("redis:7"),
+			expected: types.OperationResultCreated,
+			image:    "redis:7",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := handler.CreateOrPatch(test.obj, test.mutateFn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, result)
+			}
+			live, err := handler.getUnstructured(test.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			containers, _, _ := unstructured.NestedSlice(live.Object, "spec", "template", "spec", "containers")
+			if image := containers[0].(map[string]interface{})["image"]; image != test.image {
+				t.Errorf("expected image %q, got %q", test.image, image)
+			}
+			if replicas, _, _ := unstructured.NestedInt64(live.Object, "spec", "replicas"); replicas != 1 {
+				t.Errorf("expected replicas not changed, got %d", replicas)
+			}
+		})
+	}
+}
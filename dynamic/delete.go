@@ -1,6 +1,7 @@
 package dynamic
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 
@@ -54,10 +55,15 @@ func (h *Handler) DeleteByName(name string) error {
 	if h.gvk.Kind == types.KindJob || h.gvk.Kind == types.KindCronJob {
 		h.SetPropagationPolicy("background")
 	}
+	namespace := ""
 	if h.isNamespaced {
-		return h.dynamicClient.Resource(h.gvr).Namespace(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+		namespace = h.namespace
 	}
-	return h.dynamicClient.Resource(h.gvr).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: name}
+	_, err = h.intercept(op, func(ctx context.Context, namespace string) (*unstructured.Unstructured, error) {
+		return nil, h.resource(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes unstructured k8s resource from yaml or json file.
@@ -113,12 +119,10 @@ func (h *Handler) deleteUnstructured(obj *unstructured.Unstructured) error {
 		h.SetPropagationPolicy("background")
 	}
 
-	if h.isNamespaced {
-		namespace := obj.GetNamespace()
-		if len(namespace) == 0 {
-			namespace = h.namespace
-		}
-		return h.dynamicClient.Resource(h.gvr).Namespace(namespace).Delete(h.ctx, obj.GetName(), h.Options.DeleteOptions)
-	}
-	return h.dynamicClient.Resource(h.gvr).Delete(h.ctx, obj.GetName(), h.Options.DeleteOptions)
+	namespace := h.objectNamespace(obj)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: obj.GetName(), Object: obj}
+	_, err = h.intercept(op, func(ctx context.Context, namespace string) (*unstructured.Unstructured, error) {
+		return nil, h.resource(namespace).Delete(ctx, obj.GetName(), h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/forbearing/k8s/types"
 	"github.com/forbearing/k8s/util/client"
 	utilrestmapper "github.com/forbearing/k8s/util/restmapper"
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -40,6 +41,12 @@ type Handler struct {
 	isNamespaced bool
 	kubeconfig   string
 	namespace    string
+	logger       logr.Logger
+	observer     types.Observer
+
+	interceptors    []types.Interceptor
+	strictNamespace types.StrictNamespaceConfig
+	warnings        types.WarningConfig
 
 	config        *rest.Config
 	httpClient    *http.Client
@@ -59,8 +66,8 @@ type Handler struct {
 
 // NewOrDie creates a Handler object.
 // Panic if there is any error.
-func NewOrDie(ctx context.Context, kubeconfig string, namespace string) *Handler {
-	handler, err := New(ctx, kubeconfig, namespace)
+func NewOrDie(ctx context.Context, kubeconfig string, namespace string, opts ...types.HandlerOption) *Handler {
+	handler, err := New(ctx, kubeconfig, namespace, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -74,7 +81,7 @@ func NewOrDie(ctx context.Context, kubeconfig string, namespace string) *Handler
 // * KUBECONFIG environment variable pointing at a file.
 // * $HOME/.kube/config if exists.
 // * In-cluster config if running in cluster.
-func New(ctx context.Context, kubeconfig string, namespace string) (*Handler, error) {
+func New(ctx context.Context, kubeconfig string, namespace string, opts ...types.HandlerOption) (*Handler, error) {
 	var (
 		err             error
 		config          *rest.Config
@@ -93,6 +100,12 @@ func New(ctx context.Context, kubeconfig string, namespace string) (*Handler, er
 	if config, err = client.RESTConfig(kubeconfig); err != nil {
 		return nil, err
 	}
+	// the logger stored in ctx is used if no logger option is passed.
+	handlerConfig := types.NewHandlerConfig(ctx, opts...)
+	// wrap the http transport, such like collecting metrics.
+	for _, wrap := range handlerConfig.TransportWrappers {
+		config.Wrap(wrap)
+	}
 	config.APIPath = "api"
 	config.GroupVersion = &schema.GroupVersion{}
 	config.NegotiatedSerializer = scheme.Codecs
@@ -123,6 +136,11 @@ func New(ctx context.Context, kubeconfig string, namespace string) (*Handler, er
 
 	return &Handler{
 		ctx:             ctx,
+		logger:          handlerConfig.Logger,
+		observer:        handlerConfig.Observer,
+		interceptors:    handlerConfig.Interceptors,
+		strictNamespace: handlerConfig.StrictNamespace,
+		warnings:        handlerConfig.Warnings,
 		kubeconfig:      kubeconfig,
 		namespace:       namespace,
 		config:          config,
@@ -163,6 +181,15 @@ func (h *Handler) WithGVK(gvk schema.GroupVersionKind) *Handler {
 	return handler
 }
 
+// WithContext deep copies a new handler that uses ctx to send requests to the
+// kubernetes API server, such like the per-call deadline and trace span,
+// eg: handler.WithContext(ctx).Create(filename).
+func (h *Handler) WithContext(ctx context.Context) *Handler {
+	handler := h.DeepCopy()
+	handler.ctx = ctx
+	return handler
+}
+
 // WithDryRun deep copies a new handler and prints the create/update/apply/delete
 // operations, without sending it to apiserver.
 func (h *Handler) WithDryRun() *Handler {
@@ -182,6 +209,11 @@ func (in *Handler) DeepCopy() *Handler {
 	}
 	return &Handler{
 		ctx:              in.ctx,
+		logger:           in.logger,
+		observer:         in.observer,
+		interceptors:     append([]types.Interceptor(nil), in.interceptors...),
+		strictNamespace:  in.strictNamespace,
+		warnings:         in.warnings,
 		gvk:              in.gvk,
 		gvr:              in.gvr,
 		isNamespaced:     in.isNamespaced,
@@ -222,7 +254,9 @@ func (h *Handler) SetTimeout(timeout int64) {
 	h.Options.ListOptions.TimeoutSeconds = &timeout
 }
 
-// SetLimit
+// SetLimit sets the number of k8s objects requested from the kubernetes API
+// server per page. List methods always follow the continue token and return
+// all k8s objects, ListPages() and ListIter() return them page by page.
 func (h *Handler) SetLimit(limit int64) {
 	h.l.Lock()
 	defer h.l.Unlock()
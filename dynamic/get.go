@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 
 	"github.com/forbearing/k8s/types"
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	utilrestmapper "github.com/forbearing/k8s/util/restmapper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -56,9 +57,11 @@ func (h *Handler) GetByName(name string) (*unstructured.Unstructured, error) {
 	}
 
 	if h.isNamespaced {
-		return h.dynamicClient.Resource(h.gvr).Namespace(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+		obj, err := h.dynamicClient.Resource(h.gvr).Namespace(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+		return obj, utilerrors.Wrap("get", h.gvk, h.namespace, name, err)
 	}
-	return h.dynamicClient.Resource(h.gvr).Get(h.ctx, name, h.Options.GetOptions)
+	obj, err := h.dynamicClient.Resource(h.gvr).Get(h.ctx, name, h.Options.GetOptions)
+	return obj, utilerrors.Wrap("get", h.gvk, "", name, err)
 }
 
 // GetFromFile gets unstructured k8s resource from yaml or json file.
@@ -119,7 +122,9 @@ func (h *Handler) getUnstructured(obj *unstructured.Unstructured) (*unstructured
 		if len(namespace) == 0 {
 			namespace = h.namespace
 		}
-		return h.dynamicClient.Resource(h.gvr).Namespace(namespace).Get(h.ctx, obj.GetName(), h.Options.GetOptions)
+		newObj, err := h.dynamicClient.Resource(h.gvr).Namespace(namespace).Get(h.ctx, obj.GetName(), h.Options.GetOptions)
+		return newObj, utilerrors.Wrap("get", h.gvk, namespace, obj.GetName(), err)
 	}
-	return h.dynamicClient.Resource(h.gvr).Get(h.ctx, obj.GetName(), h.Options.GetOptions)
+	newObj, err := h.dynamicClient.Resource(h.gvr).Get(h.ctx, obj.GetName(), h.Options.GetOptions)
+	return newObj, utilerrors.Wrap("get", h.gvk, "", obj.GetName(), err)
 }
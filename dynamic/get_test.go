@@ -0,0 +1,24 @@
+package dynamic
+
+import (
+	"errors"
+	"testing"
+
+	utilerrors "github.com/forbearing/k8s/util/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestGetNotFound(t *testing.T) {
+	handler := newFakeHandler().WithGVK(deploymentGVK)
+	_, err := handler.Get("nginx")
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	var opErr *utilerrors.OperationError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("expected *OperationError, got %T", err)
+	}
+	if opErr.Verb != "get" || opErr.GVK != deploymentGVK || opErr.Namespace != "test" || opErr.Name != "nginx" {
+		t.Errorf("unexpected operation error %+v", opErr)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/forbearing/k8s/types"
 	utilrestmapper "github.com/forbearing/k8s/util/restmapper"
+	"github.com/forbearing/k8s/util/selector"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
@@ -20,16 +21,16 @@ func (h *Handler) List() ([]*unstructured.Unstructured, error) {
 // and there is an "And" relationship between multiple labels.
 // Calling this method requires WithGVK() to explicitly specify GVK.
 func (h *Handler) ListByLabel(labels string) ([]*unstructured.Unstructured, error) {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
+		return nil, err
+	}
 	listOptions := h.Options.ListOptions.DeepCopy()
 	listOptions.LabelSelector = labels
 
 	if err := h.getGVRAndNamespaceScope(); err != nil {
 		return nil, err
 	}
-	if h.isNamespaced {
-		return extractList(h.dynamicClient.Resource(h.gvr).Namespace(h.namespace).List(h.ctx, *listOptions))
-	}
-	return extractList(h.dynamicClient.Resource(h.gvr).List(h.ctx, *listOptions))
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByField list k8s objects by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -39,16 +40,16 @@ func (h *Handler) ListByField(field string) ([]*unstructured.Unstructured, error
 	if err != nil {
 		return nil, err
 	}
+	if err := selector.ValidateFieldSelectorForGVK(h.gvk, field); err != nil {
+		return nil, err
+	}
 	listOptions := h.Options.ListOptions.DeepCopy()
 	listOptions.FieldSelector = fieldSelector.String()
 
 	if err := h.getGVRAndNamespaceScope(); err != nil {
 		return nil, err
 	}
-	if h.isNamespaced {
-		return extractList(h.dynamicClient.Resource(h.gvr).Namespace(h.namespace).List(h.ctx, *listOptions))
-	}
-	return extractList(h.dynamicClient.Resource(h.gvr).List(h.ctx, *listOptions))
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByNamespace list all k8s objects in the specified namespace.
@@ -62,7 +63,7 @@ func (h *Handler) ListByNamespace(namespace string) ([]*unstructured.Unstructure
 		return nil, err
 	}
 	if h.isNamespaced {
-		return extractList(h.dynamicClient.Resource(h.gvr).Namespace(namespace).List(h.ctx, *listOptions))
+		return h.listAll(namespace, *listOptions)
 	}
 	return nil, fmt.Errorf("%s is not namespace-scoped k8s resource", h.gvr)
 }
@@ -76,22 +77,7 @@ func (h *Handler) ListAll() ([]*unstructured.Unstructured, error) {
 	if err := h.getGVRAndNamespaceScope(); err != nil {
 		return nil, err
 	}
-	if h.isNamespaced {
-		return extractList(h.dynamicClient.Resource(h.gvr).Namespace(metav1.NamespaceAll).List(h.ctx, *listOptions))
-	}
-	return extractList(h.dynamicClient.Resource(h.gvr).List(h.ctx, *listOptions))
-}
-
-// extractList
-func extractList(unstructList *unstructured.UnstructuredList, err error) ([]*unstructured.Unstructured, error) {
-	if err != nil {
-		return nil, err
-	}
-	var objList []*unstructured.Unstructured
-	for i := range unstructList.Items {
-		objList = append(objList, &unstructList.Items[i])
-	}
-	return objList, nil
+	return h.listAll(metav1.NamespaceAll, *listOptions)
 }
 
 func (h *Handler) getGVRAndNamespaceScope() error {
@@ -0,0 +1,211 @@
+package dynamic
+
+import (
+	"context"
+
+	utilerrors "github.com/forbearing/k8s/util/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// DefaultPageSize is the number of k8s objects requested per page when
+// no limit is set by SetLimit().
+const DefaultPageSize = 500
+
+// ListPages list the k8s objects in the handler namespace page by page, and
+// calls fn for every page. Use WithNamespace(metav1.NamespaceAll) to list the
+// k8s objects in all namespaces, the namespace is ignored for cluster scope k8s
+// resource. ListPages stops and returns the error if fn returns an error.
+//
+// The label selector and field selector of listOptions are used to filter the
+// k8s objects, the page size is listOptions.Limit, default to the limit set by
+// SetLimit(), then DefaultPageSize.
+//
+// ListPages follows the continue token returned by kubernetes API server. If
+// the continue token expired(the API server compacted the resource version of
+// the list), ListPages relists from the beginning and skips the k8s objects
+// that already passed to fn, so every k8s object is passed to fn only once.
+//
+// Calling this method requires WithGVK() to explicitly specify GVK.
+func (h *Handler) ListPages(listOptions metav1.ListOptions, fn func(page []*unstructured.Unstructured) error) error {
+	p, err := h.newPager(listOptions)
+	if err != nil {
+		return err
+	}
+	return p.forEach(fn)
+}
+
+// ListIterator iterates k8s objects returned by the kubernetes API server one
+// by one, only one page of k8s objects is kept in memory.
+//
+//	it := handler.WithGVK(gvk).ListIter(metav1.ListOptions{LabelSelector: "app=nginx"})
+//	for it.Next() {
+//	    obj := it.Object()
+//	}
+//	if err := it.Err(); err != nil {
+//	    ...
+//	}
+type ListIterator struct {
+	pager *pager
+	page  []*unstructured.Unstructured
+	index int
+	err   error
+}
+
+// ListIter returns a ListIterator that iterates the k8s objects in the handler
+// namespace, listOptions is used like ListPages.
+//
+// Calling this method requires WithGVK() to explicitly specify GVK.
+func (h *Handler) ListIter(listOptions metav1.ListOptions) *ListIterator {
+	p, err := h.newPager(listOptions)
+	return &ListIterator{pager: p, err: err, index: -1}
+}
+
+// Next advances the iterator to the next k8s object, it returns false when
+// there are no more k8s objects or an error occurs.
+func (it *ListIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= len(it.page) {
+		if it.pager.done {
+			return false
+		}
+		if it.page, it.err = it.pager.next(); it.err != nil {
+			return false
+		}
+		it.index = 0
+	}
+	return true
+}
+
+// Object returns the current k8s object.
+func (it *ListIterator) Object() *unstructured.Unstructured {
+	if it.index < 0 || it.index >= len(it.page) {
+		return nil
+	}
+	return it.page[it.index]
+}
+
+// Err returns the error occurred during the iteration.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// pager requests k8s objects from kubernetes API server page by page.
+type pager struct {
+	ctx         context.Context
+	client      dynamic.ResourceInterface
+	gvk         schema.GroupVersionKind
+	namespace   string
+	listOptions metav1.ListOptions
+
+	// lastKey is the "namespace/name" of the last k8s object returned by pager,
+	// the kubernetes API server always returns k8s objects ordered by the key.
+	lastKey string
+	// relist is true after the continue token expired, k8s objects which key
+	// is not greater than lastKey will be skipped.
+	relist bool
+	done   bool
+}
+
+// newPager returns a pager that lists the k8s objects in the handler namespace.
+func (h *Handler) newPager(listOptions metav1.ListOptions) (*pager, error) {
+	if err := h.getGVRAndNamespaceScope(); err != nil {
+		return nil, err
+	}
+	listOptions.Continue = ""
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = h.Options.ListOptions.Limit
+	}
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = DefaultPageSize
+	}
+	p := &pager{ctx: h.ctx, gvk: h.gvk, listOptions: listOptions}
+	if h.isNamespaced {
+		p.client = h.dynamicClient.Resource(h.gvr).Namespace(h.namespace)
+		p.namespace = h.namespace
+	} else {
+		p.client = h.dynamicClient.Resource(h.gvr)
+	}
+	return p, nil
+}
+
+// next returns the next page of k8s objects.
+func (p *pager) next() ([]*unstructured.Unstructured, error) {
+	if p.done {
+		return nil, nil
+	}
+	unstructList, err := p.client.List(p.ctx, p.listOptions)
+	if err != nil {
+		// The continue token expired, relist from the beginning.
+		if apierrors.IsResourceExpired(err) && len(p.listOptions.Continue) != 0 {
+			p.listOptions.Continue = ""
+			p.listOptions.ResourceVersion = ""
+			p.relist = true
+			return nil, nil
+		}
+		return nil, utilerrors.Wrap("list", p.gvk, p.namespace, "", err)
+	}
+	p.listOptions.Continue = unstructList.GetContinue()
+	if len(p.listOptions.Continue) == 0 {
+		p.done = true
+	}
+
+	var page []*unstructured.Unstructured
+	for i := range unstructList.Items {
+		obj := &unstructList.Items[i]
+		key := obj.GetNamespace() + "/" + obj.GetName()
+		if p.relist && key <= p.lastKey {
+			continue
+		}
+		page = append(page, obj)
+		p.lastKey = key
+	}
+	return page, nil
+}
+
+// forEach calls fn for every page of k8s objects until all k8s objects are listed,
+// it stops and returns the error if fn returns an error.
+func (p *pager) forEach(fn func(page []*unstructured.Unstructured) error) error {
+	for {
+		page, err := p.next()
+		if err != nil {
+			return err
+		}
+		if len(page) != 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+		if p.done {
+			return nil
+		}
+	}
+}
+
+// listAll follows the continue tokens to list all k8s objects.
+func (h *Handler) listAll(namespace string, listOptions metav1.ListOptions) ([]*unstructured.Unstructured, error) {
+	listOptions.Continue = ""
+	p := &pager{ctx: h.ctx, gvk: h.gvk, listOptions: listOptions}
+	if h.isNamespaced {
+		p.client = h.dynamicClient.Resource(h.gvr).Namespace(namespace)
+		p.namespace = namespace
+	} else {
+		p.client = h.dynamicClient.Resource(h.gvr)
+	}
+
+	var objList []*unstructured.Unstructured
+	for !p.done {
+		page, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		objList = append(objList, page...)
+	}
+	return objList, nil
+}
@@ -0,0 +1,160 @@
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+// pagesClient returns the scripted list responses in order and records the
+// list options, the fake dynamic client drops the continue token of the list
+// returned by the reactors.
+type pagesClient struct {
+	dynamic.ResourceInterface
+	responses []listResponse
+	requests  []metav1.ListOptions
+}
+
+type listResponse struct {
+	names []string
+	token string
+	err   error
+}
+
+func (c *pagesClient) List(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	c.requests = append(c.requests, opts)
+	if len(c.requests) > len(c.responses) {
+		return nil, errors.New("unexpected list request")
+	}
+	resp := c.responses[len(c.requests)-1]
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetContinue(resp.token)
+	for _, name := range resp.names {
+		obj := unstructured.Unstructured{}
+		obj.SetGroupVersionKind(deploymentGVK)
+		obj.SetNamespace("test")
+		obj.SetName(name)
+		list.Items = append(list.Items, obj)
+	}
+	return list, nil
+}
+
+func TestListPages(t *testing.T) {
+	expired := apierrors.NewResourceExpired("continue token expired")
+	stop := errors.New("stop")
+	tests := []struct {
+		name      string
+		responses []listResponse
+		// stopAt is the page on which fn returns an error, zero means never.
+		stopAt int
+		pages  [][]string
+		// continues are the continue tokens sent by the list requests.
+		continues []string
+		err       error
+	}{
+		{
+			name: "multiple pages",
+			responses: []listResponse{
+				{names: []string{"a", "b"}, token: "1"},
+				{names: []string{"c", "d"}, token: "2"},
+				{names: []string{"e"}},
+			},
+			pages:     [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+			continues: []string{"", "1", "2"},
+		},
+		{
+			name: "continue token expired",
+			responses: []listResponse{
+				{names: []string{"a", "b"}, token: "1"},
+				{err: expired},
+				// relist from the beginning, a and b are skipped.
+				{names: []string{"a", "b", "c"}, token: "3"},
+				{names: []string{"d"}},
+			},
+			pages:     [][]string{{"a", "b"}, {"c"}, {"d"}},
+			continues: []string{"", "1", "", "3"},
+		},
+		{
+			name: "expired without continue token",
+			responses: []listResponse{
+				{err: expired},
+			},
+			continues: []string{""},
+			err:       expired,
+		},
+		{
+			name: "fn returns error",
+			responses: []listResponse{
+				{names: []string{"a", "b"}, token: "1"},
+				{names: []string{"c", "d"}, token: "2"},
+				{names: []string{"e"}},
+			},
+			stopAt:    2,
+			pages:     [][]string{{"a", "b"}, {"c", "d"}},
+			continues: []string{"", "1"},
+			err:       stop,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &pagesClient{responses: test.responses}
+			p := &pager{ctx: context.TODO(), client: client, gvk: deploymentGVK, namespace: "test", listOptions: metav1.ListOptions{Limit: 2}}
+
+			var pages [][]string
+			err := p.forEach(func(page []*unstructured.Unstructured) error {
+				var names []string
+				for _, obj := range page {
+					names = append(names, obj.GetName())
+				}
+				pages = append(pages, names)
+				if len(pages) == test.stopAt {
+					return stop
+				}
+				return nil
+			})
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if !reflect.DeepEqual(pages, test.pages) {
+				t.Errorf("expected pages %v, got %v", test.pages, pages)
+			}
+			var continues []string
+			for _, req := range client.requests {
+				continues = append(continues, req.Continue)
+				if req.Limit != 2 {
+					t.Errorf("expected limit 2, got %d", req.Limit)
+				}
+			}
+			if !reflect.DeepEqual(continues, test.continues) {
+				t.Errorf("expected continue tokens %q, got %q", test.continues, continues)
+			}
+		})
+	}
+}
+
+func TestListPagesWithHandler(t *testing.T) {
+	handler := newFakeHandler(newDeployment(1)).WithGVK(deploymentGVK)
+	var names []string
+	err := handler.ListPages(metav1.ListOptions{}, func(page []*unstructured.Unstructured) error {
+		for _, obj := range page {
+			names = append(names, obj.GetName())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"nginx"}) {
+		t.Errorf("expected [nginx], got %v", names)
+	}
+}
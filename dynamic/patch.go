@@ -1,6 +1,7 @@
 package dynamic
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -182,12 +183,8 @@ func (h *Handler) patchUnstructured(obj *unstructured.Unstructured, patchData []
 		return nil, err
 	}
 
-	if h.isNamespaced {
-		namespace := obj.GetNamespace()
-		if len(namespace) == 0 {
-			namespace = h.namespace
-		}
-		return h.dynamicClient.Resource(h.gvr).Namespace(namespace).Patch(h.ctx, obj.GetName(), patchType, patchData, h.Options.PatchOptions)
-	}
-	return h.dynamicClient.Resource(h.gvr).Patch(h.ctx, obj.GetName(), patchType, patchData, h.Options.PatchOptions)
+	namespace := h.objectNamespace(obj)
+	return h.interceptPatch(namespace, obj.GetName(), patchType, patchData, func(ctx context.Context, namespace string) (*unstructured.Unstructured, error) {
+		return h.resource(namespace).Patch(ctx, obj.GetName(), patchType, patchData, h.Options.PatchOptions)
+	})
 }
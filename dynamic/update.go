@@ -1,6 +1,7 @@
 package dynamic
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 
@@ -94,12 +95,16 @@ func (h *Handler) updateUnstructured(obj *unstructured.Unstructured) (*unstructu
 
 	obj.SetUID("")
 	obj.SetResourceVersion("")
-	if h.isNamespaced {
-		namespace := obj.GetNamespace()
-		if len(namespace) == 0 {
-			namespace = h.namespace
-		}
-		return h.dynamicClient.Resource(h.gvr).Namespace(namespace).Update(h.ctx, obj, h.Options.UpdateOptions)
-	}
-	return h.dynamicClient.Resource(h.gvr).Update(h.ctx, obj, h.Options.UpdateOptions)
+	return h.updateUnstructuredWithResourceVersion(obj)
+}
+
+// updateUnstructuredWithResourceVersion updates the k8s object without
+// clearing its resourceVersion, the kubernetes API server rejects the update
+// with a Conflict error if the k8s object has been changed since it was read.
+func (h *Handler) updateUnstructuredWithResourceVersion(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	namespace := h.objectNamespace(obj)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: obj.GetName(), Object: obj}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*unstructured.Unstructured, error) {
+		return h.resource(namespace).Update(ctx, obj, h.Options.UpdateOptions)
+	})
 }
@@ -0,0 +1,45 @@
+package dynamic
+
+import (
+	"time"
+
+	utilrestmapper "github.com/forbearing/k8s/util/restmapper"
+	utilwait "github.com/forbearing/k8s/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+// WaitFor blocks until the k8s object satisfies the condition, it works like
+// "kubectl wait --for=condition=Ready kind/name", eg:
+//
+//	handler.WithGVK(gvk).WaitFor("nginx", utilwait.ForCondition("Available", ""), utilwait.WithTimeout(time.Minute))
+//
+// You should always specify the GroupVersionKind with WithGVK() method.
+func (h *Handler) WaitFor(name string, cond utilwait.Condition, opts ...utilwait.Option) error {
+	return h.waitFor(utilwait.Target{Name: name}, cond, opts...)
+}
+
+// WaitForByLabel blocks until all k8s objects selected by the labels satisfy
+// the condition, it works like "kubectl wait --for=delete kind -l app=nginx".
+// You should always specify the GroupVersionKind with WithGVK() method.
+func (h *Handler) WaitForByLabel(labels string, cond utilwait.Condition, opts ...utilwait.Option) error {
+	return h.waitFor(utilwait.Target{LabelSelector: labels}, cond, opts...)
+}
+
+func (h *Handler) waitFor(target utilwait.Target, cond utilwait.Condition, opts ...utilwait.Option) (err error) {
+	start := time.Now()
+	defer func() { h.observer.WaitDone(h.gvk, cond.Name, time.Since(start), err) }()
+
+	gvr, err := utilrestmapper.GVKToGVR(h.restMapper, h.gvk)
+	if err != nil {
+		return err
+	}
+	isNamespaced, err := utilrestmapper.IsNamespaced(h.restMapper, h.gvk)
+	if err != nil {
+		return err
+	}
+	var client dynamic.ResourceInterface = h.dynamicClient.Resource(gvr)
+	if isNamespaced {
+		client = h.dynamicClient.Resource(gvr).Namespace(h.namespace)
+	}
+	return utilwait.For(h.ctx, client, target, cond, opts...)
+}
@@ -1,8 +1,9 @@
 package dynamic
 
 import (
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	utilrestmapper "github.com/forbearing/k8s/util/restmapper"
-	log "github.com/sirupsen/logrus"
+	"github.com/forbearing/k8s/util/selector"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/watch"
@@ -76,6 +77,9 @@ func (h *Handler) WatchByName(name string, addFunc, modifyFunc, deleteFunc func(
 //  * If Event.Type is Error: *api.Status is recommended; other types may make sense
 //    depending on context.
 func (h *Handler) WatchByLabel(labels string, addFunc, modifyFunc, deleteFunc func(obj interface{})) error {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
+		return err
+	}
 	return h.watchUnstructuredObj(
 		metav1.ListOptions{LabelSelector: labels, TimeoutSeconds: new(int64)},
 		addFunc, modifyFunc, deleteFunc)
@@ -99,6 +103,9 @@ func (h *Handler) WatchByField(field string, addFunc, modifyFunc, deleteFunc fun
 	if err != nil {
 		return err
 	}
+	if err := selector.ValidateFieldSelectorForGVK(h.gvk, field); err != nil {
+		return err
+	}
 	listOptions := metav1.ListOptions{FieldSelector: fieldSelector.String(), TimeoutSeconds: new(int64)}
 	return h.watchUnstructuredObj(listOptions, addFunc, modifyFunc, deleteFunc)
 }
@@ -120,11 +127,11 @@ func (h *Handler) watchUnstructuredObj(listOptions metav1.ListOptions,
 	for {
 		if h.isNamespaced {
 			if watcher, err = h.dynamicClient.Resource(h.gvr).Namespace(h.namespace).Watch(h.ctx, listOptions); err != nil {
-				return err
+				return utilerrors.Wrap("watch", h.gvk, h.namespace, "", err)
 			}
 		} else {
 			if watcher, err = h.dynamicClient.Resource(h.gvr).Watch(h.ctx, listOptions); err != nil {
-				return err
+				return utilerrors.Wrap("watch", h.gvk, "", "", err)
 			}
 		}
 		// Kubernetes retains the resource event history, which includes this
@@ -140,13 +147,14 @@ func (h *Handler) watchUnstructuredObj(listOptions metav1.ListOptions,
 			case watch.Deleted:
 				deleteFunc(event.Object)
 			case watch.Bookmark:
-				log.Debugf("watch %s: bookmark", h.gvr.Resource)
+				h.logger.V(1).Info("watch bookmark", "gvk", h.gvk.String(), "namespace", h.namespace, "verb", "watch")
 			case watch.Error:
-				log.Debugf("watch %s: error", h.gvr.Resource)
+				h.logger.V(1).Info("watch error", "gvk", h.gvk.String(), "namespace", h.namespace, "verb", "watch")
 			}
 		}
 		// If event channel is closed, it means the server has closed the connection
-		log.Debugf("watch %s: reconnect to kubernetes", h.gvr.Resource)
+		h.logger.V(1).Info("watch closed, reconnect to kubernetes", "gvk", h.gvk.String(), "namespace", h.namespace, "verb", "watch")
+		h.observer.WatchReconnected(h.gvk)
 		watcher.Stop()
 	}
 }
@@ -1,8 +1,10 @@
 package ingress
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*networkingv1.Ingress,
 
 // applyIngress
 func (h *Handler) applyIngress(ing *networkingv1.Ingress) (*networkingv1.Ingress, error) {
-	_, err := h.createIngress(ing)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateIngress(ing)
+	namespace := ing.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return ing, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: ing.Name, Object: ing}
+	return h.intercept(op, func(ctx context.Context, _ string) (*networkingv1.Ingress, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createIngress(ing)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateIngress(ing)
+		}
+		return ing, err
+	})
 }
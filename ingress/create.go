@@ -1,10 +1,12 @@
 package ingress
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createIngress(ing *networkingv1.Ingress) (*networkingv1.Ingres
 	}
 	ing.ResourceVersion = ""
 	ing.UID = ""
-	return h.clientset.NetworkingV1().Ingresses(namespace).Create(h.ctx, ing, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: ing.Name, Object: ing}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*networkingv1.Ingress, error) {
+		return h.clientset.NetworkingV1().Ingresses(namespace).Create(ctx, ing, h.Options.CreateOptions)
+	})
 }
@@ -0,0 +1,131 @@
+package ingress
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing ingress into it's desired state.
+type MutateFn func(ing *networkingv1.Ingress) error
+
+// CreateOrUpdate gets the ingress from the kubernetes API server. If the
+// ingress does not exist, it will be created after mutateFn is called.
+// If the ingress exists, mutateFn is called on the live ingress and the
+// ingress will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live ingress, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The ing must contain the name and namespace(optional) of the ingress,
+// ing will be overwritten by the live ingress returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(ing *networkingv1.Ingress, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getIngress(ing)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, ing); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createIngress(ing)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*ing = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*ing = *existing.DeepCopy()
+	if err := mutate(mutateFn, ing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, ing) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateIngressWithResourceVersion(ing)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*ing = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the ingress already exists,
+// only the difference between the live ingress and the mutated ingress
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(ing *networkingv1.Ingress, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getIngress(ing)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, ing); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createIngress(ing)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*ing = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*ing = *existing.DeepCopy()
+	if err := mutate(mutateFn, ing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(ing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, networkingv1.Ingress{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*ing = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, ing *networkingv1.Ingress) error {
+	name, namespace := ing.GetName(), ing.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(ing); err != nil {
+			return err
+		}
+	}
+	if ing.GetName() != name || ing.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate ingress name and/or ingress namespace")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package ingress
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes ingress by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.NetworkingV1().Ingresses(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*networkingv1.Ingress, error) {
+		return nil, h.clientset.NetworkingV1().Ingresses(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes ingress from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteIngress(ing *networkingv1.Ingress) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.NetworkingV1().Ingresses(namespace).Delete(h.ctx, ing.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: ing.Name, Object: ing}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*networkingv1.Ingress, error) {
+		return nil, h.clientset.NetworkingV1().Ingresses(namespace).Delete(ctx, ing.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*networkingv1.Ingress, error) {
 
 // GetByName gets ingress by name.
 func (h *Handler) GetByName(name string) (*networkingv1.Ingress, error) {
-	return h.clientset.NetworkingV1().Ingresses(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	ing, err := h.clientset.NetworkingV1().Ingresses(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return ing, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets ingress from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getIngress(ing *networkingv1.Ingress) (*networkingv1.Ingress,
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.NetworkingV1().Ingresses(namespace).Get(h.ctx, ing.Name, h.Options.GetOptions)
+	newIng, err := h.clientset.NetworkingV1().Ingresses(namespace).Get(h.ctx, ing.Name, h.Options.GetOptions)
+	return newIng, utilerrors.Wrap("get", GVK, namespace, ing.Name, err)
 }
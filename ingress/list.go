@@ -1,6 +1,7 @@
 package ingress
 
 import (
+	"github.com/forbearing/k8s/util/selector"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -15,13 +16,12 @@ func (h *Handler) List() ([]*networkingv1.Ingress, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*networkingv1.Ingress, error) {
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.LabelSelector = labels
-	ingList, err := h.clientset.NetworkingV1().Ingresses(h.namespace).List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
 		return nil, err
 	}
-	return extractList(ingList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.LabelSelector = labels
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByField list ingresses by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -30,14 +30,12 @@ func (h *Handler) ListByField(field string) ([]*networkingv1.Ingress, error) {
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	ingList, err := h.clientset.NetworkingV1().Ingresses(h.namespace).List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(ingList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByNamespace list all ingresses in the specified namespace.
@@ -49,12 +47,3 @@ func (h *Handler) ListByNamespace(namespace string) ([]*networkingv1.Ingress, er
 func (h *Handler) ListAll() ([]*networkingv1.Ingress, error) {
 	return h.WithNamespace(metav1.NamespaceAll).ListByLabel("")
 }
-
-// extractList
-func extractList(ingList *networkingv1.IngressList) []*networkingv1.Ingress {
-	var objList []*networkingv1.Ingress
-	for i := range ingList.Items {
-		objList = append(objList, &ingList.Items[i])
-	}
-	return objList
-}
@@ -1,10 +1,12 @@
 package ingress
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*networkingv1.Ingress
 
 // updateIngress
 func (h *Handler) updateIngress(ing *networkingv1.Ingress) (*networkingv1.Ingress, error) {
+	ing.ResourceVersion = ""
+	ing.UID = ""
+	return h.updateIngressWithResourceVersion(ing)
+}
+
+// updateIngressWithResourceVersion updates the ingress without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the ingress has been changed since it was read.
+func (h *Handler) updateIngressWithResourceVersion(ing *networkingv1.Ingress) (*networkingv1.Ingress, error) {
 	namespace := ing.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	ing.ResourceVersion = ""
-	ing.UID = ""
-	return h.clientset.NetworkingV1().Ingresses(namespace).Update(h.ctx, ing, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: ing.Name, Object: ing}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*networkingv1.Ingress, error) {
+		return h.clientset.NetworkingV1().Ingresses(namespace).Update(ctx, ing, h.Options.UpdateOptions)
+	})
 }
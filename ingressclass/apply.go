@@ -1,8 +1,10 @@
 package ingressclass
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,14 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*networkingv1.IngressC
 
 // applyIngressclass
 func (h *Handler) applyIngressclass(ingc *networkingv1.IngressClass) (*networkingv1.IngressClass, error) {
-	_, err := h.createIngressclass(ingc)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateIngressclass(ingc)
-	}
-	return ingc, err
+	op := &types.Operation{Verb: types.VerbApply, Name: ingc.Name, Object: ingc}
+	return h.intercept(op, func(ctx context.Context, _ string) (*networkingv1.IngressClass, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createIngressclass(ingc)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateIngressclass(ingc)
+		}
+		return ingc, err
+	})
 }
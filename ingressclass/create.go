@@ -1,10 +1,12 @@
 package ingressclass
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,8 @@ func (h *Handler) CreateFromMap(u map[string]interface{}) (*networkingv1.Ingress
 func (h *Handler) createIngressclass(ingc *networkingv1.IngressClass) (*networkingv1.IngressClass, error) {
 	ingc.ResourceVersion = ""
 	ingc.UID = ""
-	return h.clientset.NetworkingV1().IngressClasses().Create(h.ctx, ingc, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Name: ingc.Name, Object: ingc}
+	return h.intercept(op, func(ctx context.Context, _ string) (*networkingv1.IngressClass, error) {
+		return h.clientset.NetworkingV1().IngressClasses().Create(ctx, ingc, h.Options.CreateOptions)
+	})
 }
@@ -0,0 +1,130 @@
+package ingressclass
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing ingressclass into it's desired state.
+type MutateFn func(ingc *networkingv1.IngressClass) error
+
+// CreateOrUpdate gets the ingressclass from the kubernetes API server. If the
+// ingressclass does not exist, it will be created after mutateFn is called.
+// If the ingressclass exists, mutateFn is called on the live ingressclass and the
+// ingressclass will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live ingressclass, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The ingc must contain the name of the ingressclass, ingc will be overwritten
+// by the live ingressclass returned by the kubernetes API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(ingc *networkingv1.IngressClass, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getIngressclass(ingc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, ingc); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createIngressclass(ingc)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*ingc = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*ingc = *existing.DeepCopy()
+	if err := mutate(mutateFn, ingc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, ingc) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateIngressclassWithResourceVersion(ingc)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*ingc = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the ingressclass already exists,
+// only the difference between the live ingressclass and the mutated ingressclass
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(ingc *networkingv1.IngressClass, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getIngressclass(ingc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, ingc); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createIngressclass(ingc)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*ingc = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*ingc = *existing.DeepCopy()
+	if err := mutate(mutateFn, ingc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(ingc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, networkingv1.IngressClass{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*ingc = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, ingc *networkingv1.IngressClass) error {
+	name := ingc.GetName()
+	if mutateFn != nil {
+		if err := mutateFn(ingc); err != nil {
+			return err
+		}
+	}
+	if ingc.GetName() != name {
+		return fmt.Errorf("MutateFn cannot mutate ingressclass name")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package ingressclass
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes ingressclass by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.NetworkingV1().IngressClasses().Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*networkingv1.IngressClass, error) {
+		return nil, h.clientset.NetworkingV1().IngressClasses().Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes ingressclass from yaml or json file.
@@ -100,5 +106,9 @@ func (h *Handler) DeleteFromMap(u map[string]interface{}) error {
 
 // deleteIngressclass
 func (h *Handler) deleteIngressclass(ingc *networkingv1.IngressClass) error {
-	return h.clientset.NetworkingV1().IngressClasses().Delete(h.ctx, ingc.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: ingc.Name, Object: ingc}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*networkingv1.IngressClass, error) {
+		return nil, h.clientset.NetworkingV1().IngressClasses().Delete(ctx, ingc.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
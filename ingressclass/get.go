@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*networkingv1.IngressClass, error) {
 
 // GetByName gets ingressclass by name.
 func (h *Handler) GetByName(name string) (*networkingv1.IngressClass, error) {
-	return h.clientset.NetworkingV1().IngressClasses().Get(h.ctx, name, h.Options.GetOptions)
+	ingc, err := h.clientset.NetworkingV1().IngressClasses().Get(h.ctx, name, h.Options.GetOptions)
+	return ingc, utilerrors.Wrap("get", GVK, "", name, err)
 }
 
 // GetFromFile gets ingressclass from yaml or json file.
@@ -102,5 +104,6 @@ func (h *Handler) GetFromMap(u map[string]interface{}) (*networkingv1.IngressCla
 // It's necessary to get a new ingressclass resource from a old ingressclass resource,
 // because old ingressclass usually don't have ingressclass.Status field.
 func (h *Handler) getIngressclass(ingc *networkingv1.IngressClass) (*networkingv1.IngressClass, error) {
-	return h.clientset.NetworkingV1().IngressClasses().Get(h.ctx, ingc.Name, h.Options.GetOptions)
+	newIngc, err := h.clientset.NetworkingV1().IngressClasses().Get(h.ctx, ingc.Name, h.Options.GetOptions)
+	return newIngc, utilerrors.Wrap("get", GVK, "", ingc.Name, err)
 }
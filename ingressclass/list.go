@@ -1,6 +1,7 @@
 package ingressclass
 
 import (
+	"github.com/forbearing/k8s/util/selector"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/fields"
 )
@@ -14,13 +15,12 @@ func (h *Handler) List() ([]*networkingv1.IngressClass, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*networkingv1.IngressClass, error) {
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.LabelSelector = labels
-	ingcList, err := h.clientset.NetworkingV1().IngressClasses().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
 		return nil, err
 	}
-	return extractList(ingcList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.LabelSelector = labels
+	return h.listAll(*listOptions)
 }
 
 // ListByField list ingressclasses by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -29,26 +29,15 @@ func (h *Handler) ListByField(field string) ([]*networkingv1.IngressClass, error
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	ingcList, err := h.clientset.NetworkingV1().IngressClasses().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(ingcList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(*listOptions)
 }
 
 // ListAll list all ingressclasses in the k8s cluster.
 func (h *Handler) ListAll() ([]*networkingv1.IngressClass, error) {
 	return h.ListByLabel("")
 }
-
-// extractList
-func extractList(ingcList *networkingv1.IngressClassList) []*networkingv1.IngressClass {
-	var objList []*networkingv1.IngressClass
-	for i := range ingcList.Items {
-		objList = append(objList, &ingcList.Items[i])
-	}
-	return objList
-}
@@ -0,0 +1,184 @@
+package ingressclass
+
+import (
+	"context"
+
+	utilerrors "github.com/forbearing/k8s/util/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typednetworkingv1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+)
+
+// DefaultPageSize is the number of ingressclasses requested per page when
+// no limit is set by SetLimit().
+const DefaultPageSize = 500
+
+// ListPages list all ingressclasses in the k8s cluster page by page, and calls fn
+// for every page. ListPages stops and returns the error if fn returns an error.
+//
+// The label selector and field selector of listOptions are used to filter the
+// ingressclasses, the page size is listOptions.Limit, default to the limit set by
+// SetLimit(), then DefaultPageSize, eg:
+//
+//	err := handler.ListPages(metav1.ListOptions{LabelSelector: "app=nginx"}, func(page []*networkingv1.IngressClass) error {
+//	    ...
+//	})
+//
+// ListPages follows the continue token returned by kubernetes API server. If
+// the continue token expired, ListPages relists from the beginning and skips
+// the ingressclasses that already passed to fn.
+func (h *Handler) ListPages(listOptions metav1.ListOptions, fn func(page []*networkingv1.IngressClass) error) error {
+	return h.newPager(listOptions).forEach(fn)
+}
+
+// ListIterator iterates ingressclasses returned by the kubernetes API server one
+// by one, only one page of ingressclasses is kept in memory.
+type ListIterator struct {
+	pager *pager
+	page  []*networkingv1.IngressClass
+	index int
+	err   error
+}
+
+// ListIter returns a ListIterator that iterates all ingressclasses in the k8s
+// cluster, listOptions is used like ListPages.
+func (h *Handler) ListIter(listOptions metav1.ListOptions) *ListIterator {
+	return &ListIterator{pager: h.newPager(listOptions), index: -1}
+}
+
+// Next advances the iterator to the next ingressclass, it returns false when
+// there are no more ingressclasses or an error occurs.
+func (it *ListIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= len(it.page) {
+		if it.pager.done {
+			return false
+		}
+		if it.page, it.err = it.pager.next(); it.err != nil {
+			return false
+		}
+		it.index = 0
+	}
+	return true
+}
+
+// Object returns the current ingressclass.
+func (it *ListIterator) Object() *networkingv1.IngressClass {
+	if it.index < 0 || it.index >= len(it.page) {
+		return nil
+	}
+	return it.page[it.index]
+}
+
+// Err returns the error occurred during the iteration.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// pager requests ingressclasses from kubernetes API server page by page.
+type pager struct {
+	ctx         context.Context
+	client      typednetworkingv1.IngressClassInterface
+	listOptions metav1.ListOptions
+
+	// lastKey is the name of the last ingressclass returned by pager,
+	// the kubernetes API server always returns ingressclasses ordered by the key.
+	lastKey string
+	// relist is true after the continue token expired, ingressclasses which key
+	// is not greater than lastKey will be skipped.
+	relist bool
+	done   bool
+}
+
+// newPager returns a pager that lists all ingressclasses.
+func (h *Handler) newPager(listOptions metav1.ListOptions) *pager {
+	listOptions.Continue = ""
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = h.Options.ListOptions.Limit
+	}
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = DefaultPageSize
+	}
+	return &pager{
+		ctx:         h.ctx,
+		client:      h.clientset.NetworkingV1().IngressClasses(),
+		listOptions: listOptions,
+	}
+}
+
+// next returns the next page of ingressclasses.
+func (p *pager) next() ([]*networkingv1.IngressClass, error) {
+	if p.done {
+		return nil, nil
+	}
+	ingcList, err := p.client.List(p.ctx, p.listOptions)
+	if err != nil {
+		// The continue token expired, relist from the beginning.
+		if k8serrors.IsResourceExpired(err) && len(p.listOptions.Continue) != 0 {
+			p.listOptions.Continue = ""
+			p.listOptions.ResourceVersion = ""
+			p.relist = true
+			return nil, nil
+		}
+		return nil, utilerrors.Wrap("list", GVK, "", "", err)
+	}
+	p.listOptions.Continue = ingcList.Continue
+	if len(p.listOptions.Continue) == 0 {
+		p.done = true
+	}
+
+	var page []*networkingv1.IngressClass
+	for i := range ingcList.Items {
+		ingc := &ingcList.Items[i]
+		key := ingc.Name
+		if p.relist && key <= p.lastKey {
+			continue
+		}
+		page = append(page, ingc)
+		p.lastKey = key
+	}
+	return page, nil
+}
+
+// forEach calls fn for every page of ingressclasses until all ingressclasses are listed,
+// it stops and returns the error if fn returns an error.
+func (p *pager) forEach(fn func(page []*networkingv1.IngressClass) error) error {
+	for {
+		page, err := p.next()
+		if err != nil {
+			return err
+		}
+		if len(page) != 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+		if p.done {
+			return nil
+		}
+	}
+}
+
+// listAll follows the continue tokens to list all ingressclasses.
+func (h *Handler) listAll(listOptions metav1.ListOptions) ([]*networkingv1.IngressClass, error) {
+	listOptions.Continue = ""
+	p := &pager{
+		ctx:         h.ctx,
+		client:      h.clientset.NetworkingV1().IngressClasses(),
+		listOptions: listOptions,
+	}
+
+	var objList []*networkingv1.IngressClass
+	for !p.done {
+		page, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		objList = append(objList, page...)
+	}
+	return objList, nil
+}
@@ -1,10 +1,12 @@
 package ingressclass
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,15 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*networkingv1.Ingress
 func (h *Handler) updateIngressclass(ingc *networkingv1.IngressClass) (*networkingv1.IngressClass, error) {
 	ingc.ResourceVersion = ""
 	ingc.UID = ""
-	return h.clientset.NetworkingV1().IngressClasses().Update(h.ctx, ingc, h.Options.UpdateOptions)
+	return h.updateIngressclassWithResourceVersion(ingc)
+}
+
+// updateIngressclassWithResourceVersion updates the ingressclass without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the ingressclass has been changed since it was read.
+func (h *Handler) updateIngressclassWithResourceVersion(ingc *networkingv1.IngressClass) (*networkingv1.IngressClass, error) {
+	op := &types.Operation{Verb: types.VerbUpdate, Name: ingc.Name, Object: ingc}
+	return h.intercept(op, func(ctx context.Context, _ string) (*networkingv1.IngressClass, error) {
+		return h.clientset.NetworkingV1().IngressClasses().Update(ctx, ingc, h.Options.UpdateOptions)
+	})
 }
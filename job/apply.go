@@ -1,8 +1,10 @@
 package job
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	batchv1 "k8s.io/api/batch/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*batchv1.Job, error) {
 
 // applyJob
 func (h *Handler) applyJob(job *batchv1.Job) (*batchv1.Job, error) {
-	_, err := h.createJob(job)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateJob(job)
+	namespace := job.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return job, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: job.Name, Object: job}
+	return h.intercept(op, func(ctx context.Context, _ string) (*batchv1.Job, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createJob(job)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateJob(job)
+		}
+		return job, err
+	})
 }
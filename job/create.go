@@ -1,10 +1,12 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createJob(job *batchv1.Job) (*batchv1.Job, error) {
 	}
 	job.ResourceVersion = ""
 	job.UID = ""
-	return h.clientset.BatchV1().Jobs(namespace).Create(h.ctx, job, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: job.Name, Object: job}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*batchv1.Job, error) {
+		return h.clientset.BatchV1().Jobs(namespace).Create(ctx, job, h.Options.CreateOptions)
+	})
 }
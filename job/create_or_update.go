@@ -0,0 +1,131 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing job into it's desired state.
+type MutateFn func(job *batchv1.Job) error
+
+// CreateOrUpdate gets the job from the kubernetes API server. If the
+// job does not exist, it will be created after mutateFn is called.
+// If the job exists, mutateFn is called on the live job and the
+// job will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live job, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The job must contain the name and namespace(optional) of the job,
+// job will be overwritten by the live job returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(job *batchv1.Job, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getJob(job)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, job); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createJob(job)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*job = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*job = *existing.DeepCopy()
+	if err := mutate(mutateFn, job); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, job) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateJobWithResourceVersion(job)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*job = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the job already exists,
+// only the difference between the live job and the mutated job
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(job *batchv1.Job, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getJob(job)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, job); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createJob(job)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*job = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*job = *existing.DeepCopy()
+	if err := mutate(mutateFn, job); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(job); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, batchv1.Job{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*job = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, job *batchv1.Job) error {
+	name, namespace := job.GetName(), job.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(job); err != nil {
+			return err
+		}
+	}
+	if job.GetName() != name || job.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate job name and/or job namespace")
+	}
+	return nil
+}
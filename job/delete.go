@@ -1,10 +1,12 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes job by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.BatchV1().Jobs(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*batchv1.Job, error) {
+		return nil, h.clientset.BatchV1().Jobs(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes job from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteJob(job *batchv1.Job) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.BatchV1().Jobs(namespace).Delete(h.ctx, job.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: job.Name, Object: job}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*batchv1.Job, error) {
+		return nil, h.clientset.BatchV1().Jobs(namespace).Delete(ctx, job.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
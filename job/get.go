@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*batchv1.Job, error) {
 
 // GetByName gets job by name.
 func (h *Handler) GetByName(name string) (*batchv1.Job, error) {
-	return h.clientset.BatchV1().Jobs(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	job, err := h.clientset.BatchV1().Jobs(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return job, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets job from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getJob(job *batchv1.Job) (*batchv1.Job, error) {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.BatchV1().Jobs(namespace).Get(h.ctx, job.Name, h.Options.GetOptions)
+	newJob, err := h.clientset.BatchV1().Jobs(namespace).Get(h.ctx, job.Name, h.Options.GetOptions)
+	return newJob, utilerrors.Wrap("get", GVK, namespace, job.Name, err)
 }
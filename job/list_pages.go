@@ -0,0 +1,189 @@
+package job
+
+import (
+	"context"
+
+	utilerrors "github.com/forbearing/k8s/util/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedbatchv1 "k8s.io/client-go/kubernetes/typed/batch/v1"
+)
+
+// DefaultPageSize is the number of jobs requested per page when
+// no limit is set by SetLimit().
+const DefaultPageSize = 500
+
+// ListPages list the jobs in the handler namespace page by page, and
+// calls fn for every page. Use WithNamespace(metav1.NamespaceAll) to list the
+// jobs in all namespaces. ListPages stops and returns the error if fn
+// returns an error.
+//
+// The label selector and field selector of listOptions are used to filter the
+// jobs, the page size is listOptions.Limit, default to the limit set by
+// SetLimit(), then DefaultPageSize, eg:
+//
+//	err := handler.ListPages(metav1.ListOptions{LabelSelector: "app=nginx"}, func(page []*batchv1.Job) error {
+//	    ...
+//	})
+//
+// ListPages follows the continue token returned by kubernetes API server. If
+// the continue token expired, ListPages relists from the beginning and skips
+// the jobs that already passed to fn.
+func (h *Handler) ListPages(listOptions metav1.ListOptions, fn func(page []*batchv1.Job) error) error {
+	return h.newPager(listOptions).forEach(fn)
+}
+
+// ListIterator iterates jobs returned by the kubernetes API server one
+// by one, only one page of jobs is kept in memory.
+type ListIterator struct {
+	pager *pager
+	page  []*batchv1.Job
+	index int
+	err   error
+}
+
+// ListIter returns a ListIterator that iterates the jobs in the handler
+// namespace, listOptions is used like ListPages.
+func (h *Handler) ListIter(listOptions metav1.ListOptions) *ListIterator {
+	return &ListIterator{pager: h.newPager(listOptions), index: -1}
+}
+
+// Next advances the iterator to the next job, it returns false when
+// there are no more jobs or an error occurs.
+func (it *ListIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= len(it.page) {
+		if it.pager.done {
+			return false
+		}
+		if it.page, it.err = it.pager.next(); it.err != nil {
+			return false
+		}
+		it.index = 0
+	}
+	return true
+}
+
+// Object returns the current job.
+func (it *ListIterator) Object() *batchv1.Job {
+	if it.index < 0 || it.index >= len(it.page) {
+		return nil
+	}
+	return it.page[it.index]
+}
+
+// Err returns the error occurred during the iteration.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// pager requests jobs from kubernetes API server page by page.
+type pager struct {
+	ctx         context.Context
+	client      typedbatchv1.JobInterface
+	namespace   string
+	listOptions metav1.ListOptions
+
+	// lastKey is the "namespace/name" of the last job returned by pager,
+	// the kubernetes API server always returns jobs ordered by the key.
+	lastKey string
+	// relist is true after the continue token expired, jobs which key
+	// is not greater than lastKey will be skipped.
+	relist bool
+	done   bool
+}
+
+// newPager returns a pager that lists the jobs in the handler namespace.
+func (h *Handler) newPager(listOptions metav1.ListOptions) *pager {
+	listOptions.Continue = ""
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = h.Options.ListOptions.Limit
+	}
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = DefaultPageSize
+	}
+	return &pager{
+		ctx:         h.ctx,
+		namespace:   h.namespace,
+		client:      h.clientset.BatchV1().Jobs(h.namespace),
+		listOptions: listOptions,
+	}
+}
+
+// next returns the next page of jobs.
+func (p *pager) next() ([]*batchv1.Job, error) {
+	if p.done {
+		return nil, nil
+	}
+	jobList, err := p.client.List(p.ctx, p.listOptions)
+	if err != nil {
+		// The continue token expired, relist from the beginning.
+		if k8serrors.IsResourceExpired(err) && len(p.listOptions.Continue) != 0 {
+			p.listOptions.Continue = ""
+			p.listOptions.ResourceVersion = ""
+			p.relist = true
+			return nil, nil
+		}
+		return nil, utilerrors.Wrap("list", GVK, p.namespace, "", err)
+	}
+	p.listOptions.Continue = jobList.Continue
+	if len(p.listOptions.Continue) == 0 {
+		p.done = true
+	}
+
+	var page []*batchv1.Job
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		key := job.Namespace + "/" + job.Name
+		if p.relist && key <= p.lastKey {
+			continue
+		}
+		page = append(page, job)
+		p.lastKey = key
+	}
+	return page, nil
+}
+
+// forEach calls fn for every page of jobs until all jobs are listed,
+// it stops and returns the error if fn returns an error.
+func (p *pager) forEach(fn func(page []*batchv1.Job) error) error {
+	for {
+		page, err := p.next()
+		if err != nil {
+			return err
+		}
+		if len(page) != 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+		if p.done {
+			return nil
+		}
+	}
+}
+
+// listAll follows the continue tokens to list all jobs.
+func (h *Handler) listAll(namespace string, listOptions metav1.ListOptions) ([]*batchv1.Job, error) {
+	listOptions.Continue = ""
+	p := &pager{
+		ctx:         h.ctx,
+		namespace:   namespace,
+		client:      h.clientset.BatchV1().Jobs(namespace),
+		listOptions: listOptions,
+	}
+
+	var objList []*batchv1.Job
+	for !p.done {
+		page, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		objList = append(objList, page...)
+	}
+	return objList, nil
+}
@@ -0,0 +1,23 @@
+package job
+
+import (
+	"github.com/forbearing/k8s/util/logs"
+	"github.com/forbearing/k8s/util/selector"
+)
+
+// StreamLogs streams the logs of all pods and containers of the job like
+// stern, the lines are prefixed with the pod and container names, see package
+// util/logs. It follows the new pods if opts.Follow is true, eg:
+//
+//	err := handler.WithContext(ctx).StreamLogs(name, &logs.Options{Follow: true, TailLines: &lines})
+func (h *Handler) StreamLogs(name string, opts *logs.Options) error {
+	job, err := h.Get(name)
+	if err != nil {
+		return err
+	}
+	podSelector, err := selector.ForObject(job)
+	if err != nil {
+		return err
+	}
+	return logs.Stream(h.ctx, h.clientset, job.Namespace, podSelector, opts)
+}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"time"
 
+	utilwait "github.com/forbearing/k8s/util/wait"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 //type JobController struct {
@@ -98,69 +100,53 @@ func (h *Handler) IsFinished(name string) bool {
 	return false
 }
 
-// WaitFinish wait job status to be "true"
-func (h *Handler) WaitFinish(name string) (err error) {
-	var (
-		watcher watch.Interface
-		timeout = int64(0)
-	)
-	if h.IsFinished(name) {
-		return
+// WaitFinish waits for the job to finish, it doesn't matter if the job runs to
+// completion or fails. It watches the job and honours the handler context, use
+// WithContext() to pass the per-call context, and utilwait.WithTimeout/WithProgress
+// to set the timeout and to report why the job is not finished yet, eg:
+//
+//	err := handler.WithContext(ctx).WaitFinish(name, utilwait.WithTimeout(5*time.Minute))
+//
+// WaitFinish returns the NotFound error immediately if the job doesn't exist,
+// and utilwait.ErrDeleted if the job is deleted while waiting. Without
+// utilwait.WithTimeout it waits until the handler context is done.
+func (h *Handler) WaitFinish(name string, opts ...utilwait.Option) error {
+	// the wait by name waits for the job to be created, get it first so a
+	// missing job doesn't block until timeout.
+	if _, err := h.Get(name); err != nil {
+		return err
 	}
-
-	for {
-		listOptions := metav1.SingleObject(metav1.ObjectMeta{Name: name, Namespace: h.namespace})
-		listOptions.TimeoutSeconds = &timeout
-		watcher, err = h.clientset.BatchV1().Jobs(h.namespace).Watch(h.ctx, listOptions)
-		if err != nil {
-			return
-		}
-		for event := range watcher.ResultChan() {
-			switch event.Type {
-			case watch.Modified:
-				if h.IsFinished(name) {
-					watcher.Stop()
-					return
-				}
-			case watch.Deleted:
-				watcher.Stop()
-				return fmt.Errorf("%s deleted", name)
-			}
+	cond := utilwait.Condition{Name: "finished", Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		job := &batchv1.Job{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), job); err != nil {
+			return false, "", err
 		}
-	}
+		return finished(job)
+	}}
+	return h.waitFor(utilwait.Target{Name: name}, cond, opts...)
 }
 
-// WaitNotExist wait job not exist
-func (h *Handler) WaitNotExist(name string) (err error) {
-	var (
-		watcher watch.Interface
-		timeout = int64(0)
-	)
-	_, err = h.Get(name)
-	if err != nil { // job not exist
-		return nil
-	}
-	for {
-		listOptions := metav1.SingleObject(metav1.ObjectMeta{Name: name, Namespace: h.namespace})
-		listOptions.TimeoutSeconds = &timeout
-		watcher, err = h.clientset.BatchV1().Jobs(h.namespace).Watch(h.ctx, listOptions)
-		if err != nil {
-			return
-		}
-		for event := range watcher.ResultChan() {
-			switch event.Type {
-			case watch.Deleted:
-				for {
-					if _, err := h.Get(name); err != nil { // job not exist
-						break
-					}
-					time.Sleep(time.Millisecond * 500)
-				}
-				watcher.Stop()
-				return
-			}
+// finished returns whether the job is complete or failed and the reason if it's not.
+func finished(job *batchv1.Job) (bool, string, error) {
+	for _, cond := range job.Status.Conditions {
+		if (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) && cond.Status == corev1.ConditionTrue {
+			return true, "", nil
 		}
 	}
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	return false, fmt.Sprintf("%d/%d completions succeeded, %d active, %d failed",
+		job.Status.Succeeded, completions, job.Status.Active, job.Status.Failed), nil
+}
+
+// WaitNotExist waits for the job to be deleted, it returns immediately if the
+// job doesn't exist. It honours the handler context and the utilwait options
+// like WaitFinish, and returns the error if the job can't be listed, such like
+// forbidden.
+func (h *Handler) WaitNotExist(name string, opts ...utilwait.Option) error {
+	return h.waitFor(utilwait.Target{Name: name}, utilwait.ForDelete(), opts...)
 }
 
 func (h *Handler) getController(jobObj *batchv1.Job) (*JobController, error) {
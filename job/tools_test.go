@@ -0,0 +1,131 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/forbearing/k8s/types"
+	utilwait "github.com/forbearing/k8s/util/wait"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestFinished(t *testing.T) {
+	condition := func(conditionType batchv1.JobConditionType, status corev1.ConditionStatus) batchv1.JobCondition {
+		return batchv1.JobCondition{Type: conditionType, Status: status}
+	}
+	completions := int32(3)
+	tests := []struct {
+		name     string
+		job      batchv1.Job
+		finished bool
+		reason   string
+	}{
+		{
+			name:   "running",
+			job:    batchv1.Job{Status: batchv1.JobStatus{Active: 1}},
+			reason: "0/1 completions succeeded, 1 active, 0 failed",
+		},
+		{
+			name: "running with completions",
+			job: batchv1.Job{
+				Spec:   batchv1.JobSpec{Completions: &completions},
+				Status: batchv1.JobStatus{Succeeded: 1, Active: 1, Failed: 1},
+			},
+			reason: "1/3 completions succeeded, 1 active, 1 failed",
+		},
+		{
+			name:     "complete",
+			job:      batchv1.Job{Status: batchv1.JobStatus{Succeeded: 1, Conditions: []batchv1.JobCondition{condition(batchv1.JobComplete, corev1.ConditionTrue)}}},
+			finished: true,
+		},
+		{
+			name:     "failed",
+			job:      batchv1.Job{Status: batchv1.JobStatus{Failed: 1, Conditions: []batchv1.JobCondition{condition(batchv1.JobFailed, corev1.ConditionTrue)}}},
+			finished: true,
+		},
+		{
+			name:   "suspended",
+			job:    batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{condition(batchv1.JobSuspended, corev1.ConditionTrue)}}},
+			reason: "0/1 completions succeeded, 0 active, 0 failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			done, reason, err := finished(&test.job)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if done != test.finished || reason != test.reason {
+				t.Errorf("expected finished %t with reason %q, got %t with reason %q", test.finished, test.reason, done, reason)
+			}
+		})
+	}
+}
+
+func newFakeHandler(objects ...runtime.Object) (*Handler, *fake.FakeDynamicClient) {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{GVR: "JobList"}, objects...)
+	return &Handler{
+		ctx:           context.TODO(),
+		namespace:     "test",
+		observer:      types.NopObserver{},
+		dynamicClient: client,
+		Options:       &types.HandlerOptions{},
+	}, client
+}
+
+func newJob(name string) *unstructured.Unstructured {
+	job := &unstructured.Unstructured{}
+	job.SetGroupVersionKind(GVK)
+	job.SetName(name)
+	job.SetNamespace("test")
+	return job
+}
+
+func TestWaitNotExist(t *testing.T) {
+	t.Run("not exist", func(t *testing.T) {
+		handler, _ := newFakeHandler()
+		if err := handler.WaitNotExist("pi", utilwait.WithTimeout(10*time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		handler, client := newFakeHandler(newJob("pi"))
+		resource := client.Resource(GVR).Namespace("test")
+		err := handler.WaitNotExist("pi", utilwait.WithTimeout(10*time.Second), utilwait.WithProgress(func(utilwait.Progress) {
+			go resource.Delete(context.TODO(), "pi", metav1.DeleteOptions{})
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		handler, _ := newFakeHandler(newJob("pi"))
+		err := handler.WaitNotExist("pi", utilwait.WithTimeout(100*time.Millisecond))
+		var timeoutErr *utilwait.TimeoutError
+		if !errors.As(err, &timeoutErr) {
+			t.Fatalf("expected *utilwait.TimeoutError, got %v", err)
+		}
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		handler, _ := newFakeHandler(newJob("pi"))
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		handler.ctx = ctx
+		if err := handler.WaitNotExist("pi"); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
@@ -1,10 +1,12 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,12 +94,22 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*batchv1.Job, error)
 
 // updateJob
 func (h *Handler) updateJob(job *batchv1.Job) (*batchv1.Job, error) {
+	job.ResourceVersion = ""
+	job.UID = ""
+	return h.updateJobWithResourceVersion(job)
+}
+
+// updateJobWithResourceVersion updates the job without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the job has been changed since it was read.
+func (h *Handler) updateJobWithResourceVersion(job *batchv1.Job) (*batchv1.Job, error) {
 	namespace := job.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
 	//// resourceVersion cann't be set, the resourceVersion field is empty.
-	job.ResourceVersion = ""
-	job.UID = ""
-	return h.clientset.BatchV1().Jobs(namespace).Update(h.ctx, job, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: job.Name, Object: job}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*batchv1.Job, error) {
+		return h.clientset.BatchV1().Jobs(namespace).Update(ctx, job, h.Options.UpdateOptions)
+	})
 }
@@ -1,8 +1,10 @@
 package namespace
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,14 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*corev1.Namespace, err
 
 // applyNamespace
 func (h *Handler) applyNamespace(ns *corev1.Namespace) (*corev1.Namespace, error) {
-	_, err := h.createNamespace(ns)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateNamespace(ns)
-	}
-	return ns, err
+	op := &types.Operation{Verb: types.VerbApply, Name: ns.Name, Object: ns}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.Namespace, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createNamespace(ns)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateNamespace(ns)
+		}
+		return ns, err
+	})
 }
@@ -1,10 +1,12 @@
 package namespace
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,8 @@ func (h *Handler) CreateFromMap(u map[string]interface{}) (*corev1.Namespace, er
 func (h *Handler) createNamespace(ns *corev1.Namespace) (*corev1.Namespace, error) {
 	ns.ResourceVersion = ""
 	ns.UID = ""
-	return h.clientset.CoreV1().Namespaces().Create(h.ctx, ns, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Name: ns.Name, Object: ns}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.Namespace, error) {
+		return h.clientset.CoreV1().Namespaces().Create(ctx, ns, h.Options.CreateOptions)
+	})
 }
@@ -0,0 +1,130 @@
+package namespace
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing namespace into it's desired state.
+type MutateFn func(ns *corev1.Namespace) error
+
+// CreateOrUpdate gets the namespace from the kubernetes API server. If the
+// namespace does not exist, it will be created after mutateFn is called.
+// If the namespace exists, mutateFn is called on the live namespace and the
+// namespace will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live namespace, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The ns must contain the name of the namespace, ns will be overwritten
+// by the live namespace returned by the kubernetes API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(ns *corev1.Namespace, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getNamespace(ns)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, ns); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createNamespace(ns)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*ns = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*ns = *existing.DeepCopy()
+	if err := mutate(mutateFn, ns); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, ns) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateNamespaceWithResourceVersion(ns)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*ns = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the namespace already exists,
+// only the difference between the live namespace and the mutated namespace
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(ns *corev1.Namespace, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getNamespace(ns)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, ns); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createNamespace(ns)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*ns = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*ns = *existing.DeepCopy()
+	if err := mutate(mutateFn, ns); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(ns); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, corev1.Namespace{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*ns = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, ns *corev1.Namespace) error {
+	name := ns.GetName()
+	if mutateFn != nil {
+		if err := mutateFn(ns); err != nil {
+			return err
+		}
+	}
+	if ns.GetName() != name {
+		return fmt.Errorf("MutateFn cannot mutate namespace name")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package namespace
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes namespace by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.CoreV1().Namespaces().Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*corev1.Namespace, error) {
+		return nil, h.clientset.CoreV1().Namespaces().Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes namespace from yaml or json file.
@@ -100,5 +106,9 @@ func (h *Handler) DeleteFromMap(u map[string]interface{}) error {
 
 // deleteNamespace
 func (h *Handler) deleteNamespace(ns *corev1.Namespace) error {
-	return h.clientset.CoreV1().Namespaces().Delete(h.ctx, ns.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: ns.Name, Object: ns}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*corev1.Namespace, error) {
+		return nil, h.clientset.CoreV1().Namespaces().Delete(ctx, ns.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
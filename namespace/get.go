@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*corev1.Namespace, error) {
 
 // GetByName gets namespace by name.
 func (h *Handler) GetByName(name string) (*corev1.Namespace, error) {
-	return h.clientset.CoreV1().Namespaces().Get(h.ctx, name, h.Options.GetOptions)
+	ns, err := h.clientset.CoreV1().Namespaces().Get(h.ctx, name, h.Options.GetOptions)
+	return ns, utilerrors.Wrap("get", GVK, "", name, err)
 }
 
 // GetFromFile gets namespace from yaml or json file.
@@ -102,5 +104,6 @@ func (h *Handler) GetFromMap(u map[string]interface{}) (*corev1.Namespace, error
 // It's necessary to get a new namespace resource from a old namespace resource,
 // because old namespace usually don't have namespace.Status field.
 func (h *Handler) getNamespace(ns *corev1.Namespace) (*corev1.Namespace, error) {
-	return h.clientset.CoreV1().Namespaces().Get(h.ctx, ns.Name, h.Options.GetOptions)
+	newNS, err := h.clientset.CoreV1().Namespaces().Get(h.ctx, ns.Name, h.Options.GetOptions)
+	return newNS, utilerrors.Wrap("get", GVK, "", ns.Name, err)
 }
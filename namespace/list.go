@@ -1,6 +1,7 @@
 package namespace
 
 import (
+	"github.com/forbearing/k8s/util/selector"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 )
@@ -14,13 +15,12 @@ func (h *Handler) List() ([]*corev1.Namespace, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*corev1.Namespace, error) {
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.LabelSelector = labels
-	nsList, err := h.clientset.CoreV1().Namespaces().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
 		return nil, err
 	}
-	return extractList(nsList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.LabelSelector = labels
+	return h.listAll(*listOptions)
 }
 
 // ListByField list namespaces by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -29,26 +29,15 @@ func (h *Handler) ListByField(field string) ([]*corev1.Namespace, error) {
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	nsList, err := h.clientset.CoreV1().Namespaces().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(nsList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(*listOptions)
 }
 
 // ListAll list all namespaces in the k8s cluster.
 func (h *Handler) ListAll() ([]*corev1.Namespace, error) {
 	return h.ListByLabel("")
 }
-
-// extractList
-func extractList(nsList *corev1.NamespaceList) []*corev1.Namespace {
-	var objList []*corev1.Namespace
-	for i := range nsList.Items {
-		objList = append(objList, &nsList.Items[i])
-	}
-	return objList
-}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/forbearing/k8s/types"
 	"github.com/forbearing/k8s/util/client"
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -23,6 +24,12 @@ import (
 type Handler struct {
 	ctx        context.Context
 	kubeconfig string
+	logger     logr.Logger
+	observer   types.Observer
+
+	interceptors    []types.Interceptor
+	strictNamespace types.StrictNamespaceConfig
+	warnings        types.WarningConfig
 
 	config          *rest.Config
 	httpClient      *http.Client
@@ -43,8 +50,8 @@ type Handler struct {
 
 // NewOrDie simply call New() to get a namespace handler.
 // panic if there is any error occurs.
-func NewOrDie(ctx context.Context, kubeconfig string) *Handler {
-	handler, err := New(ctx, kubeconfig)
+func NewOrDie(ctx context.Context, kubeconfig string, opts ...types.HandlerOption) *Handler {
+	handler, err := New(ctx, kubeconfig, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -57,7 +64,7 @@ func NewOrDie(ctx context.Context, kubeconfig string) *Handler {
 // * KUBECONFIG environment variable pointing at a file.
 // * $HOME/.kube/config if exists.
 // * In-cluster config if running in cluster.
-func New(ctx context.Context, kubeconfig string) (*Handler, error) {
+func New(ctx context.Context, kubeconfig string, opts ...types.HandlerOption) (*Handler, error) {
 	var (
 		err             error
 		config          *rest.Config
@@ -77,6 +84,12 @@ func New(ctx context.Context, kubeconfig string) (*Handler, error) {
 	if config, err = client.RESTConfig(kubeconfig); err != nil {
 		return nil, err
 	}
+	// the logger stored in ctx is used if no logger option is passed.
+	handlerConfig := types.NewHandlerConfig(ctx, opts...)
+	// wrap the http transport, such like collecting metrics.
+	for _, wrap := range handlerConfig.TransportWrappers {
+		config.Wrap(wrap)
+	}
 	// setup APIPath, GroupVersion and NegotiatedSerializer before initializing a RESTClient
 	config.APIPath = "api"
 	config.GroupVersion = &corev1.SchemeGroupVersion
@@ -107,6 +120,11 @@ func New(ctx context.Context, kubeconfig string) (*Handler, error) {
 
 	return &Handler{
 		ctx:             ctx,
+		logger:          handlerConfig.Logger.WithValues("gvk", GVK.String()),
+		observer:        handlerConfig.Observer,
+		interceptors:    handlerConfig.Interceptors,
+		strictNamespace: handlerConfig.StrictNamespace,
+		warnings:        handlerConfig.Warnings,
 		kubeconfig:      kubeconfig,
 		config:          config,
 		httpClient:      httpClient,
@@ -119,6 +137,15 @@ func New(ctx context.Context, kubeconfig string) (*Handler, error) {
 	}, nil
 }
 
+// WithContext deep copies a new handler that uses ctx to send requests to the
+// kubernetes API server, such like the per-call deadline and trace span,
+// eg: handler.WithContext(ctx).Create(filename).
+func (h *Handler) WithContext(ctx context.Context) *Handler {
+	handler := h.DeepCopy()
+	handler.ctx = ctx
+	return handler
+}
+
 // WithDryRun deep copies a new handler and prints the create/update/apply/delete
 // operations, without sending it to apiserver.
 func (h *Handler) WithDryRun() *Handler {
@@ -136,6 +163,11 @@ func (in *Handler) DeepCopy() *Handler {
 	}
 	return &Handler{
 		ctx:              in.ctx,
+		logger:           in.logger,
+		observer:         in.observer,
+		interceptors:     append([]types.Interceptor(nil), in.interceptors...),
+		strictNamespace:  in.strictNamespace,
+		warnings:         in.warnings,
 		kubeconfig:       in.kubeconfig,
 		config:           in.config,
 		httpClient:       in.httpClient,
@@ -159,6 +191,9 @@ func (in *Handler) DeepCopy() *Handler {
 	}
 }
 
+// SetLimit sets the number of namespaces requested from the kubernetes API
+// server per page. List methods always follow the continue token and return
+// all namespaces, ListPages() and ListIter() return them page by page.
 func (h *Handler) SetLimit(limit int64) {
 	h.l.Lock()
 	defer h.l.Unlock()
@@ -1,10 +1,12 @@
 package namespace
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,15 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*corev1.Namespace, er
 func (h *Handler) updateNamespace(ns *corev1.Namespace) (*corev1.Namespace, error) {
 	ns.ResourceVersion = ""
 	ns.UID = ""
-	return h.clientset.CoreV1().Namespaces().Update(h.ctx, ns, h.Options.UpdateOptions)
+	return h.updateNamespaceWithResourceVersion(ns)
+}
+
+// updateNamespaceWithResourceVersion updates the namespace without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the namespace has been changed since it was read.
+func (h *Handler) updateNamespaceWithResourceVersion(ns *corev1.Namespace) (*corev1.Namespace, error) {
+	op := &types.Operation{Verb: types.VerbUpdate, Name: ns.Name, Object: ns}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.Namespace, error) {
+		return h.clientset.CoreV1().Namespaces().Update(ctx, ns, h.Options.UpdateOptions)
+	})
 }
@@ -1,8 +1,10 @@
 package networkpolicy
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*networkingv1.NetworkP
 
 // applyNetpol
 func (h *Handler) applyNetpol(netpol *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
-	_, err := h.createNetpol(netpol)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateNetpol(netpol)
+	namespace := netpol.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return netpol, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: netpol.Name, Object: netpol}
+	return h.intercept(op, func(ctx context.Context, _ string) (*networkingv1.NetworkPolicy, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createNetpol(netpol)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateNetpol(netpol)
+		}
+		return netpol, err
+	})
 }
@@ -1,10 +1,12 @@
 package networkpolicy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createNetpol(netpol *networkingv1.NetworkPolicy) (*networkingv
 	}
 	netpol.ResourceVersion = ""
 	netpol.UID = ""
-	return h.clientset.NetworkingV1().NetworkPolicies(namespace).Create(h.ctx, netpol, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: netpol.Name, Object: netpol}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*networkingv1.NetworkPolicy, error) {
+		return h.clientset.NetworkingV1().NetworkPolicies(namespace).Create(ctx, netpol, h.Options.CreateOptions)
+	})
 }
@@ -0,0 +1,131 @@
+package networkpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing networkpolicy into it's desired state.
+type MutateFn func(netpol *networkingv1.NetworkPolicy) error
+
+// CreateOrUpdate gets the networkpolicy from the kubernetes API server. If the
+// networkpolicy does not exist, it will be created after mutateFn is called.
+// If the networkpolicy exists, mutateFn is called on the live networkpolicy and the
+// networkpolicy will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live networkpolicy, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The netpol must contain the name and namespace(optional) of the networkpolicy,
+// netpol will be overwritten by the live networkpolicy returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(netpol *networkingv1.NetworkPolicy, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getNetpol(netpol)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, netpol); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createNetpol(netpol)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*netpol = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*netpol = *existing.DeepCopy()
+	if err := mutate(mutateFn, netpol); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, netpol) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateNetpolWithResourceVersion(netpol)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*netpol = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the networkpolicy already exists,
+// only the difference between the live networkpolicy and the mutated networkpolicy
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(netpol *networkingv1.NetworkPolicy, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getNetpol(netpol)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, netpol); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createNetpol(netpol)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*netpol = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*netpol = *existing.DeepCopy()
+	if err := mutate(mutateFn, netpol); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(netpol); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, networkingv1.NetworkPolicy{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*netpol = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, netpol *networkingv1.NetworkPolicy) error {
+	name, namespace := netpol.GetName(), netpol.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(netpol); err != nil {
+			return err
+		}
+	}
+	if netpol.GetName() != name || netpol.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate networkpolicy name and/or networkpolicy namespace")
+	}
+	return nil
+}
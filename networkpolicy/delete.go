@@ -1,10 +1,12 @@
 package networkpolicy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes networkpolicy by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.NetworkingV1().NetworkPolicies(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*networkingv1.NetworkPolicy, error) {
+		return nil, h.clientset.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes networkpolicy from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteNetpol(netpol *networkingv1.NetworkPolicy) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.NetworkingV1().NetworkPolicies(namespace).Delete(h.ctx, netpol.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: netpol.Name, Object: netpol}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*networkingv1.NetworkPolicy, error) {
+		return nil, h.clientset.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, netpol.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
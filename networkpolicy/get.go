@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*networkingv1.NetworkPolicy, error) {
 
 // GetByName gets networkpolicy by name.
 func (h *Handler) GetByName(name string) (*networkingv1.NetworkPolicy, error) {
-	return h.clientset.NetworkingV1().NetworkPolicies(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	netpol, err := h.clientset.NetworkingV1().NetworkPolicies(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return netpol, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets networkpolicy from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getNetpol(netpol *networkingv1.NetworkPolicy) (*networkingv1.N
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.NetworkingV1().NetworkPolicies(namespace).Get(h.ctx, netpol.Name, h.Options.GetOptions)
+	newNetpol, err := h.clientset.NetworkingV1().NetworkPolicies(namespace).Get(h.ctx, netpol.Name, h.Options.GetOptions)
+	return newNetpol, utilerrors.Wrap("get", GVK, namespace, netpol.Name, err)
 }
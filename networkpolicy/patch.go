@@ -1,6 +1,7 @@
 package networkpolicy
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -117,8 +118,10 @@ func (h *Handler) strategicMergePatch(original *networkingv1.NetworkPolicy, patc
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.NetworkingV1().NetworkPolicies(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*networkingv1.NetworkPolicy, error) {
+		return h.clientset.NetworkingV1().NetworkPolicies(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonMergePatch use the "JSON Merge Patch" patch type to patch networkpolicy.
@@ -140,8 +143,10 @@ func (h *Handler) jsonMergePatch(original *networkingv1.NetworkPolicy, patchData
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.NetworkingV1().NetworkPolicies(namespace).
-		Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*networkingv1.NetworkPolicy, error) {
+		return h.clientset.NetworkingV1().NetworkPolicies(namespace).
+			Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonPatch use "JSON Patch" patch type to patch networkpolicy.
@@ -156,8 +161,10 @@ func (h *Handler) jsonPatch(original *networkingv1.NetworkPolicy, patchData []by
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.NetworkingV1().NetworkPolicies(namespace).Patch(h.ctx,
-		original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.JSONPatchType, patchData, func(ctx context.Context, namespace string) (*networkingv1.NetworkPolicy, error) {
+		return h.clientset.NetworkingV1().NetworkPolicies(namespace).Patch(ctx,
+			original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // diffMergePatch will tak the difference data between original and modified networkpolicy object,
@@ -190,9 +197,13 @@ func (h *Handler) diffMergePatch(original, modified *networkingv1.NetworkPolicy,
 		namespace = h.namespace
 	}
 	if len(patchOptions) != 0 && patchOptions[0] == types.MergePatchType {
-		return h.clientset.NetworkingV1().NetworkPolicies(namespace).
-			Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*networkingv1.NetworkPolicy, error) {
+			return h.clientset.NetworkingV1().NetworkPolicies(namespace).
+				Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		})
 	}
-	return h.clientset.NetworkingV1().NetworkPolicies(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*networkingv1.NetworkPolicy, error) {
+		return h.clientset.NetworkingV1().NetworkPolicies(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
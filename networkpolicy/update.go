@@ -1,10 +1,12 @@
 package networkpolicy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*networkingv1.Network
 
 // updateNetpol
 func (h *Handler) updateNetpol(netpol *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	netpol.ResourceVersion = ""
+	netpol.UID = ""
+	return h.updateNetpolWithResourceVersion(netpol)
+}
+
+// updateNetpolWithResourceVersion updates the networkpolicy without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the networkpolicy has been changed since it was read.
+func (h *Handler) updateNetpolWithResourceVersion(netpol *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
 	namespace := netpol.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	netpol.ResourceVersion = ""
-	netpol.UID = ""
-	return h.clientset.NetworkingV1().NetworkPolicies(namespace).Update(h.ctx, netpol, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: netpol.Name, Object: netpol}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*networkingv1.NetworkPolicy, error) {
+		return h.clientset.NetworkingV1().NetworkPolicies(namespace).Update(ctx, netpol, h.Options.UpdateOptions)
+	})
 }
@@ -1,8 +1,10 @@
 package node
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,14 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*corev1.Node, error) {
 
 // applyNode
 func (h *Handler) applyNode(node *corev1.Node) (*corev1.Node, error) {
-	_, err := h.createNode(node)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateNode(node)
-	}
-	return node, err
+	op := &types.Operation{Verb: types.VerbApply, Name: node.Name, Object: node}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.Node, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createNode(node)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateNode(node)
+		}
+		return node, err
+	})
 }
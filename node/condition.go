@@ -0,0 +1,120 @@
+package node
+
+import (
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Condition is a condition of the node.
+type Condition struct {
+	Type    corev1.NodeConditionType
+	Status  corev1.ConditionStatus
+	Reason  string
+	Message string
+	// Healthy is true if the Ready condition is True, or the other
+	// conditions, such like MemoryPressure, are False.
+	Healthy            bool
+	LastHeartbeatTime  time.Time
+	LastTransitionTime time.Time
+}
+
+// ConditionSummary summarises the conditions of the node.
+type ConditionSummary struct {
+	Name               string
+	Ready              bool
+	MemoryPressure     bool
+	DiskPressure       bool
+	PIDPressure        bool
+	NetworkUnavailable bool
+	// Conditions are all conditions of the node sorted by type, the Ready
+	// condition goes first.
+	Conditions []Condition
+}
+
+// Healthy reports whether all conditions of the node are healthy.
+func (s *ConditionSummary) Healthy() bool {
+	for _, c := range s.Conditions {
+		if !c.Healthy {
+			return false
+		}
+	}
+	return s.Ready
+}
+
+// Unhealthy returns the unhealthy conditions of the node.
+func (s *ConditionSummary) Unhealthy() []Condition {
+	var conditions []Condition
+	for _, c := range s.Conditions {
+		if !c.Healthy {
+			conditions = append(conditions, c)
+		}
+	}
+	return conditions
+}
+
+// GetConditions returns the summary of the conditions of the node, such like
+// Ready, MemoryPressure, DiskPressure, PIDPressure and NetworkUnavailable,
+// with their transition times. The unknown conditions are unhealthy.
+func (h *Handler) GetConditions(object interface{}) (*ConditionSummary, error) {
+	switch val := object.(type) {
+	case string:
+		node, err := h.Get(val)
+		if err != nil {
+			return nil, err
+		}
+		return h.getConditions(node), nil
+	case *corev1.Node:
+		return h.getConditions(val), nil
+	case corev1.Node:
+		return h.getConditions(&val), nil
+	default:
+		return nil, ErrInvalidToolsType
+	}
+}
+
+func (h *Handler) getConditions(node *corev1.Node) *ConditionSummary {
+	summary := &ConditionSummary{Name: node.Name}
+	for _, c := range node.Status.Conditions {
+		condition := Condition{
+			Type:               c.Type,
+			Status:             c.Status,
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastHeartbeatTime:  c.LastHeartbeatTime.Time,
+			LastTransitionTime: c.LastTransitionTime.Time,
+		}
+		isTrue := c.Status == corev1.ConditionTrue
+		switch c.Type {
+		case corev1.NodeReady:
+			summary.Ready = isTrue
+			condition.Healthy = isTrue
+		case corev1.NodeMemoryPressure:
+			summary.MemoryPressure = isTrue
+			condition.Healthy = c.Status == corev1.ConditionFalse
+		case corev1.NodeDiskPressure:
+			summary.DiskPressure = isTrue
+			condition.Healthy = c.Status == corev1.ConditionFalse
+		case corev1.NodePIDPressure:
+			summary.PIDPressure = isTrue
+			condition.Healthy = c.Status == corev1.ConditionFalse
+		case corev1.NodeNetworkUnavailable:
+			summary.NetworkUnavailable = isTrue
+			condition.Healthy = c.Status == corev1.ConditionFalse
+		default:
+			// the conditions reported by the node problem detector are
+			// healthy when False.
+			condition.Healthy = c.Status == corev1.ConditionFalse
+		}
+		summary.Conditions = append(summary.Conditions, condition)
+	}
+	sort.Slice(summary.Conditions, func(i, j int) bool {
+		ci, cj := summary.Conditions[i], summary.Conditions[j]
+		if (ci.Type == corev1.NodeReady) != (cj.Type == corev1.NodeReady) {
+			return ci.Type == corev1.NodeReady
+		}
+		return ci.Type < cj.Type
+	})
+	return summary
+}
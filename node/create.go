@@ -1,10 +1,12 @@
 package node
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,8 @@ func (h *Handler) CreateFromMap(u map[string]interface{}) (*corev1.Node, error)
 func (h *Handler) createNode(node *corev1.Node) (*corev1.Node, error) {
 	node.ResourceVersion = ""
 	node.UID = ""
-	return h.clientset.CoreV1().Nodes().Create(h.ctx, node, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Name: node.Name, Object: node}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.Node, error) {
+		return h.clientset.CoreV1().Nodes().Create(ctx, node, h.Options.CreateOptions)
+	})
 }
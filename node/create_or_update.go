@@ -0,0 +1,130 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing node into it's desired state.
+type MutateFn func(node *corev1.Node) error
+
+// CreateOrUpdate gets the node from the kubernetes API server. If the
+// node does not exist, it will be created after mutateFn is called.
+// If the node exists, mutateFn is called on the live node and the
+// node will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live node, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The node must contain the name of the node, node will be overwritten
+// by the live node returned by the kubernetes API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(node *corev1.Node, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getNode(node)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, node); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createNode(node)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*node = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*node = *existing.DeepCopy()
+	if err := mutate(mutateFn, node); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, node) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateNodeWithResourceVersion(node)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*node = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the node already exists,
+// only the difference between the live node and the mutated node
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(node *corev1.Node, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getNode(node)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, node); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createNode(node)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*node = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*node = *existing.DeepCopy()
+	if err := mutate(mutateFn, node); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(node); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, corev1.Node{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*node = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, node *corev1.Node) error {
+	name := node.GetName()
+	if mutateFn != nil {
+		if err := mutateFn(node); err != nil {
+			return err
+		}
+	}
+	if node.GetName() != name {
+		return fmt.Errorf("MutateFn cannot mutate node name")
+	}
+	return nil
+}
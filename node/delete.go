@@ -1,10 +1,12 @@
 package node
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes node by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.CoreV1().Nodes().Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*corev1.Node, error) {
+		return nil, h.clientset.CoreV1().Nodes().Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes node from yaml or json file.
@@ -100,5 +106,9 @@ func (h *Handler) DeleteFromMap(u map[string]interface{}) error {
 
 // deleteNode
 func (h *Handler) deleteNode(node *corev1.Node) error {
-	return h.clientset.CoreV1().Nodes().Delete(h.ctx, node.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: node.Name, Object: node}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*corev1.Node, error) {
+		return nil, h.clientset.CoreV1().Nodes().Delete(ctx, node.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -0,0 +1,296 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// MirrorPodAnnotation is the annotation of the static pods mirrored by the kubelet.
+const MirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+var (
+	// evictionRetryInterval is the interval to retry the eviction rejected by
+	// the PodDisruptionBudgets, it's the same as kubectl.
+	evictionRetryInterval = 5 * time.Second
+	// deletionPollInterval is the interval to check whether the pod is deleted.
+	deletionPollInterval = time.Second
+)
+
+// DrainPhase is the phase of draining a pod.
+type DrainPhase string
+
+const (
+	// DrainEvicting means the eviction of the pod is requested.
+	DrainEvicting DrainPhase = "Evicting"
+	// DrainRetrying means the eviction is rejected by a PodDisruptionBudget,
+	// and it's retried later.
+	DrainRetrying DrainPhase = "Retrying"
+	// DrainDeleted means the pod is deleted.
+	DrainDeleted DrainPhase = "Deleted"
+	// DrainSkipped means the pod is not evicted, such like DaemonSet pods and
+	// mirror pods.
+	DrainSkipped DrainPhase = "Skipped"
+	// DrainFailed means the pod can't be evicted.
+	DrainFailed DrainPhase = "Failed"
+)
+
+// DrainProgress is the progress of draining a pod.
+type DrainProgress struct {
+	Namespace string
+	Pod       string
+	Phase     DrainPhase
+	Message   string
+}
+
+func (p DrainProgress) String() string {
+	if len(p.Message) == 0 {
+		return fmt.Sprintf("pod %s/%s: %s", p.Namespace, p.Pod, p.Phase)
+	}
+	return fmt.Sprintf("pod %s/%s: %s: %s", p.Namespace, p.Pod, p.Phase, p.Message)
+}
+
+// DrainOptions configures the node draining, it mirrors the options of
+// "kubectl drain".
+type DrainOptions struct {
+	// Force drains the pods not managed by a controller, they are lost.
+	Force bool
+	// DeleteEmptyDirData drains the pods using emptyDir volumes, the data of
+	// the volumes are lost.
+	DeleteEmptyDirData bool
+	// GracePeriodSeconds overrides the termination grace period of the pods
+	// if it's not nil.
+	GracePeriodSeconds *int64
+	// Timeout is the time to wait for the node to be drained, zero means no
+	// timeout.
+	Timeout time.Duration
+	// PodSelector only drains the pods selected by the label selector.
+	PodSelector string
+	// DisableEviction deletes the pods instead of evicting them, it bypasses
+	// the PodDisruptionBudgets.
+	DisableEviction bool
+	// Progress is called when the phase of a pod is changed, the calls are
+	// serialized.
+	Progress func(DrainProgress)
+}
+
+// Cordon marks the node unschedulable, it works like "kubectl cordon".
+func (h *Handler) Cordon(name string) (*corev1.Node, error) {
+	return h.setUnschedulable(name, true)
+}
+
+// Uncordon marks the node schedulable, it works like "kubectl uncordon".
+func (h *Handler) Uncordon(name string) (*corev1.Node, error) {
+	return h.setUnschedulable(name, false)
+}
+
+func (h *Handler) setUnschedulable(name string, unschedulable bool) (*corev1.Node, error) {
+	node, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		return node, nil
+	}
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	return h.Patch(node, []byte(patch), k8stypes.MergePatchType)
+}
+
+// Drain cordons the node and evicts its pods through the Eviction API like
+// "kubectl drain", it returns after all pods are deleted. The evictions
+// rejected by the PodDisruptionBudgets are retried until the timeout expires
+// or the handler context is done. The DaemonSet pods, the mirror pods and the
+// pods not selected by opts.PodSelector are skipped. The node is not drained if
+// any pod is not managed by a controller or uses emptyDir volumes, unless
+// opts.Force or opts.DeleteEmptyDirData is set, eg:
+//
+//	err := handler.Drain("node1", &node.DrainOptions{DeleteEmptyDirData: true, Timeout: 10 * time.Minute})
+//
+// The evictions and deletions use the delete options of the handler and run
+// through its interceptors like Delete. The handler created by WithDryRun()
+// cordons the node and evicts the pods in dry run mode, nothing is persisted
+// and Drain doesn't wait for the pods to be deleted.
+func (h *Handler) Drain(name string, opts *DrainOptions) error {
+	if opts == nil {
+		opts = &DrainOptions{}
+	}
+	ctx := h.ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+		h = h.WithContext(ctx)
+	}
+	node, err := h.Cordon(name)
+	if err != nil {
+		return err
+	}
+
+	listOptions := metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", node.Name),
+		LabelSelector: opts.PodSelector,
+	}
+	podList, err := h.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	d := &drainer{
+		ctx:           ctx,
+		client:        h.clientset,
+		intercept:     h.interceptPod,
+		deleteOptions: *h.Options.DeleteOptions.DeepCopy(),
+		opts:          opts,
+	}
+	var pods []*corev1.Pod
+	var blocked []string
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		skip, reason := d.filter(pod)
+		switch {
+		case skip:
+			d.report(pod, DrainSkipped, reason)
+		case len(reason) != 0:
+			blocked = append(blocked, fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, reason))
+		default:
+			pods = append(pods, pod)
+		}
+	}
+	if len(blocked) != 0 {
+		return fmt.Errorf("cannot drain node %q: %s", node.Name, strings.Join(blocked, ", "))
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(pods))
+	for i := range pods {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if errs[i] = d.evict(pods[i]); errs[i] != nil {
+				d.report(pods[i], DrainFailed, errs[i].Error())
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return fmt.Errorf("drain node %q: %w", node.Name, err)
+	}
+	return nil
+}
+
+type drainer struct {
+	ctx    context.Context
+	client kubernetes.Interface
+	// intercept runs the interceptors of the handler around the eviction
+	// and deletion.
+	intercept func(op *types.Operation, call func(ctx context.Context, namespace string) error) error
+	// deleteOptions are the delete options of the handler, such like DryRun.
+	deleteOptions metav1.DeleteOptions
+	opts          *DrainOptions
+	mu            sync.Mutex
+}
+
+// filter reports whether the pod is skipped, or the reason why the pod blocks
+// draining the node.
+func (d *drainer) filter(pod *corev1.Pod) (bool, string) {
+	if _, ok := pod.Annotations[MirrorPodAnnotation]; ok {
+		return true, "mirror pod"
+	}
+	controller := metav1.GetControllerOf(pod)
+	if controller != nil && controller.Kind == "DaemonSet" {
+		return true, "DaemonSet pod"
+	}
+	// the finished pods are deleted without checking.
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false, ""
+	}
+	if controller == nil && !d.opts.Force {
+		return false, "not managed by a controller"
+	}
+	if !d.opts.DeleteEmptyDirData {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.EmptyDir != nil {
+				return false, "uses emptyDir volume " + volume.Name
+			}
+		}
+	}
+	return false, ""
+}
+
+// evict evicts or deletes the pod, and waits for it to be deleted.
+func (d *drainer) evict(pod *corev1.Pod) error {
+	deleteOptions := d.deleteOptions.DeepCopy()
+	if d.opts.GracePeriodSeconds != nil {
+		deleteOptions.GracePeriodSeconds = d.opts.GracePeriodSeconds
+	}
+	for {
+		d.report(pod, DrainEvicting, "")
+		op := &types.Operation{Verb: types.VerbDelete, Namespace: pod.Namespace, Name: pod.Name, Object: pod}
+		if !d.opts.DisableEviction {
+			op.Subresource = "eviction"
+		}
+		err := d.intercept(op, func(ctx context.Context, namespace string) error {
+			if d.opts.DisableEviction {
+				return d.client.CoreV1().Pods(namespace).Delete(ctx, pod.Name, *deleteOptions)
+			}
+			return d.client.CoreV1().Pods(namespace).EvictV1(ctx, &policyv1.Eviction{
+				ObjectMeta:    metav1.ObjectMeta{Name: pod.Name, Namespace: namespace},
+				DeleteOptions: deleteOptions,
+			})
+		})
+		if err == nil || apierrors.IsNotFound(err) {
+			break
+		}
+		if !apierrors.IsTooManyRequests(err) {
+			return err
+		}
+		// the eviction is rejected by a PodDisruptionBudget.
+		d.report(pod, DrainRetrying, err.Error())
+		select {
+		case <-d.ctx.Done():
+			return fmt.Errorf("evict pod %s/%s: %w", pod.Namespace, pod.Name, d.ctx.Err())
+		case <-time.After(evictionRetryInterval):
+		}
+	}
+
+	// the pod is not deleted by the dry run eviction or deletion.
+	if len(deleteOptions.DryRun) != 0 {
+		d.report(pod, DrainDeleted, "dry run")
+		return nil
+	}
+	pods := d.client.CoreV1().Pods(pod.Namespace)
+	for {
+		current, err := pods.Get(d.ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			d.report(pod, DrainDeleted, "")
+			return nil
+		}
+		if err != nil && d.ctx.Err() == nil {
+			return err
+		}
+		select {
+		case <-d.ctx.Done():
+			return fmt.Errorf("wait for pod %s/%s to be deleted: %w", pod.Namespace, pod.Name, d.ctx.Err())
+		case <-time.After(deletionPollInterval):
+		}
+	}
+}
+
+func (d *drainer) report(pod *corev1.Pod, phase DrainPhase, message string) {
+	if d.opts.Progress == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.opts.Progress(DrainProgress{Namespace: pod.Namespace, Pod: pod.Name, Phase: phase, Message: message})
+}
@@ -0,0 +1,249 @@
+package node
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newDrainPod(name string, mutate func(pod *corev1.Pod)) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			UID:       k8stypes.UID(name + "-uid"),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "nginx", Controller: func() *bool { b := true; return &b }(),
+			}},
+		},
+		Spec:   corev1.PodSpec{NodeName: "node1"},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if mutate != nil {
+		mutate(pod)
+	}
+	return pod
+}
+
+func TestDrainerFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		pod    *corev1.Pod
+		opts   DrainOptions
+		skip   bool
+		reason string
+	}{
+		{
+			name: "managed pod",
+			pod:  newDrainPod("nginx", nil),
+		},
+		{
+			name: "mirror pod",
+			pod: newDrainPod("etcd", func(pod *corev1.Pod) {
+				pod.Annotations = map[string]string{MirrorPodAnnotation: "hash"}
+			}),
+			skip:   true,
+			reason: "mirror pod",
+		},
+		{
+			name: "daemonset pod",
+			pod: newDrainPod("fluentd", func(pod *corev1.Pod) {
+				pod.OwnerReferences[0].Kind = "DaemonSet"
+			}),
+			skip:   true,
+			reason: "DaemonSet pod",
+		},
+		{
+			name: "unmanaged pod",
+			pod: newDrainPod("bare", func(pod *corev1.Pod) {
+				pod.OwnerReferences = nil
+			}),
+			reason: "not managed by a controller",
+		},
+		{
+			name: "unmanaged pod with force",
+			pod: newDrainPod("bare", func(pod *corev1.Pod) {
+				pod.OwnerReferences = nil
+			}),
+			opts: DrainOptions{Force: true},
+		},
+		{
+			name: "finished unmanaged pod",
+			pod: newDrainPod("bare", func(pod *corev1.Pod) {
+				pod.OwnerReferences = nil
+				pod.Status.Phase = corev1.PodSucceeded
+			}),
+		},
+		{
+			name: "emptyDir pod",
+			pod: newDrainPod("cache", func(pod *corev1.Pod) {
+				pod.Spec.Volumes = []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+			}),
+			reason: "uses emptyDir volume data",
+		},
+		{
+			name: "emptyDir pod with DeleteEmptyDirData",
+			pod: newDrainPod("cache", func(pod *corev1.Pod) {
+				pod.Spec.Volumes = []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+			}),
+			opts: DrainOptions{DeleteEmptyDirData: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := &drainer{ctx: context.TODO(), opts: &test.opts}
+			skip, reason := d.filter(test.pod)
+			if skip != test.skip || reason != test.reason {
+				t.Errorf("expected (%t, %q), got (%t, %q)", test.skip, test.reason, skip, reason)
+			}
+		})
+	}
+}
+
+func TestDrainerEvict(t *testing.T) {
+	evictionRetryInterval, deletionPollInterval = 10*time.Millisecond, 10*time.Millisecond
+	podsGVR := corev1.SchemeGroupVersion.WithResource("pods")
+
+	tests := []struct {
+		name string
+		// rejections is the number of evictions rejected by a PodDisruptionBudget.
+		rejections int
+		// recreate recreates the pod with the same name and a new UID after it's
+		// evicted, like a StatefulSet pod.
+		recreate bool
+		dryRun   bool
+		opts     DrainOptions
+		phases   []DrainPhase
+	}{
+		{
+			name:   "evicted",
+			phases: []DrainPhase{DrainEvicting, DrainDeleted},
+		},
+		{
+			name:       "retried on 429",
+			rejections: 2,
+			phases:     []DrainPhase{DrainEvicting, DrainRetrying, DrainEvicting, DrainRetrying, DrainEvicting, DrainDeleted},
+		},
+		{
+			name:     "recreated with new UID",
+			recreate: true,
+			phases:   []DrainPhase{DrainEvicting, DrainDeleted},
+		},
+		{
+			name:   "deleted without eviction",
+			opts:   DrainOptions{DisableEviction: true},
+			phases: []DrainPhase{DrainEvicting, DrainDeleted},
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			phases: []DrainPhase{DrainEvicting, DrainDeleted},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := newDrainPod("nginx", nil)
+			clientset := fake.NewSimpleClientset(pod)
+			var evictions []*policyv1.Eviction
+			rejections := test.rejections
+			clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+				evictions = append(evictions, eviction)
+				if rejections > 0 {
+					rejections--
+					return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+				}
+				if len(eviction.DeleteOptions.DryRun) != 0 {
+					return true, nil, nil
+				}
+				if err := clientset.Tracker().Delete(podsGVR, pod.Namespace, pod.Name); err != nil {
+					return true, nil, err
+				}
+				if test.recreate {
+					recreated := newDrainPod(pod.Name, func(p *corev1.Pod) { p.UID = "recreated" })
+					return true, nil, clientset.Tracker().Add(recreated)
+				}
+				return true, nil, nil
+			})
+
+			var ops []*types.Operation
+			handler := &Handler{ctx: context.TODO(), Options: &types.HandlerOptions{}}
+			handler.interceptors = []types.Interceptor{func(ctx context.Context, op *types.Operation, invoke types.Invoker) error {
+				ops = append(ops, op)
+				return invoke(ctx, op)
+			}}
+			if test.dryRun {
+				handler = handler.WithDryRun()
+			}
+			var phases []DrainPhase
+			var mu sync.Mutex
+			test.opts.Progress = func(p DrainProgress) {
+				mu.Lock()
+				defer mu.Unlock()
+				phases = append(phases, p.Phase)
+			}
+			ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+			defer cancel()
+			d := &drainer{
+				ctx:           ctx,
+				client:        clientset,
+				intercept:     handler.interceptPod,
+				deleteOptions: handler.Options.DeleteOptions,
+				opts:          &test.opts,
+			}
+			if err := d.evict(pod); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(phases) != len(test.phases) {
+				t.Fatalf("expected phases %v, got %v", test.phases, phases)
+			}
+			for i := range phases {
+				if phases[i] != test.phases[i] {
+					t.Fatalf("expected phases %v, got %v", test.phases, phases)
+				}
+			}
+			if len(ops) != test.rejections+1 {
+				t.Fatalf("expected %d intercepted operations, got %d", test.rejections+1, len(ops))
+			}
+			if ops[0].GVK.Kind != "Pod" || ops[0].DryRun != test.dryRun {
+				t.Errorf("unexpected operation %+v", ops[0])
+			}
+			if test.opts.DisableEviction {
+				if len(evictions) != 0 {
+					t.Errorf("expected no eviction, got %d", len(evictions))
+				}
+				if ops[0].Subresource != "" {
+					t.Errorf("expected delete operation, got subresource %q", ops[0].Subresource)
+				}
+				return
+			}
+			if ops[0].Subresource != "eviction" {
+				t.Errorf("expected eviction operation, got subresource %q", ops[0].Subresource)
+			}
+			if dryRun := evictions[len(evictions)-1].DeleteOptions.DryRun; (len(dryRun) != 0) != test.dryRun {
+				t.Errorf("expected dry run %t, got %v", test.dryRun, dryRun)
+			}
+			_, err := clientset.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+			if test.dryRun && err != nil {
+				t.Errorf("expected the pod not deleted by dry run, got %v", err)
+			}
+		})
+	}
+}
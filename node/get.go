@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*corev1.Node, error) {
 
 // GetByName gets node by name.
 func (h *Handler) GetByName(name string) (*corev1.Node, error) {
-	return h.clientset.CoreV1().Nodes().Get(h.ctx, name, h.Options.GetOptions)
+	node, err := h.clientset.CoreV1().Nodes().Get(h.ctx, name, h.Options.GetOptions)
+	return node, utilerrors.Wrap("get", GVK, "", name, err)
 }
 
 // GetFromFile gets node from yaml or json file.
@@ -102,5 +104,6 @@ func (h *Handler) GetFromMap(u map[string]interface{}) (*corev1.Node, error) {
 // It's necessary to get a new node resource from a old node resource,
 // because old node usually don't have node.Status field.
 func (h *Handler) getNode(node *corev1.Node) (*corev1.Node, error) {
-	return h.clientset.CoreV1().Nodes().Get(h.ctx, node.Name, h.Options.GetOptions)
+	newNode, err := h.clientset.CoreV1().Nodes().Get(h.ctx, node.Name, h.Options.GetOptions)
+	return newNode, utilerrors.Wrap("get", GVK, "", node.Name, err)
 }
@@ -0,0 +1,83 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// AddLabels adds the labels to the node, the existing labels with the same keys
+// are overwritten. Only the labels are patched, eg:
+//
+//	node, err := handler.AddLabels("node1", map[string]string{"disktype": "ssd"})
+func (h *Handler) AddLabels(name string, labels map[string]string) (*corev1.Node, error) {
+	patch := make(map[string]interface{}, len(labels))
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid label value %q: %s", value, strings.Join(errs, "; "))
+		}
+		patch[key] = value
+	}
+	return h.patchLabels(name, patch)
+}
+
+// RemoveLabels removes the labels from the node by the keys.
+func (h *Handler) RemoveLabels(name string, keys ...string) (*corev1.Node, error) {
+	patch := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		patch[key] = nil
+	}
+	return h.patchLabels(name, patch)
+}
+
+// AddRoles adds the roles to the node, the role is the label
+// "node-role.kubernetes.io/<role>" with empty value shown by "kubectl get node".
+func (h *Handler) AddRoles(name string, roles ...string) (*corev1.Node, error) {
+	labels := make(map[string]string, len(roles))
+	for _, role := range roles {
+		labels[LabelNodeRolePrefix+role] = ""
+	}
+	return h.AddLabels(name, labels)
+}
+
+// RemoveRoles removes the roles from the node, both the label
+// "node-role.kubernetes.io/<role>" and the legacy label "kubernetes.io/role"
+// with the role value are removed.
+func (h *Handler) RemoveRoles(name string, roles ...string) (*corev1.Node, error) {
+	node, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, role := range roles {
+		keys = append(keys, LabelNodeRolePrefix+role)
+		if node.Labels[LabelNodeRole] == role {
+			keys = append(keys, LabelNodeRole)
+		}
+	}
+	return h.RemoveLabels(name, keys...)
+}
+
+// patchLabels patches the labels with JSON merge patch, the nil values remove
+// the labels.
+func (h *Handler) patchLabels(name string, labels map[string]interface{}) (*corev1.Node, error) {
+	node, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return node, nil
+	}
+	data, err := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"labels": labels}})
+	if err != nil {
+		return nil, err
+	}
+	return h.Patch(node, data, types.MergePatchType)
+}
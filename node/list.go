@@ -1,6 +1,7 @@
 package node
 
 import (
+	"github.com/forbearing/k8s/util/selector"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 )
@@ -14,13 +15,12 @@ func (h *Handler) List() ([]*corev1.Node, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*corev1.Node, error) {
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.LabelSelector = labels
-	nodeList, err := h.clientset.CoreV1().Nodes().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
 		return nil, err
 	}
-	return extractList(nodeList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.LabelSelector = labels
+	return h.listAll(*listOptions)
 }
 
 // ListByField list nodes by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -29,26 +29,15 @@ func (h *Handler) ListByField(field string) ([]*corev1.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	nodeList, err := h.clientset.CoreV1().Nodes().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(nodeList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(*listOptions)
 }
 
 // ListAll list all nodes in the k8s cluster.
 func (h *Handler) ListAll() ([]*corev1.Node, error) {
 	return h.ListByLabel("")
 }
-
-// extractList
-func extractList(nodeList *corev1.NodeList) []*corev1.Node {
-	var objList []*corev1.Node
-	for i := range nodeList.Items {
-		objList = append(objList, &nodeList.Items[i])
-	}
-	return objList
-}
@@ -0,0 +1,256 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// ResourceAllocation is the allocation of a resource of the node.
+type ResourceAllocation struct {
+	Name        corev1.ResourceName
+	Allocatable resource.Quantity
+	// Requests and Limits are the sums of the requests and limits of the
+	// non-terminated pods on the node.
+	Requests resource.Quantity
+	Limits   resource.Quantity
+	// RequestsPercent and LimitsPercent are the percentages of Allocatable,
+	// the limits may exceed 100 percent.
+	RequestsPercent float64
+	LimitsPercent   float64
+	// Usage is the usage reported by metrics-server, it's nil unless the
+	// usage is requested and reported for the resource, such like cpu and
+	// memory.
+	Usage        *resource.Quantity
+	UsagePercent float64
+}
+
+func (r ResourceAllocation) String() string {
+	s := fmt.Sprintf("%s: requests %s (%.0f%%), limits %s (%.0f%%), allocatable %s",
+		r.Name, r.Requests.String(), r.RequestsPercent, r.Limits.String(), r.LimitsPercent, r.Allocatable.String())
+	if r.Usage != nil {
+		s += fmt.Sprintf(", usage %s (%.0f%%)", r.Usage.String(), r.UsagePercent)
+	}
+	return s
+}
+
+// AllocatedResources is the report of the allocated resources of the node like
+// the "Allocated resources" of "kubectl describe node".
+type AllocatedResources struct {
+	Name string
+	// Pods is the number of the non-terminated pods on the node.
+	Pods int
+	// Resources are cpu, memory and ephemeral-storage, followed by the
+	// extended resources and hugepages sorted by name.
+	Resources []ResourceAllocation
+}
+
+// Get returns the allocation of the resource, nil if it's not found.
+func (a *AllocatedResources) Get(name corev1.ResourceName) *ResourceAllocation {
+	for i := range a.Resources {
+		if a.Resources[i].Name == name {
+			return &a.Resources[i]
+		}
+	}
+	return nil
+}
+
+// GetAllocatedResources returns the requests and limits of cpu, memory,
+// ephemeral storage and extended resources of the non-terminated pods on the
+// node, as the percentages of the allocatable resources. The init containers
+// and the pod overhead are counted like the scheduler. If usage is true, the
+// cpu and memory usage reported by metrics-server are included.
+func (h *Handler) GetAllocatedResources(object interface{}, usage bool) (*AllocatedResources, error) {
+	var node *corev1.Node
+	switch val := object.(type) {
+	case string:
+		var err error
+		if node, err = h.Get(val); err != nil {
+			return nil, err
+		}
+	case *corev1.Node:
+		node = val
+	case corev1.Node:
+		node = &val
+	default:
+		return nil, ErrInvalidToolsType
+	}
+	reports, err := h.allocatedResources([]*corev1.Node{node}, fields.OneTermEqualSelector("spec.nodeName", node.Name), usage)
+	if err != nil {
+		return nil, err
+	}
+	return reports[0], nil
+}
+
+// GetClusterAllocatedResources returns the allocated resources of all nodes of
+// the cluster sorted by node name, see GetAllocatedResources.
+func (h *Handler) GetClusterAllocatedResources(usage bool) ([]*AllocatedResources, error) {
+	nodes, err := h.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return h.allocatedResources(nodes, fields.Everything(), usage)
+}
+
+func (h *Handler) allocatedResources(nodes []*corev1.Node, selector fields.Selector, usage bool) ([]*AllocatedResources, error) {
+	selector = fields.AndSelectors(selector,
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)))
+	podList, err := h.clientset.CoreV1().Pods(metav1.NamespaceAll).List(h.ctx, metav1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	podsByNode := make(map[string][]*corev1.Pod)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+	}
+
+	var usages map[string]corev1.ResourceList
+	if usage {
+		if usages, err = h.nodeUsages(nodes); err != nil {
+			return nil, err
+		}
+	}
+	reports := make([]*AllocatedResources, 0, len(nodes))
+	for _, node := range nodes {
+		report := allocatedResources(node, podsByNode[node.Name])
+		if usage {
+			for i := range report.Resources {
+				r := &report.Resources[i]
+				if q, ok := usages[node.Name][r.Name]; ok {
+					r.Usage = &q
+					r.UsagePercent = percent(q, r.Allocatable)
+				}
+			}
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// nodeUsages returns the usage of the nodes reported by metrics-server.
+func (h *Handler) nodeUsages(nodes []*corev1.Node) (map[string]corev1.ResourceList, error) {
+	clientset, err := metricsv.NewForConfig(h.config)
+	if err != nil {
+		return nil, err
+	}
+	usages := make(map[string]corev1.ResourceList)
+	if len(nodes) == 1 {
+		metrics, err := clientset.MetricsV1beta1().NodeMetricses().Get(h.ctx, nodes[0].Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("get metrics of node %q: %w", nodes[0].Name, err)
+		}
+		usages[metrics.Name] = metrics.Usage
+		return usages, nil
+	}
+	metricsList, err := clientset.MetricsV1beta1().NodeMetricses().List(h.ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list node metrics: %w", err)
+	}
+	for _, metrics := range metricsList.Items {
+		usages[metrics.Name] = metrics.Usage
+	}
+	return usages, nil
+}
+
+// allocatedResources sums the requests and limits of the pods on the node.
+func allocatedResources(node *corev1.Node, pods []*corev1.Pod) *AllocatedResources {
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, pod := range pods {
+		podRequests, podLimits := podRequestsAndLimits(pod)
+		addResourceList(requests, podRequests)
+		addResourceList(limits, podLimits)
+	}
+
+	// cpu, memory and ephemeral-storage are always reported.
+	names := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage}
+	var extended []string
+	seen := map[corev1.ResourceName]bool{corev1.ResourceCPU: true, corev1.ResourceMemory: true, corev1.ResourceEphemeralStorage: true, corev1.ResourcePods: true}
+	for _, list := range []corev1.ResourceList{node.Status.Allocatable, requests, limits} {
+		for name := range list {
+			if !seen[name] {
+				seen[name] = true
+				extended = append(extended, string(name))
+			}
+		}
+	}
+	sort.Strings(extended)
+	for _, name := range extended {
+		names = append(names, corev1.ResourceName(name))
+	}
+
+	report := &AllocatedResources{Name: node.Name, Pods: len(pods)}
+	for _, name := range names {
+		r := ResourceAllocation{
+			Name:        name,
+			Allocatable: node.Status.Allocatable[name],
+			Requests:    requests[name],
+			Limits:      limits[name],
+		}
+		r.RequestsPercent = percent(r.Requests, r.Allocatable)
+		r.LimitsPercent = percent(r.Limits, r.Allocatable)
+		report.Resources = append(report.Resources, r)
+	}
+	return report
+}
+
+// podRequestsAndLimits returns the requests and limits of the pod like the
+// scheduler: the sum of the containers, or the largest init container if
+// it's larger, plus the pod overhead.
+func podRequestsAndLimits(pod *corev1.Pod) (corev1.ResourceList, corev1.ResourceList) {
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		addResourceList(requests, c.Resources.Requests)
+		addResourceList(limits, c.Resources.Limits)
+	}
+	// the init containers run one by one before the containers.
+	for _, c := range pod.Spec.InitContainers {
+		maxResourceList(requests, c.Resources.Requests)
+		maxResourceList(limits, c.Resources.Limits)
+	}
+	if pod.Spec.Overhead != nil {
+		addResourceList(requests, pod.Spec.Overhead)
+		// the overhead is added to the limits only if the limits are set.
+		for name, quantity := range pod.Spec.Overhead {
+			if value, ok := limits[name]; ok {
+				value.Add(quantity)
+				limits[name] = value
+			}
+		}
+	}
+	return requests, limits
+}
+
+func addResourceList(list, add corev1.ResourceList) {
+	for name, quantity := range add {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func maxResourceList(list, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if value, ok := list[name]; !ok || quantity.Cmp(value) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// percent returns the percentage of q in total, zero if total is zero.
+func percent(q, total resource.Quantity) float64 {
+	if total.IsZero() {
+		return 0
+	}
+	return q.AsApproximateFloat64() / total.AsApproximateFloat64() * 100
+}
@@ -0,0 +1,175 @@
+package node
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newResourceList(pairs ...string) corev1.ResourceList {
+	list := corev1.ResourceList{}
+	for i := 0; i < len(pairs); i += 2 {
+		list[corev1.ResourceName(pairs[i])] = resource.MustParse(pairs[i+1])
+	}
+	return list
+}
+
+func newContainer(requests, limits corev1.ResourceList) corev1.Container {
+	return corev1.Container{Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits}}
+}
+
+func TestPodRequestsAndLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     corev1.PodSpec
+		requests corev1.ResourceList
+		limits   corev1.ResourceList
+	}{
+		{
+			name: "containers",
+			spec: corev1.PodSpec{Containers: []corev1.Container{
+				newContainer(newResourceList("cpu", "100m", "memory", "128Mi"), newResourceList("cpu", "200m")),
+				newContainer(newResourceList("cpu", "200m", "memory", "64Mi"), newResourceList("cpu", "300m", "memory", "256Mi")),
+			}},
+			requests: newResourceList("cpu", "300m", "memory", "192Mi"),
+			limits:   newResourceList("cpu", "500m", "memory", "256Mi"),
+		},
+		{
+			name: "init container larger than the containers",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					newContainer(newResourceList("cpu", "1", "memory", "64Mi"), newResourceList("cpu", "2")),
+					newContainer(newResourceList("cpu", "500m"), nil),
+				},
+				Containers: []corev1.Container{
+					newContainer(newResourceList("cpu", "100m", "memory", "128Mi"), newResourceList("cpu", "200m", "memory", "128Mi")),
+					newContainer(newResourceList("cpu", "200m"), nil),
+				},
+			},
+			requests: newResourceList("cpu", "1", "memory", "128Mi"),
+			limits:   newResourceList("cpu", "2", "memory", "128Mi"),
+		},
+		{
+			name: "overhead",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					newContainer(newResourceList("cpu", "100m", "memory", "128Mi"), newResourceList("cpu", "200m")),
+				},
+				Overhead: newResourceList("cpu", "50m", "memory", "32Mi"),
+			},
+			requests: newResourceList("cpu", "150m", "memory", "160Mi"),
+			// the overhead isn't added to the memory limit, which is not set.
+			limits: newResourceList("cpu", "250m"),
+		},
+		{
+			name: "extended resources and hugepages",
+			spec: corev1.PodSpec{Containers: []corev1.Container{
+				newContainer(newResourceList("nvidia.com/gpu", "1", "hugepages-2Mi", "64Mi"), newResourceList("nvidia.com/gpu", "1", "hugepages-2Mi", "64Mi")),
+				newContainer(newResourceList("nvidia.com/gpu", "2"), newResourceList("nvidia.com/gpu", "2")),
+			}},
+			requests: newResourceList("nvidia.com/gpu", "3", "hugepages-2Mi", "64Mi"),
+			limits:   newResourceList("nvidia.com/gpu", "3", "hugepages-2Mi", "64Mi"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests, limits := podRequestsAndLimits(&corev1.Pod{Spec: test.spec})
+			assertResourceList(t, "requests", test.requests, requests)
+			assertResourceList(t, "limits", test.limits, limits)
+		})
+	}
+}
+
+func assertResourceList(t *testing.T, name string, expected, actual corev1.ResourceList) {
+	t.Helper()
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %s %v, got %v", name, expected, actual)
+	}
+	for resourceName, q := range expected {
+		if value, ok := actual[resourceName]; !ok || value.Cmp(q) != 0 {
+			t.Errorf("expected %s %s %s, got %s", name, resourceName, q.String(), value.String())
+		}
+	}
+}
+
+func TestAllocatedResources(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: corev1.NodeStatus{Allocatable: newResourceList(
+			"cpu", "4",
+			"memory", "8Gi",
+			"ephemeral-storage", "100Gi",
+			"pods", "110",
+			"nvidia.com/gpu", "4",
+			"hugepages-2Mi", "1Gi",
+			"hugepages-1Gi", "0",
+		)},
+	}
+	pods := []*corev1.Pod{
+		{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				newContainer(newResourceList("cpu", "2"), newResourceList("cpu", "2")),
+			},
+			Containers: []corev1.Container{
+				newContainer(newResourceList("cpu", "500m", "memory", "1Gi"), newResourceList("cpu", "1", "memory", "2Gi")),
+			},
+			Overhead: newResourceList("cpu", "100m"),
+		}},
+		{Spec: corev1.PodSpec{Containers: []corev1.Container{
+			newContainer(newResourceList("cpu", "1", "nvidia.com/gpu", "2", "hugepages-2Mi", "256Mi", "example.com/foo", "1"),
+				newResourceList("nvidia.com/gpu", "2", "hugepages-2Mi", "256Mi", "example.com/foo", "1")),
+		}}},
+	}
+
+	report := allocatedResources(node, pods)
+	if report.Name != "node1" || report.Pods != 2 {
+		t.Fatalf("unexpected report %s with %d pods", report.Name, report.Pods)
+	}
+	// cpu, memory and ephemeral-storage go first, followed by the extended
+	// resources and hugepages sorted by name, pods is not reported.
+	names := []corev1.ResourceName{"cpu", "memory", "ephemeral-storage", "example.com/foo", "hugepages-1Gi", "hugepages-2Mi", "nvidia.com/gpu"}
+	if len(report.Resources) != len(names) {
+		t.Fatalf("expected %d resources, got %v", len(names), report.Resources)
+	}
+	for i, name := range names {
+		if report.Resources[i].Name != name {
+			t.Fatalf("expected resource %s at %d, got %s", name, i, report.Resources[i].Name)
+		}
+	}
+
+	tests := []struct {
+		name            corev1.ResourceName
+		requests        string
+		limits          string
+		requestsPercent float64
+		limitsPercent   float64
+	}{
+		// the init container of the first pod is larger than its containers,
+		// plus the overhead.
+		{name: "cpu", requests: "3100m", limits: "2100m", requestsPercent: 77.5, limitsPercent: 52.5},
+		{name: "memory", requests: "1Gi", limits: "2Gi", requestsPercent: 12.5, limitsPercent: 25},
+		{name: "ephemeral-storage", requests: "0", limits: "0"},
+		// example.com/foo isn't allocatable on the node.
+		{name: "example.com/foo", requests: "1", limits: "1"},
+		{name: "hugepages-1Gi", requests: "0", limits: "0"},
+		{name: "hugepages-2Mi", requests: "256Mi", limits: "256Mi", requestsPercent: 25, limitsPercent: 25},
+		{name: "nvidia.com/gpu", requests: "2", limits: "2", requestsPercent: 50, limitsPercent: 50},
+	}
+	for _, test := range tests {
+		r := report.Get(test.name)
+		if r == nil {
+			t.Fatalf("resource %s not found", test.name)
+		}
+		if r.Requests.Cmp(resource.MustParse(test.requests)) != 0 || r.Limits.Cmp(resource.MustParse(test.limits)) != 0 {
+			t.Errorf("expected %s requests %s limits %s, got requests %s limits %s",
+				test.name, test.requests, test.limits, r.Requests.String(), r.Limits.String())
+		}
+		if r.RequestsPercent != test.requestsPercent || r.LimitsPercent != test.limitsPercent {
+			t.Errorf("expected %s requests %.1f%% limits %.1f%%, got requests %.1f%% limits %.1f%%",
+				test.name, test.requestsPercent, test.limitsPercent, r.RequestsPercent, r.LimitsPercent)
+		}
+	}
+}
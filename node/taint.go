@@ -0,0 +1,154 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+)
+
+// ParseTaint parses the taint in the format of "kubectl taint", such like
+// "key=value:NoSchedule", "key:NoExecute" and "key". The effect is optional
+// for the taints to remove.
+func ParseTaint(spec string) (corev1.Taint, error) {
+	taint := corev1.Taint{}
+	keyValue := spec
+	if i := strings.LastIndex(spec, ":"); i >= 0 {
+		keyValue, taint.Effect = spec[:i], corev1.TaintEffect(spec[i+1:])
+	}
+	taint.Key = keyValue
+	if i := strings.Index(keyValue, "="); i >= 0 {
+		taint.Key, taint.Value = keyValue[:i], keyValue[i+1:]
+	}
+	if err := validateTaint(taint, len(taint.Effect) != 0 || strings.HasSuffix(spec, ":")); err != nil {
+		return corev1.Taint{}, err
+	}
+	return taint, nil
+}
+
+// validateTaint validates the key, value and effect of the taint, the effect is
+// validated if requireEffect is true or it's not empty.
+func validateTaint(taint corev1.Taint, requireEffect bool) error {
+	if errs := validation.IsQualifiedName(taint.Key); len(errs) != 0 {
+		return fmt.Errorf("invalid taint key %q: %s", taint.Key, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(taint.Value); len(errs) != 0 {
+		return fmt.Errorf("invalid taint value %q: %s", taint.Value, strings.Join(errs, "; "))
+	}
+	if !requireEffect && len(taint.Effect) == 0 {
+		return nil
+	}
+	switch taint.Effect {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		return nil
+	default:
+		return fmt.Errorf("invalid taint effect %q of taint %q, must be one of %s, %s, %s", taint.Effect, taint.Key,
+			corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute)
+	}
+}
+
+// AddTaints adds the taints to the node like "kubectl taint --overwrite", the
+// existing taint with the same key and effect is replaced, eg:
+//
+//	node, err := handler.AddTaints("node1", corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule})
+func (h *Handler) AddTaints(name string, taints ...corev1.Taint) (*corev1.Node, error) {
+	for _, taint := range taints {
+		if err := validateTaint(taint, true); err != nil {
+			return nil, err
+		}
+	}
+	return h.updateTaints(name, func(current []corev1.Taint) []corev1.Taint {
+		return addTaints(current, taints)
+	})
+}
+
+// addTaints replaces the current taints with the same key and effect as the
+// taints, and appends the others.
+func addTaints(current, taints []corev1.Taint) []corev1.Taint {
+	for _, taint := range taints {
+		replaced := false
+		for i := range current {
+			if current[i].Key == taint.Key && current[i].Effect == taint.Effect {
+				current[i], replaced = taint, true
+			}
+		}
+		if !replaced {
+			current = append(current, taint)
+		}
+	}
+	return current
+}
+
+// RemoveTaints removes the taints from the node like "kubectl taint key-", the
+// taints are matched by the key, and the effect if it's not empty.
+func (h *Handler) RemoveTaints(name string, taints ...corev1.Taint) (*corev1.Node, error) {
+	for _, taint := range taints {
+		if err := validateTaint(taint, false); err != nil {
+			return nil, err
+		}
+	}
+	return h.updateTaints(name, func(current []corev1.Taint) []corev1.Taint {
+		return removeTaints(current, taints)
+	})
+}
+
+// removeTaints returns the current taints that don't match the taints by the
+// key, and the effect if it's not empty.
+func removeTaints(current, taints []corev1.Taint) []corev1.Taint {
+	var kept []corev1.Taint
+	for _, c := range current {
+		removed := false
+		for _, taint := range taints {
+			if c.Key == taint.Key && (len(taint.Effect) == 0 || c.Effect == taint.Effect) {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// ReplaceTaints replaces all taints of the node, empty taints remove all of them.
+func (h *Handler) ReplaceTaints(name string, taints []corev1.Taint) (*corev1.Node, error) {
+	for _, taint := range taints {
+		if err := validateTaint(taint, true); err != nil {
+			return nil, err
+		}
+	}
+	return h.updateTaints(name, func([]corev1.Taint) []corev1.Taint { return taints })
+}
+
+// updateTaints patches the taints of the node with the resourceVersion, so the
+// taints changed concurrently are not lost, it retries on conflict. The
+// status of the node written by the kubelet is not touched.
+func (h *Handler) updateTaints(name string, update func([]corev1.Taint) []corev1.Taint) (*corev1.Node, error) {
+	var node *corev1.Node
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := h.Get(name)
+		if err != nil {
+			return err
+		}
+		taints := update(append([]corev1.Taint(nil), current.Spec.Taints...))
+		if taints == nil {
+			taints = []corev1.Taint{}
+		}
+		patch := map[string]interface{}{
+			"metadata": map[string]interface{}{"resourceVersion": current.ResourceVersion},
+			"spec":     map[string]interface{}{"taints": taints},
+		}
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return err
+		}
+		node, err = h.Patch(current, data, types.MergePatchType)
+		return err
+	})
+	return node, err
+}
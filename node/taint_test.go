@@ -0,0 +1,174 @@
+package node
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestParseTaint(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected corev1.Taint
+		wantErr  bool
+	}{
+		{spec: "dedicated=gpu:NoSchedule", expected: corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}},
+		{spec: "dedicated:NoExecute", expected: corev1.Taint{Key: "dedicated", Effect: corev1.TaintEffectNoExecute}},
+		{spec: "example.com/dedicated=gpu:PreferNoSchedule", expected: corev1.Taint{Key: "example.com/dedicated", Value: "gpu", Effect: corev1.TaintEffectPreferNoSchedule}},
+		// the effect is optional for the taints to remove.
+		{spec: "dedicated", expected: corev1.Taint{Key: "dedicated"}},
+		{spec: "dedicated=gpu", expected: corev1.Taint{Key: "dedicated", Value: "gpu"}},
+		{spec: "dedicated=", expected: corev1.Taint{Key: "dedicated"}},
+		{spec: "dedicated=gpu:", wantErr: true},
+		{spec: "dedicated=gpu:Invalid", wantErr: true},
+		{spec: "=gpu:NoSchedule", wantErr: true},
+		{spec: "dedi cated:NoSchedule", wantErr: true},
+		{spec: "dedicated=g=pu:NoSchedule", wantErr: true},
+		{spec: "", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			taint, err := ParseTaint(test.spec)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", taint)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(taint, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, taint)
+			}
+		})
+	}
+}
+
+func TestValidateTaint(t *testing.T) {
+	tests := []struct {
+		name          string
+		taint         corev1.Taint
+		requireEffect bool
+		err           string
+	}{
+		{name: "valid", taint: corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}, requireEffect: true},
+		{name: "effect not required", taint: corev1.Taint{Key: "dedicated"}},
+		{name: "effect required", taint: corev1.Taint{Key: "dedicated"}, requireEffect: true, err: "invalid taint effect"},
+		// the effect is validated if it's not empty.
+		{name: "invalid effect", taint: corev1.Taint{Key: "dedicated", Effect: "Invalid"}, err: "invalid taint effect"},
+		{name: "invalid key", taint: corev1.Taint{Key: "-dedicated", Effect: corev1.TaintEffectNoSchedule}, err: "invalid taint key"},
+		{name: "empty key", taint: corev1.Taint{Effect: corev1.TaintEffectNoSchedule}, err: "invalid taint key"},
+		{name: "invalid value", taint: corev1.Taint{Key: "dedicated", Value: strings.Repeat("a", 64)}, err: "invalid taint value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateTaint(test.taint, test.requireEffect)
+			if len(test.err) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestAddTaints(t *testing.T) {
+	gpu := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+	tests := []struct {
+		name     string
+		current  []corev1.Taint
+		taints   []corev1.Taint
+		expected []corev1.Taint
+	}{
+		{
+			name:     "add to no taints",
+			taints:   []corev1.Taint{gpu},
+			expected: []corev1.Taint{gpu},
+		},
+		{
+			name:     "replace the taint with the same key and effect",
+			current:  []corev1.Taint{{Key: "dedicated", Value: "cpu", Effect: corev1.TaintEffectNoSchedule}},
+			taints:   []corev1.Taint{gpu},
+			expected: []corev1.Taint{gpu},
+		},
+		{
+			name:    "keep the taint with the same key and other effect",
+			current: []corev1.Taint{{Key: "dedicated", Value: "cpu", Effect: corev1.TaintEffectNoExecute}},
+			taints:  []corev1.Taint{gpu},
+			expected: []corev1.Taint{
+				{Key: "dedicated", Value: "cpu", Effect: corev1.TaintEffectNoExecute},
+				gpu,
+			},
+		},
+		{
+			name:    "add multiple taints",
+			current: []corev1.Taint{{Key: "zone", Value: "a", Effect: corev1.TaintEffectPreferNoSchedule}},
+			taints:  []corev1.Taint{gpu, {Key: "maintenance", Effect: corev1.TaintEffectNoExecute}},
+			expected: []corev1.Taint{
+				{Key: "zone", Value: "a", Effect: corev1.TaintEffectPreferNoSchedule},
+				gpu,
+				{Key: "maintenance", Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			taints := addTaints(test.current, test.taints)
+			if !reflect.DeepEqual(taints, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, taints)
+			}
+		})
+	}
+}
+
+func TestRemoveTaints(t *testing.T) {
+	current := []corev1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+		{Key: "zone", Value: "a", Effect: corev1.TaintEffectPreferNoSchedule},
+	}
+	tests := []struct {
+		name     string
+		taints   []corev1.Taint
+		expected []corev1.Taint
+	}{
+		{
+			name:     "remove by key",
+			taints:   []corev1.Taint{{Key: "dedicated"}},
+			expected: current[2:],
+		},
+		{
+			name:     "remove by key and effect",
+			taints:   []corev1.Taint{{Key: "dedicated", Effect: corev1.TaintEffectNoExecute}},
+			expected: []corev1.Taint{current[0], current[2]},
+		},
+		{
+			name:     "remove not existing taint",
+			taints:   []corev1.Taint{{Key: "maintenance"}},
+			expected: current,
+		},
+		{
+			name:   "remove all taints",
+			taints: []corev1.Taint{{Key: "dedicated"}, {Key: "zone"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			taints := removeTaints(current, test.taints)
+			if !reflect.DeepEqual(taints, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, taints)
+			}
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package node
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,15 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*corev1.Node, error)
 func (h *Handler) updateNode(node *corev1.Node) (*corev1.Node, error) {
 	node.ResourceVersion = ""
 	node.UID = ""
-	return h.clientset.CoreV1().Nodes().Update(h.ctx, node, h.Options.UpdateOptions)
+	return h.updateNodeWithResourceVersion(node)
+}
+
+// updateNodeWithResourceVersion updates the node without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the node has been changed since it was read.
+func (h *Handler) updateNodeWithResourceVersion(node *corev1.Node) (*corev1.Node, error) {
+	op := &types.Operation{Verb: types.VerbUpdate, Name: node.Name, Object: node}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.Node, error) {
+		return h.clientset.CoreV1().Nodes().Update(ctx, node, h.Options.UpdateOptions)
+	})
 }
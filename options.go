@@ -7,4 +7,10 @@ const (
 	IgnoreNotFound
 	IgnoreInvalid
 	IgnoreTimeout
+	// FailOnDeprecation returns *types.DeprecationError if the kubernetes API
+	// server returned deprecation warnings for the k8s resources.
+	FailOnDeprecation
+	// WaitReady makes ApplyF wait until the status of all applied k8s resources
+	// is Current, see util/status. Set the timeout by the deadline of ctx.
+	WaitReady
 )
@@ -1,8 +1,10 @@
 package persistentvolume
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,14 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*corev1.PersistentVolu
 
 // applyPV
 func (h *Handler) applyPV(pv *corev1.PersistentVolume) (*corev1.PersistentVolume, error) {
-	_, err := h.createPV(pv)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updatePV(pv)
-	}
-	return pv, err
+	op := &types.Operation{Verb: types.VerbApply, Name: pv.Name, Object: pv}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.PersistentVolume, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createPV(pv)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updatePV(pv)
+		}
+		return pv, err
+	})
 }
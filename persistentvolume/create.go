@@ -1,10 +1,12 @@
 package persistentvolume
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,8 @@ func (h *Handler) CreateFromMap(u map[string]interface{}) (*corev1.PersistentVol
 func (h *Handler) createPV(pv *corev1.PersistentVolume) (*corev1.PersistentVolume, error) {
 	pv.ResourceVersion = ""
 	pv.UID = ""
-	return h.clientset.CoreV1().PersistentVolumes().Create(h.ctx, pv, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Name: pv.Name, Object: pv}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.PersistentVolume, error) {
+		return h.clientset.CoreV1().PersistentVolumes().Create(ctx, pv, h.Options.CreateOptions)
+	})
 }
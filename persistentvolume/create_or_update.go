@@ -0,0 +1,130 @@
+package persistentvolume
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing persistentvolume into it's desired state.
+type MutateFn func(pv *corev1.PersistentVolume) error
+
+// CreateOrUpdate gets the persistentvolume from the kubernetes API server. If the
+// persistentvolume does not exist, it will be created after mutateFn is called.
+// If the persistentvolume exists, mutateFn is called on the live persistentvolume and the
+// persistentvolume will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live persistentvolume, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The pv must contain the name of the persistentvolume, pv will be overwritten
+// by the live persistentvolume returned by the kubernetes API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(pv *corev1.PersistentVolume, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getPV(pv)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, pv); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createPV(pv)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*pv = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*pv = *existing.DeepCopy()
+	if err := mutate(mutateFn, pv); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, pv) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updatePVWithResourceVersion(pv)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*pv = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the persistentvolume already exists,
+// only the difference between the live persistentvolume and the mutated persistentvolume
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(pv *corev1.PersistentVolume, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getPV(pv)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, pv); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createPV(pv)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*pv = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*pv = *existing.DeepCopy()
+	if err := mutate(mutateFn, pv); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(pv); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, corev1.PersistentVolume{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*pv = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, pv *corev1.PersistentVolume) error {
+	name := pv.GetName()
+	if mutateFn != nil {
+		if err := mutateFn(pv); err != nil {
+			return err
+		}
+	}
+	if pv.GetName() != name {
+		return fmt.Errorf("MutateFn cannot mutate persistentvolume name")
+	}
+	return nil
+}
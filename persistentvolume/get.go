@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*corev1.PersistentVolume, error) {
 
 // GetByName gets persistentvolume by name.
 func (h *Handler) GetByName(name string) (*corev1.PersistentVolume, error) {
-	return h.clientset.CoreV1().PersistentVolumes().Get(h.ctx, name, h.Options.GetOptions)
+	pv, err := h.clientset.CoreV1().PersistentVolumes().Get(h.ctx, name, h.Options.GetOptions)
+	return pv, utilerrors.Wrap("get", GVK, "", name, err)
 }
 
 // GetFromFile gets persistentvolume from yaml or json file.
@@ -102,5 +104,6 @@ func (h *Handler) GetFromMap(u map[string]interface{}) (*corev1.PersistentVolume
 // It's necessary to get a new persistentvolume resource from a old persistentvolume resource,
 // because old persistentvolume usually don't have persistentvolume.Status field.
 func (h *Handler) getPV(pv *corev1.PersistentVolume) (*corev1.PersistentVolume, error) {
-	return h.clientset.CoreV1().PersistentVolumes().Get(h.ctx, pv.Name, h.Options.GetOptions)
+	newPV, err := h.clientset.CoreV1().PersistentVolumes().Get(h.ctx, pv.Name, h.Options.GetOptions)
+	return newPV, utilerrors.Wrap("get", GVK, "", pv.Name, err)
 }
@@ -1,6 +1,7 @@
 package persistentvolume
 
 import (
+	"github.com/forbearing/k8s/util/selector"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 )
@@ -14,13 +15,12 @@ func (h *Handler) List() ([]*corev1.PersistentVolume, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*corev1.PersistentVolume, error) {
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.LabelSelector = labels
-	pvList, err := h.clientset.CoreV1().PersistentVolumes().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
 		return nil, err
 	}
-	return extractList(pvList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.LabelSelector = labels
+	return h.listAll(*listOptions)
 }
 
 // ListByField list persistentvolumes by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -29,26 +29,15 @@ func (h *Handler) ListByField(field string) ([]*corev1.PersistentVolume, error)
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	pvList, err := h.clientset.CoreV1().PersistentVolumes().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(pvList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(*listOptions)
 }
 
 // ListAll list all persistentvolumes in the k8s cluster.
 func (h *Handler) ListAll() ([]*corev1.PersistentVolume, error) {
 	return h.ListByLabel("")
 }
-
-// extractList
-func extractList(pvList *corev1.PersistentVolumeList) []*corev1.PersistentVolume {
-	var objList []*corev1.PersistentVolume
-	for i := range pvList.Items {
-		objList = append(objList, &pvList.Items[i])
-	}
-	return objList
-}
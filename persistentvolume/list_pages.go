@@ -0,0 +1,184 @@
+package persistentvolume
+
+import (
+	"context"
+
+	utilerrors "github.com/forbearing/k8s/util/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// DefaultPageSize is the number of persistentvolumes requested per page when
+// no limit is set by SetLimit().
+const DefaultPageSize = 500
+
+// ListPages list all persistentvolumes in the k8s cluster page by page, and calls fn
+// for every page. ListPages stops and returns the error if fn returns an error.
+//
+// The label selector and field selector of listOptions are used to filter the
+// persistentvolumes, the page size is listOptions.Limit, default to the limit set by
+// SetLimit(), then DefaultPageSize, eg:
+//
+//	err := handler.ListPages(metav1.ListOptions{LabelSelector: "app=nginx"}, func(page []*corev1.PersistentVolume) error {
+//	    ...
+//	})
+//
+// ListPages follows the continue token returned by kubernetes API server. If
+// the continue token expired, ListPages relists from the beginning and skips
+// the persistentvolumes that already passed to fn.
+func (h *Handler) ListPages(listOptions metav1.ListOptions, fn func(page []*corev1.PersistentVolume) error) error {
+	return h.newPager(listOptions).forEach(fn)
+}
+
+// ListIterator iterates persistentvolumes returned by the kubernetes API server one
+// by one, only one page of persistentvolumes is kept in memory.
+type ListIterator struct {
+	pager *pager
+	page  []*corev1.PersistentVolume
+	index int
+	err   error
+}
+
+// ListIter returns a ListIterator that iterates all persistentvolumes in the k8s
+// cluster, listOptions is used like ListPages.
+func (h *Handler) ListIter(listOptions metav1.ListOptions) *ListIterator {
+	return &ListIterator{pager: h.newPager(listOptions), index: -1}
+}
+
+// Next advances the iterator to the next persistentvolume, it returns false when
+// there are no more persistentvolumes or an error occurs.
+func (it *ListIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= len(it.page) {
+		if it.pager.done {
+			return false
+		}
+		if it.page, it.err = it.pager.next(); it.err != nil {
+			return false
+		}
+		it.index = 0
+	}
+	return true
+}
+
+// Object returns the current persistentvolume.
+func (it *ListIterator) Object() *corev1.PersistentVolume {
+	if it.index < 0 || it.index >= len(it.page) {
+		return nil
+	}
+	return it.page[it.index]
+}
+
+// Err returns the error occurred during the iteration.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// pager requests persistentvolumes from kubernetes API server page by page.
+type pager struct {
+	ctx         context.Context
+	client      typedcorev1.PersistentVolumeInterface
+	listOptions metav1.ListOptions
+
+	// lastKey is the name of the last persistentvolume returned by pager,
+	// the kubernetes API server always returns persistentvolumes ordered by the key.
+	lastKey string
+	// relist is true after the continue token expired, persistentvolumes which key
+	// is not greater than lastKey will be skipped.
+	relist bool
+	done   bool
+}
+
+// newPager returns a pager that lists all persistentvolumes.
+func (h *Handler) newPager(listOptions metav1.ListOptions) *pager {
+	listOptions.Continue = ""
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = h.Options.ListOptions.Limit
+	}
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = DefaultPageSize
+	}
+	return &pager{
+		ctx:         h.ctx,
+		client:      h.clientset.CoreV1().PersistentVolumes(),
+		listOptions: listOptions,
+	}
+}
+
+// next returns the next page of persistentvolumes.
+func (p *pager) next() ([]*corev1.PersistentVolume, error) {
+	if p.done {
+		return nil, nil
+	}
+	pvList, err := p.client.List(p.ctx, p.listOptions)
+	if err != nil {
+		// The continue token expired, relist from the beginning.
+		if k8serrors.IsResourceExpired(err) && len(p.listOptions.Continue) != 0 {
+			p.listOptions.Continue = ""
+			p.listOptions.ResourceVersion = ""
+			p.relist = true
+			return nil, nil
+		}
+		return nil, utilerrors.Wrap("list", GVK, "", "", err)
+	}
+	p.listOptions.Continue = pvList.Continue
+	if len(p.listOptions.Continue) == 0 {
+		p.done = true
+	}
+
+	var page []*corev1.PersistentVolume
+	for i := range pvList.Items {
+		pv := &pvList.Items[i]
+		key := pv.Name
+		if p.relist && key <= p.lastKey {
+			continue
+		}
+		page = append(page, pv)
+		p.lastKey = key
+	}
+	return page, nil
+}
+
+// forEach calls fn for every page of persistentvolumes until all persistentvolumes are listed,
+// it stops and returns the error if fn returns an error.
+func (p *pager) forEach(fn func(page []*corev1.PersistentVolume) error) error {
+	for {
+		page, err := p.next()
+		if err != nil {
+			return err
+		}
+		if len(page) != 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+		if p.done {
+			return nil
+		}
+	}
+}
+
+// listAll follows the continue tokens to list all persistentvolumes.
+func (h *Handler) listAll(listOptions metav1.ListOptions) ([]*corev1.PersistentVolume, error) {
+	listOptions.Continue = ""
+	p := &pager{
+		ctx:         h.ctx,
+		client:      h.clientset.CoreV1().PersistentVolumes(),
+		listOptions: listOptions,
+	}
+
+	var objList []*corev1.PersistentVolume
+	for !p.done {
+		page, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		objList = append(objList, page...)
+	}
+	return objList, nil
+}
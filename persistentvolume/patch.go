@@ -1,6 +1,7 @@
 package persistentvolume
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -112,8 +113,10 @@ func (h *Handler) strategicMergePatch(original *corev1.PersistentVolume, patchDa
 	if len(patchData) == 0 || string(patchData) == "{}" {
 		return original, nil
 	}
-	return h.clientset.CoreV1().PersistentVolumes().
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch("", original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, _ string) (*corev1.PersistentVolume, error) {
+		return h.clientset.CoreV1().PersistentVolumes().
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonMergePatch use the "JSON Merge Patch" patch type to patch persistentvolume.
@@ -130,8 +133,10 @@ func (h *Handler) jsonMergePatch(original *corev1.PersistentVolume, patchData []
 	if len(patchData) == 0 || string(patchData) == "{}" {
 		return original, nil
 	}
-	return h.clientset.CoreV1().PersistentVolumes().
-		Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch("", original.Name, types.MergePatchType, patchData, func(ctx context.Context, _ string) (*corev1.PersistentVolume, error) {
+		return h.clientset.CoreV1().PersistentVolumes().
+			Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonPatch use "JSON Patch" patch type to patch persistentvolume.
@@ -142,8 +147,10 @@ func (h *Handler) jsonMergePatch(original *corev1.PersistentVolume, patchData []
 //     https://kubernetes.io/docs/tasks/manage-kubernetes-objects/update-api-object-kubectl-patch/#before-you-begin
 //     https://tools.ietf.org/html/rfc7386
 func (h *Handler) jsonPatch(original *corev1.PersistentVolume, patchData []byte) (*corev1.PersistentVolume, error) {
-	return h.clientset.CoreV1().PersistentVolumes().Patch(h.ctx,
-		original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch("", original.Name, types.JSONPatchType, patchData, func(ctx context.Context, _ string) (*corev1.PersistentVolume, error) {
+		return h.clientset.CoreV1().PersistentVolumes().Patch(ctx,
+			original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // diffMergePatch will tak the difference data between original and modified persistentvolume object,
@@ -172,9 +179,13 @@ func (h *Handler) diffMergePatch(original, modified *corev1.PersistentVolume, pa
 	}
 
 	if len(patchOptions) != 0 && patchOptions[0] == types.MergePatchType {
-		return h.clientset.CoreV1().PersistentVolumes().
-			Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		return h.interceptPatch("", original.Name, types.MergePatchType, patchData, func(ctx context.Context, _ string) (*corev1.PersistentVolume, error) {
+			return h.clientset.CoreV1().PersistentVolumes().
+				Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		})
 	}
-	return h.clientset.CoreV1().PersistentVolumes().
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch("", original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, _ string) (*corev1.PersistentVolume, error) {
+		return h.clientset.CoreV1().PersistentVolumes().
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
@@ -1,10 +1,12 @@
 package persistentvolume
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,15 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*corev1.PersistentVol
 func (h *Handler) updatePV(pv *corev1.PersistentVolume) (*corev1.PersistentVolume, error) {
 	pv.ResourceVersion = ""
 	pv.UID = ""
-	return h.clientset.CoreV1().PersistentVolumes().Update(h.ctx, pv, h.Options.UpdateOptions)
+	return h.updatePVWithResourceVersion(pv)
+}
+
+// updatePVWithResourceVersion updates the persistentvolume without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the persistentvolume has been changed since it was read.
+func (h *Handler) updatePVWithResourceVersion(pv *corev1.PersistentVolume) (*corev1.PersistentVolume, error) {
+	op := &types.Operation{Verb: types.VerbUpdate, Name: pv.Name, Object: pv}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.PersistentVolume, error) {
+		return h.clientset.CoreV1().PersistentVolumes().Update(ctx, pv, h.Options.UpdateOptions)
+	})
 }
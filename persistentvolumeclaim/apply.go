@@ -1,8 +1,10 @@
 package persistentvolumeclaim
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*corev1.PersistentVolu
 
 // applyPVC
 func (h *Handler) applyPVC(pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
-	_, err := h.createPVC(pvc)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updatePVC(pvc)
+	namespace := pvc.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return pvc, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: pvc.Name, Object: pvc}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.PersistentVolumeClaim, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createPVC(pvc)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updatePVC(pvc)
+		}
+		return pvc, err
+	})
 }
@@ -1,10 +1,12 @@
 package persistentvolumeclaim
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createPVC(pvc *corev1.PersistentVolumeClaim) (*corev1.Persiste
 	}
 	pvc.ResourceVersion = ""
 	pvc.UID = ""
-	return h.clientset.CoreV1().PersistentVolumeClaims(namespace).Create(h.ctx, pvc, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: pvc.Name, Object: pvc}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaim, error) {
+		return h.clientset.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, pvc, h.Options.CreateOptions)
+	})
 }
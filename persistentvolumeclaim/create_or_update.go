@@ -0,0 +1,131 @@
+package persistentvolumeclaim
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing persistentvolumeclaim into it's desired state.
+type MutateFn func(pvc *corev1.PersistentVolumeClaim) error
+
+// CreateOrUpdate gets the persistentvolumeclaim from the kubernetes API server. If the
+// persistentvolumeclaim does not exist, it will be created after mutateFn is called.
+// If the persistentvolumeclaim exists, mutateFn is called on the live persistentvolumeclaim and the
+// persistentvolumeclaim will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live persistentvolumeclaim, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The pvc must contain the name and namespace(optional) of the persistentvolumeclaim,
+// pvc will be overwritten by the live persistentvolumeclaim returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(pvc *corev1.PersistentVolumeClaim, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getPVC(pvc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, pvc); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createPVC(pvc)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*pvc = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*pvc = *existing.DeepCopy()
+	if err := mutate(mutateFn, pvc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, pvc) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updatePVCWithResourceVersion(pvc)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*pvc = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the persistentvolumeclaim already exists,
+// only the difference between the live persistentvolumeclaim and the mutated persistentvolumeclaim
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(pvc *corev1.PersistentVolumeClaim, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getPVC(pvc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, pvc); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createPVC(pvc)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*pvc = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*pvc = *existing.DeepCopy()
+	if err := mutate(mutateFn, pvc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(pvc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, corev1.PersistentVolumeClaim{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*pvc = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, pvc *corev1.PersistentVolumeClaim) error {
+	name, namespace := pvc.GetName(), pvc.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(pvc); err != nil {
+			return err
+		}
+	}
+	if pvc.GetName() != name || pvc.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate persistentvolumeclaim name and/or persistentvolumeclaim namespace")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package persistentvolumeclaim
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes persistentvolumeclaim by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.CoreV1().PersistentVolumeClaims(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaim, error) {
+		return nil, h.clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes persistentvolumeclaim from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deletePVC(pvc *corev1.PersistentVolumeClaim) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(h.ctx, pvc.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: pvc.Name, Object: pvc}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaim, error) {
+		return nil, h.clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, pvc.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*corev1.PersistentVolumeClaim, error) {
 
 // GetByName gets persistentvolumeclaim by name.
 func (h *Handler) GetByName(name string) (*corev1.PersistentVolumeClaim, error) {
-	return h.clientset.CoreV1().PersistentVolumeClaims(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	pvc, err := h.clientset.CoreV1().PersistentVolumeClaims(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return pvc, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets persistentvolumeclaim from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getPVC(pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentV
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(h.ctx, pvc.Name, h.Options.GetOptions)
+	newPVC, err := h.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(h.ctx, pvc.Name, h.Options.GetOptions)
+	return newPVC, utilerrors.Wrap("get", GVK, namespace, pvc.Name, err)
 }
@@ -1,6 +1,7 @@
 package persistentvolumeclaim
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -117,8 +118,10 @@ func (h *Handler) strategicMergePatch(original *corev1.PersistentVolumeClaim, pa
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().PersistentVolumeClaims(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaim, error) {
+		return h.clientset.CoreV1().PersistentVolumeClaims(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonMergePatch use the "JSON Merge Patch" patch type to patch persistentvolumeclaim.
@@ -140,8 +143,10 @@ func (h *Handler) jsonMergePatch(original *corev1.PersistentVolumeClaim, patchDa
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().PersistentVolumeClaims(namespace).
-		Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaim, error) {
+		return h.clientset.CoreV1().PersistentVolumeClaims(namespace).
+			Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonPatch use "JSON Patch" patch type to patch persistentvolumeclaim.
@@ -156,8 +161,10 @@ func (h *Handler) jsonPatch(original *corev1.PersistentVolumeClaim, patchData []
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().PersistentVolumeClaims(namespace).Patch(h.ctx,
-		original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.JSONPatchType, patchData, func(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaim, error) {
+		return h.clientset.CoreV1().PersistentVolumeClaims(namespace).Patch(ctx,
+			original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // diffMergePatch will tak the difference data between original and modified persistentvolumeclaim object,
@@ -190,9 +197,13 @@ func (h *Handler) diffMergePatch(original, modified *corev1.PersistentVolumeClai
 		namespace = h.namespace
 	}
 	if len(patchOptions) != 0 && patchOptions[0] == types.MergePatchType {
-		return h.clientset.CoreV1().PersistentVolumeClaims(namespace).
-			Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaim, error) {
+			return h.clientset.CoreV1().PersistentVolumeClaims(namespace).
+				Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		})
 	}
-	return h.clientset.CoreV1().PersistentVolumeClaims(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaim, error) {
+		return h.clientset.CoreV1().PersistentVolumeClaims(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
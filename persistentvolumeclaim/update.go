@@ -1,10 +1,12 @@
 package persistentvolumeclaim
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*corev1.PersistentVol
 
 // updatePVC
 func (h *Handler) updatePVC(pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	pvc.ResourceVersion = ""
+	pvc.UID = ""
+	return h.updatePVCWithResourceVersion(pvc)
+}
+
+// updatePVCWithResourceVersion updates the persistentvolumeclaim without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the persistentvolumeclaim has been changed since it was read.
+func (h *Handler) updatePVCWithResourceVersion(pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
 	namespace := pvc.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	pvc.ResourceVersion = ""
-	pvc.UID = ""
-	return h.clientset.CoreV1().PersistentVolumeClaims(namespace).Update(h.ctx, pvc, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: pvc.Name, Object: pvc}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaim, error) {
+		return h.clientset.CoreV1().PersistentVolumeClaims(namespace).Update(ctx, pvc, h.Options.UpdateOptions)
+	})
 }
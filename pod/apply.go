@@ -1,8 +1,10 @@
 package pod
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*corev1.Pod, error) {
 
 // applyPod
 func (h *Handler) applyPod(pod *corev1.Pod) (*corev1.Pod, error) {
-	_, err := h.createPod(pod)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updatePod(pod)
+	namespace := pod.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return pod, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: pod.Name, Object: pod}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.Pod, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createPod(pod)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updatePod(pod)
+		}
+		return pod, err
+	})
 }
@@ -0,0 +1,24 @@
+package pod
+
+import (
+	"github.com/forbearing/k8s/util/cp"
+)
+
+// CopyToPod copies the local file or directory src to dest in the container of
+// the pod like "kubectl cp", the data is streamed through tar over exec, see
+// package util/cp. If dest is an existing directory, src is copied into it, eg:
+//
+//	err := handler.CopyToPod("nginx", "./html", "/usr/share/nginx", &cp.Options{Container: "nginx"})
+func (h *Handler) CopyToPod(name, src, dest string, opts *cp.Options) error {
+	return cp.ToPod(h.ctx, h.config, h.clientset, h.namespace, name, src, dest, opts)
+}
+
+// CopyFromPod copies the file or directory src in the container of the pod to
+// the local dest, the permissions are preserved and the entries escaping dest
+// are rejected. Set opts.Resume to continue copying a large file after the
+// previous copy is interrupted, eg:
+//
+//	err := handler.CopyFromPod("mysql-0", "/backup/db.sql", "./db.sql", &cp.Options{Resume: true})
+func (h *Handler) CopyFromPod(name, src, dest string, opts *cp.Options) error {
+	return cp.FromPod(h.ctx, h.config, h.clientset, h.namespace, name, src, dest, opts)
+}
@@ -1,10 +1,12 @@
 package pod
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createPod(pod *corev1.Pod) (*corev1.Pod, error) {
 	}
 	pod.UID = ""
 	pod.ResourceVersion = ""
-	return h.clientset.CoreV1().Pods(namespace).Create(h.ctx, pod, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: pod.Name, Object: pod}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Pod, error) {
+		return h.clientset.CoreV1().Pods(namespace).Create(ctx, pod, h.Options.CreateOptions)
+	})
 }
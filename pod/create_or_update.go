@@ -0,0 +1,131 @@
+package pod
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing pod into it's desired state.
+type MutateFn func(pod *corev1.Pod) error
+
+// CreateOrUpdate gets the pod from the kubernetes API server. If the
+// pod does not exist, it will be created after mutateFn is called.
+// If the pod exists, mutateFn is called on the live pod and the
+// pod will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live pod, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The pod must contain the name and namespace(optional) of the pod,
+// pod will be overwritten by the live pod returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(pod *corev1.Pod, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getPod(pod)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, pod); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createPod(pod)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*pod = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*pod = *existing.DeepCopy()
+	if err := mutate(mutateFn, pod); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, pod) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updatePodWithResourceVersion(pod)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*pod = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the pod already exists,
+// only the difference between the live pod and the mutated pod
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(pod *corev1.Pod, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getPod(pod)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, pod); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createPod(pod)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*pod = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*pod = *existing.DeepCopy()
+	if err := mutate(mutateFn, pod); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(pod); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, corev1.Pod{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*pod = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, pod *corev1.Pod) error {
+	name, namespace := pod.GetName(), pod.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(pod); err != nil {
+			return err
+		}
+	}
+	if pod.GetName() != name || pod.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate pod name and/or pod namespace")
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/forbearing/k8s/types"
+	utilexec "github.com/forbearing/k8s/util/exec"
+	utilwait "github.com/forbearing/k8s/util/wait"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+// DebugOptions configures the debug container, it mirrors the options of
+// "kubectl debug".
+type DebugOptions struct {
+	// Image is the image of the debug container, it's required unless
+	// CopyTo is set and Container is an existing container of the pod.
+	Image string
+	// Command is the command of the debug container, default to the
+	// entrypoint of the image.
+	Command []string
+	// Container is the name of the debug container, default to
+	// "debugger-xxxxx". In copy mode, the existing container with the name
+	// is modified by Image and Command instead of adding a new one.
+	Container string
+	// Env is the environment variables of the debug container.
+	Env []corev1.EnvVar
+	// Stdin keeps the stdin of the debug container open, it's required to
+	// attach to an interactive shell.
+	Stdin bool
+	// TTY allocates a terminal for the debug container.
+	TTY bool
+
+	// TargetContainer shares the process namespace of the container with
+	// the ephemeral container, so the processes of the target container can
+	// be inspected. It's not used in copy mode.
+	TargetContainer string
+
+	// CopyTo creates a copy of the pod with the name instead of injecting an
+	// ephemeral container, the copy is deleted by DebugSession.Close.
+	CopyTo string
+	// SetImages changes the images of the containers of the copy, the key
+	// is the container name, "*" changes all containers.
+	SetImages map[string]string
+	// ShareProcesses enables the process namespace sharing of the copy.
+	ShareProcesses bool
+	// KeepLabels keeps the labels of the copy, they are removed by default,
+	// so the copy isn't selected by the services and the controllers.
+	KeepLabels bool
+	// KeepProbes keeps the probes of the containers of the copy, they are
+	// removed by default, so the copy isn't restarted while debugging.
+	KeepProbes bool
+
+	// Timeout is the time to wait for the debug container to run, default
+	// to 5 minutes.
+	Timeout time.Duration
+}
+
+// DebugSession is the running debug container.
+type DebugSession struct {
+	Namespace string
+	Pod       string
+	Container string
+	// Copied is true if the pod is a copy created by the debug session.
+	Copied bool
+
+	handler *Handler
+}
+
+// Exec executes the command in the debug container, see Handler.Exec.
+func (s *DebugSession) Exec(command []string, opts *utilexec.Options) (*utilexec.Result, error) {
+	o := utilexec.Options{}
+	if opts != nil {
+		o = *opts
+	}
+	o.Container = s.Container
+	return utilexec.Exec(s.handler.ctx, s.handler.config, s.handler.clientset, s.Namespace, s.Pod, command, &o)
+}
+
+// Attach attaches the streams to the debug container, the streams.TTY must
+// match the DebugOptions.TTY.
+func (s *DebugSession) Attach(streams *utilexec.Streams) error {
+	return utilexec.Attach(s.handler.ctx, s.handler.config, s.handler.clientset, s.Namespace, s.Pod, streams, &utilexec.Options{Container: s.Container})
+}
+
+// Close deletes the pod copy. The ephemeral containers can't be removed from
+// the pod, the debug container keeps running until its process exits.
+func (s *DebugSession) Close() error {
+	if !s.Copied {
+		return nil
+	}
+	return s.handler.WithNamespace(s.Namespace).DeleteByName(s.Pod)
+}
+
+// Attach attaches the streams to the main process of the running container of
+// the pod like "kubectl attach", see package util/exec. opts.Container default
+// to the default container of the pod, eg:
+//
+//	err := handler.Attach("nginx", &utilexec.Streams{Stdout: os.Stdout, Stderr: os.Stderr}, nil)
+func (h *Handler) Attach(name string, streams *utilexec.Streams, opts *utilexec.Options) error {
+	return utilexec.Attach(h.ctx, h.config, h.clientset, h.namespace, name, streams, opts)
+}
+
+// Debug starts a debug container for the running pod like "kubectl debug", and
+// returns the session after the debug container is running. By default, an
+// ephemeral container is injected into the pod, set opts.TargetContainer to
+// share the process namespace of the container. If opts.CopyTo is set, a copy
+// of the pod is created with the debug container or the changed images and
+// command instead. In dry run mode, the session is returned without waiting
+// for the debug container, eg:
+//
+//	session, err := handler.Debug("nginx", &pod.DebugOptions{Image: "busybox", Command: []string{"sleep", "3600"}, TargetContainer: "nginx"})
+//	result, err := session.Exec([]string{"ps"}, nil)
+func (h *Handler) Debug(name string, opts *DebugOptions) (*DebugSession, error) {
+	if opts == nil {
+		opts = &DebugOptions{}
+	}
+	pod, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	container := opts.Container
+	if len(container) == 0 {
+		container = "debugger-" + utilrand.String(5)
+	}
+
+	session := &DebugSession{Namespace: pod.Namespace, Container: container, handler: h}
+	if len(opts.CopyTo) != 0 {
+		var copied *corev1.Pod
+		if copied, container, err = debugCopy(pod, container, opts); err != nil {
+			return nil, err
+		}
+		session.Container = container
+		if _, err = h.WithNamespace(pod.Namespace).Create(copied); err != nil {
+			return nil, err
+		}
+		session.Pod, session.Copied = copied.Name, true
+		// the pod copy isn't created by dry run.
+		if h.Options.IsDryRun(types.VerbCreate) {
+			return session, nil
+		}
+	} else {
+		if len(opts.Image) == 0 {
+			return nil, fmt.Errorf("image of the debug container of pod %q is required", name)
+		}
+		for _, c := range pod.Spec.EphemeralContainers {
+			if c.Name == container {
+				return nil, fmt.Errorf("ephemeral container %q already exists in pod %q", container, name)
+			}
+		}
+		ec := corev1.EphemeralContainer{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+				Name:                     container,
+				Image:                    opts.Image,
+				Command:                  opts.Command,
+				Env:                      opts.Env,
+				Stdin:                    opts.Stdin,
+				TTY:                      opts.TTY,
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+			},
+			TargetContainerName: opts.TargetContainer,
+		}
+		pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, ec)
+		op := &types.Operation{Verb: types.VerbUpdate, Namespace: pod.Namespace, Name: pod.Name, Subresource: "ephemeralcontainers", Object: pod}
+		_, err = h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Pod, error) {
+			return h.clientset.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, pod.Name, pod, h.Options.UpdateOptions)
+		})
+		if err != nil {
+			return nil, err
+		}
+		session.Pod = pod.Name
+		// the debug container isn't added to the pod by dry run.
+		if op.DryRun {
+			return session, nil
+		}
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+	err = h.WithNamespace(session.Namespace).waitFor(utilwait.Target{Name: session.Pod}, forContainerRunning(container), utilwait.WithTimeout(timeout))
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	return session, nil
+}
+
+// debugCopy returns the copy of the pod for debugging, and the name of the
+// container to debug.
+func debugCopy(pod *corev1.Pod, container string, opts *DebugOptions) (*corev1.Pod, string, error) {
+	copied := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        opts.CopyTo,
+			Namespace:   pod.Namespace,
+			Annotations: pod.Annotations,
+		},
+		Spec: *pod.Spec.DeepCopy(),
+	}
+	if opts.KeepLabels {
+		copied.Labels = pod.Labels
+	}
+	// the copy may be scheduled to any node.
+	copied.Spec.NodeName = ""
+	copied.Spec.EphemeralContainers = nil
+	if opts.ShareProcesses {
+		shareProcesses := true
+		copied.Spec.ShareProcessNamespace = &shareProcesses
+	}
+
+	found := false
+	for i := range copied.Spec.Containers {
+		c := &copied.Spec.Containers[i]
+		if image, ok := opts.SetImages[c.Name]; ok {
+			c.Image = image
+		} else if image, ok := opts.SetImages["*"]; ok {
+			c.Image = image
+		}
+		if !opts.KeepProbes {
+			c.LivenessProbe, c.ReadinessProbe, c.StartupProbe = nil, nil, nil
+		}
+		if c.Name != container {
+			continue
+		}
+		found = true
+		if len(opts.Image) != 0 {
+			c.Image = opts.Image
+		}
+		if opts.Command != nil {
+			c.Command, c.Args = opts.Command, nil
+		}
+		c.Env = append(c.Env, opts.Env...)
+		c.Stdin, c.TTY = opts.Stdin, opts.TTY
+	}
+	if !found {
+		if len(opts.Image) == 0 {
+			if len(opts.SetImages) == 0 {
+				return nil, "", fmt.Errorf("image of the debug container of pod %q is required", pod.Name)
+			}
+			// only the images are changed, debug the default container.
+			return copied, utilexec.DefaultContainer(copied), nil
+		}
+		copied.Spec.Containers = append(copied.Spec.Containers, corev1.Container{
+			Name:                     container,
+			Image:                    opts.Image,
+			Command:                  opts.Command,
+			Env:                      opts.Env,
+			Stdin:                    opts.Stdin,
+			TTY:                      opts.TTY,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		})
+	}
+	return copied, container, nil
+}
+
+// forContainerRunning returns the condition that the container, init container
+// or ephemeral container of the pod is running. It fails if the container
+// terminated or its image can't be pulled.
+func forContainerRunning(container string) utilwait.Condition {
+	return utilwait.Condition{Name: fmt.Sprintf("container %s running", container), Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		pod := &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pod); err != nil {
+			return false, "", err
+		}
+		var statuses []corev1.ContainerStatus
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.EphemeralContainerStatuses...)
+		for _, status := range statuses {
+			if status.Name != container {
+				continue
+			}
+			switch {
+			case status.State.Running != nil:
+				return true, "", nil
+			case status.State.Terminated != nil:
+				return false, "", fmt.Errorf("container %q terminated: %s", container, status.State.Terminated.Reason)
+			case status.State.Waiting != nil:
+				switch reason := status.State.Waiting.Reason; reason {
+				case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError":
+					return false, "", fmt.Errorf("container %q is waiting: %s: %s", container, reason, status.State.Waiting.Message)
+				default:
+					return false, fmt.Sprintf("container %s is waiting: %s", container, reason), nil
+				}
+			}
+		}
+		return false, fmt.Sprintf("container %s is not created", container), nil
+	}}
+}
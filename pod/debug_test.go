@@ -0,0 +1,194 @@
+package pod
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newDebugPod() *corev1.Pod {
+	probe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{}}}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "test",
+			Labels:    map[string]string{"app": "nginx"},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node1",
+			Containers: []corev1.Container{
+				{Name: "nginx", Image: "nginx:1.21", LivenessProbe: probe, ReadinessProbe: probe},
+				{Name: "sidecar", Image: "envoy:1.22", StartupProbe: probe},
+			},
+		},
+	}
+}
+
+func TestDebugCopy(t *testing.T) {
+	tests := []struct {
+		name      string
+		container string
+		opts      DebugOptions
+		// images are the expected images of the containers of the copy.
+		images   []string
+		expected string
+		labels   bool
+		probes   bool
+		wantErr  bool
+	}{
+		{
+			name:      "add debug container",
+			container: "debugger",
+			opts:      DebugOptions{CopyTo: "nginx-debug", Image: "busybox"},
+			images:    []string{"nginx:1.21", "envoy:1.22", "busybox"},
+			expected:  "debugger",
+		},
+		{
+			name:      "set images of all containers",
+			container: "debugger",
+			opts:      DebugOptions{CopyTo: "nginx-debug", SetImages: map[string]string{"*": "busybox"}},
+			images:    []string{"busybox", "busybox"},
+			expected:  "nginx",
+		},
+		{
+			name:      "set image of the container overrides *",
+			container: "debugger",
+			opts:      DebugOptions{CopyTo: "nginx-debug", SetImages: map[string]string{"*": "busybox", "sidecar": "envoy:1.23"}},
+			images:    []string{"busybox", "envoy:1.23"},
+			expected:  "nginx",
+		},
+		{
+			name:      "modify existing container",
+			container: "sidecar",
+			opts:      DebugOptions{CopyTo: "nginx-debug", Image: "busybox", Command: []string{"sh"}},
+			images:    []string{"nginx:1.21", "busybox"},
+			expected:  "sidecar",
+		},
+		{
+			name:      "keep labels and probes",
+			container: "debugger",
+			opts:      DebugOptions{CopyTo: "nginx-debug", Image: "busybox", KeepLabels: true, KeepProbes: true},
+			images:    []string{"nginx:1.21", "envoy:1.22", "busybox"},
+			expected:  "debugger",
+			labels:    true,
+			probes:    true,
+		},
+		{
+			name:      "image required",
+			container: "debugger",
+			opts:      DebugOptions{CopyTo: "nginx-debug"},
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := newDebugPod()
+			copied, container, err := debugCopy(pod, test.container, &test.opts)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if container != test.expected {
+				t.Errorf("expected container %q, got %q", test.expected, container)
+			}
+			if copied.Name != test.opts.CopyTo || len(copied.Spec.NodeName) != 0 {
+				t.Errorf("unexpected copy %s on node %q", copied.Name, copied.Spec.NodeName)
+			}
+			if len(copied.Spec.Containers) != len(test.images) {
+				t.Fatalf("expected %d containers, got %d", len(test.images), len(copied.Spec.Containers))
+			}
+			for i, c := range copied.Spec.Containers {
+				if c.Image != test.images[i] {
+					t.Errorf("expected image %q of container %s, got %q", test.images[i], c.Name, c.Image)
+				}
+				hasProbes := c.LivenessProbe != nil || c.ReadinessProbe != nil || c.StartupProbe != nil
+				if hasProbes && !test.probes {
+					t.Errorf("expected probes of container %s removed", c.Name)
+				}
+			}
+			if test.probes && copied.Spec.Containers[0].LivenessProbe == nil {
+				t.Errorf("expected probes of container nginx kept")
+			}
+			if (copied.Labels != nil) != test.labels {
+				t.Errorf("expected labels kept %t, got %v", test.labels, copied.Labels)
+			}
+			// the original pod isn't modified.
+			if pod.Spec.Containers[0].Image != "nginx:1.21" || pod.Spec.Containers[0].LivenessProbe == nil {
+				t.Errorf("the pod is modified")
+			}
+		})
+	}
+}
+
+func TestForContainerRunning(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   *corev1.ContainerState
+		ready   bool
+		wantErr bool
+	}{
+		{
+			name:  "not created",
+			state: nil,
+		},
+		{
+			name:  "running",
+			state: &corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			ready: true,
+		},
+		{
+			name:  "container creating",
+			state: &corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+		},
+		{
+			name:    "image pull error",
+			state:   &corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull"}},
+			wantErr: true,
+		},
+		{
+			name:    "image pull backoff",
+			state:   &corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid image name",
+			state:   &corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "InvalidImageName"}},
+			wantErr: true,
+		},
+		{
+			name:    "terminated",
+			state:   &corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error"}},
+			wantErr: true,
+		},
+	}
+
+	condition := forContainerRunning("debugger")
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := newDebugPod()
+			if test.state != nil {
+				pod.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{{Name: "debugger", State: *test.state}}
+			}
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ready, _, err := condition.Func(&unstructured.Unstructured{Object: obj})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, got %v", test.wantErr, err)
+			}
+			if ready != test.ready {
+				t.Errorf("expected ready %t, got %t", test.ready, ready)
+			}
+		})
+	}
+}
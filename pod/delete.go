@@ -1,10 +1,12 @@
 package pod
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes pod by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.CoreV1().Pods(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Pod, error) {
+		return nil, h.clientset.CoreV1().Pods(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes pod from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deletePod(pod *corev1.Pod) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().Pods(namespace).Delete(h.ctx, pod.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: pod.Name, Object: pod}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Pod, error) {
+		return nil, h.clientset.CoreV1().Pods(namespace).Delete(ctx, pod.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
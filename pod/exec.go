@@ -0,0 +1,27 @@
+package pod
+
+import (
+	utilexec "github.com/forbearing/k8s/util/exec"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+)
+
+// Exec executes the command in the container of the pod and captures its
+// stdout, stderr and exit code, see package util/exec. Unlike Execute, it
+// doesn't connect to os.Stdin, os.Stdout and os.Stderr, and a non-zero exit
+// code is returned by Result.ExitCode instead of an error, eg:
+//
+//	result, err := handler.Exec("nginx", []string{"nginx", "-t"}, &utilexec.Options{Timeout: time.Minute})
+func (h *Handler) Exec(name string, command []string, opts *utilexec.Options) (*utilexec.Result, error) {
+	return utilexec.Exec(h.ctx, h.config, h.clientset, h.namespace, name, command, opts)
+}
+
+// ExecByLabel executes the command in all running pods selected by the labels
+// concurrently, the results are sorted by pod name and the error of every pod
+// is returned by Result.Err.
+func (h *Handler) ExecByLabel(labels string, command []string, opts *utilexec.Options) ([]*utilexec.Result, error) {
+	selector, err := k8slabels.Parse(labels)
+	if err != nil {
+		return nil, err
+	}
+	return utilexec.ExecAll(h.ctx, h.config, h.clientset, h.namespace, selector, command, opts)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*corev1.Pod, error) {
 
 // GetByName gets pod by name.
 func (h *Handler) GetByName(name string) (*corev1.Pod, error) {
-	return h.clientset.CoreV1().Pods(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	pod, err := h.clientset.CoreV1().Pods(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return pod, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets pod from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getPod(pod *corev1.Pod) (*corev1.Pod, error) {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().Pods(namespace).Get(h.ctx, pod.Name, h.Options.GetOptions)
+	newPod, err := h.clientset.CoreV1().Pods(namespace).Get(h.ctx, pod.Name, h.Options.GetOptions)
+	return newPod, utilerrors.Wrap("get", GVK, namespace, pod.Name, err)
 }
@@ -3,8 +3,7 @@ package pod
 import (
 	"time"
 
-	"github.com/sirupsen/logrus"
-	log "github.com/sirupsen/logrus"
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
@@ -118,11 +117,15 @@ func (h *Handler) RunInformer(
 		DeleteFunc: deleteFunc,
 	})
 
+	types.ObserveInformer(h.Informer(), h.observer, GVK)
+
 	// method 1, recommended
 	h.InformerFactory().Start(stopCh)
-	logrus.Info("Waiting for informer caches to sync")
-	if ok := cache.WaitForCacheSync(stopCh, h.Informer().HasSynced); !ok {
-		logrus.Error("failed to wait for caches to sync")
+	h.logger.Info("waiting for informer caches to sync")
+	ok := cache.WaitForCacheSync(stopCh, h.Informer().HasSynced)
+	h.observer.InformerSynced(GVK, ok)
+	if !ok {
+		h.logger.Error(nil, "failed to wait for caches to sync")
 	}
 
 	//// method 2
@@ -151,13 +154,13 @@ func (h *Handler) TestInformer(stopCh chan struct{}) {
 	h.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			myObj := obj.(metav1.Object)
-			log.Printf("New Pod Added to Store: %s", myObj.GetName())
+			h.logger.Info("pod added to store", "name", myObj.GetName())
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			newPod := newObj.(*corev1.Pod)
 			oldPod := oldObj.(*corev1.Pod)
 			if newPod.ResourceVersion != oldPod.ResourceVersion {
-				log.Printf("Pod Updated to Store: %s\n", newPod.Name)
+				h.logger.Info("pod updated to store", "namespace", newPod.Namespace, "name", newPod.Name)
 			}
 			//if !reflect.DeepEqual(newObj, oldObj) {
 			//    log.Printf("Pod Updated to Store: %s\n", newObj.(metav1.Object).GetName())
@@ -165,13 +168,15 @@ func (h *Handler) TestInformer(stopCh chan struct{}) {
 		},
 		DeleteFunc: func(obj interface{}) {
 			myObj := obj.(metav1.Object)
-			log.Printf("Pod Deleted from Store: %s", myObj.GetName())
+			h.logger.Info("pod deleted from store", "name", myObj.GetName())
 		},
 	})
 	h.InformerFactory().Start(stopCh)
-	logrus.Info("Waiting for informer caches to sync")
-	if ok := cache.WaitForCacheSync(stopCh, h.Informer().HasSynced); !ok {
-		logrus.Error("failed to wait for caches to sync")
+	h.logger.Info("waiting for informer caches to sync")
+	ok := cache.WaitForCacheSync(stopCh, h.Informer().HasSynced)
+	h.observer.InformerSynced(GVK, ok)
+	if !ok {
+		h.logger.Error(nil, "failed to wait for caches to sync")
 	}
 }
 
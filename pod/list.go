@@ -3,6 +3,7 @@ package pod
 import (
 	"fmt"
 
+	"github.com/forbearing/k8s/util/selector"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -24,14 +25,13 @@ func (h *Handler) List() ([]*corev1.Pod, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*corev1.Pod, error) {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
+		return nil, err
+	}
 	listOptions := h.Options.ListOptions.DeepCopy()
 	listOptions.LabelSelector = labels
 	//listOptions.ResourceVersion = ""
-	podList, err := h.clientset.CoreV1().Pods(h.namespace).List(h.ctx, *listOptions)
-	if err != nil {
-		return nil, err
-	}
-	return extractList(podList), nil
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByField list pods by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -42,14 +42,12 @@ func (h *Handler) ListByField(field string) ([]*corev1.Pod, error) {
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	podList, err := h.clientset.CoreV1().Pods(h.namespace).List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(podList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(h.namespace, *listOptions)
 }
 
 // ListByNamespace list all pods in the specified namespace.
@@ -117,24 +115,6 @@ func (h *Handler) ListUnknow() ([]*corev1.Pod, error) {
 	return h.WithNamespace(metav1.NamespaceAll).ListByField(field)
 }
 
-// extractList
-func extractList(podList *corev1.PodList) []*corev1.Pod {
-	//var pl []*corev1.Pod
-	// 不能这种方式遍历
-	// 参考: https://segmentfault.com/a/1190000038352530
-	//for _, p := range podList.Items {
-	//    pl = append(pl, &p)
-	//}
-
-	var pl []*corev1.Pod
-	for i := range podList.Items {
-		//val := ([]corev1.Pod(podList.Items))[i]
-		//pl = append(pl, &val)
-		pl = append(pl, &podList.Items[i])
-	}
-	return pl
-}
-
 // ListByStatus, reverse=true/false
 // https://kubernetes.io/docs/concepts/overview/working-with-objects/field-selectors/
 
@@ -4,11 +4,14 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/util/logs"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
@@ -133,3 +136,73 @@ func (h *Handler) getLog(namespace, name string, logOptions *LogOptions) error {
 	}
 	return scanner.Err()
 }
+
+// StreamLogs streams the logs of all pods selected by the labels and all their
+// containers like stern, the lines are prefixed with the pod and container
+// names, see package util/logs. It follows the new pods if opts.Follow is true.
+func (h *Handler) StreamLogs(labels string, opts *logs.Options) error {
+	selector, err := k8slabels.Parse(labels)
+	if err != nil {
+		return err
+	}
+	return logs.Stream(h.ctx, h.clientset, h.namespace, selector, opts)
+}
+
+// LogStream returns the logs of the pod as a stream, the caller must close it,
+// closing it stops streaming independently of the handler context. Unlike Log,
+// it doesn't require the pod to be ready, so the logs of the crashed or
+// completed pods can be read, set logOptions.Previous to read the logs of the
+// previous terminated container, eg:
+//
+//	rc, err := handler.LogStream("nginx", &corev1.PodLogOptions{Container: "nginx", Previous: true})
+func (h *Handler) LogStream(name string, logOptions *corev1.PodLogOptions) (io.ReadCloser, error) {
+	if logOptions == nil {
+		logOptions = &corev1.PodLogOptions{}
+	}
+	return h.clientset.CoreV1().Pods(h.namespace).GetLogs(name, logOptions).Stream(h.ctx)
+}
+
+// LogLines streams the log lines of the pod with the timestamps parsed, it
+// doesn't require the pod to be ready either. The lines channel is closed when
+// the logs end or the handler context is done, then the error channel receives
+// the error, nil if the logs end normally, eg:
+//
+//	lines, errc := handler.WithContext(ctx).LogLines("nginx", &corev1.PodLogOptions{Follow: true})
+//	for line := range lines {
+//	    fmt.Println(line.Timestamp, line.Text)
+//	}
+//	err := <-errc
+func (h *Handler) LogLines(name string, logOptions *corev1.PodLogOptions) (<-chan logs.Line, <-chan error) {
+	lines, errc := make(chan logs.Line), make(chan error, 1)
+	options := &corev1.PodLogOptions{}
+	if logOptions != nil {
+		options = logOptions.DeepCopy()
+	}
+	options.Timestamps = true
+
+	go func() {
+		defer close(errc)
+		rc, err := h.LogStream(name, options)
+		if err != nil {
+			close(lines)
+			errc <- err
+			return
+		}
+		defer rc.Close()
+		err = logs.ReadLines(rc, true, func(line logs.Line) bool {
+			line.Namespace, line.Pod, line.Container = h.namespace, name, options.Container
+			select {
+			case lines <- line:
+				return true
+			case <-h.ctx.Done():
+				return false
+			}
+		})
+		if err == nil {
+			err = h.ctx.Err()
+		}
+		close(lines)
+		errc <- err
+	}()
+	return lines, errc
+}
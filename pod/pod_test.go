@@ -113,7 +113,7 @@ func testPodTools(t *testing.T) {
 		"-c",
 		"cat /etc/os-release",
 	}
-	err = handler.Execute(name, "", command, nil)
+	err = handler.Execute(name, "", command)
 	myerr(t, "Execute", err)
 
 	//handler.DeleteFromFile(filename)
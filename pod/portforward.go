@@ -0,0 +1,42 @@
+package pod
+
+import (
+	"github.com/forbearing/k8s/util/portforward"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+)
+
+// ForwardPorts forwards the local ports to the pod in background, it returns
+// after the ports are forwarded. The ports are in the format of
+// "kubectl port-forward", ":80" forwards a random local port, and the remote
+// port can be the name of a container port, see package util/portforward.
+// The forwarding stops when the handler context is done or the forwarder is
+// closed, eg:
+//
+//	f, err := handler.ForwardPorts("nginx", []string{":http"}, &portforward.Options{Reconnect: true})
+//	defer f.Close()
+//	ports, _ := f.Ports()
+func (h *Handler) ForwardPorts(name string, ports []string, opts *portforward.Options) (*portforward.Forwarder, error) {
+	return forwardPorts(h, &portforward.Target{Pod: name}, ports, opts)
+}
+
+// ForwardPortsByLabel is like ForwardPorts, but forwards the ports to a running
+// and ready pod selected by the labels, and to another one if the pod is
+// replaced and opts.Reconnect is true.
+func (h *Handler) ForwardPortsByLabel(labels string, ports []string, opts *portforward.Options) (*portforward.Forwarder, error) {
+	selector, err := k8slabels.Parse(labels)
+	if err != nil {
+		return nil, err
+	}
+	return forwardPorts(h, &portforward.Target{Selector: selector}, ports, opts)
+}
+
+func forwardPorts(h *Handler, target *portforward.Target, ports []string, opts *portforward.Options) (*portforward.Forwarder, error) {
+	f, err := portforward.Forward(h.ctx, h.config, h.clientset, h.namespace, target, ports, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.WaitReady(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -1,24 +1,20 @@
 package pod
 
 import (
-	"context"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"os"
-	"os/signal"
 	"reflect"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/forbearing/k8s/util/signals"
-	log "github.com/sirupsen/logrus"
+	utilwait "github.com/forbearing/k8s/util/wait"
 	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/tools/remotecommand"
@@ -35,115 +31,71 @@ type PodController struct {
 
 // IsReady check whether the pod is ready.
 func (h *Handler) IsReady(name string) bool {
-	checkPhase := func(pod *corev1.Pod) bool {
-		if pod.Status.Phase == corev1.PodRunning {
-			return true
-		}
-		return false
-	}
-	checkCondition := func(pod *corev1.Pod) bool {
-		for _, cond := range pod.Status.Conditions {
-			if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
-				return true
-			}
-		}
-		return false
-	}
-
 	pod, err := h.Get(name)
 	if err != nil {
 		return false
 	}
-	if checkPhase(pod) && checkCondition(pod) {
-		return true
+	ready, _, _ := readiness(pod)
+	return ready
+}
+
+// WaitReady waits for the pod to be in the ready status. It watches the
+// pod and honours the handler context, use WithContext() to pass the
+// per-call context, and utilwait.WithTimeout/WithProgress to set the timeout
+// and to report why the pod is not ready yet, eg:
+//
+//	err := handler.WithContext(ctx).WaitReady(name, utilwait.WithTimeout(5*time.Minute))
+//
+// WaitReady returns the NotFound error immediately if the pod doesn't
+// exist. Without utilwait.WithTimeout it waits until the handler context is
+// done, so always pass a timeout unless the context has a deadline.
+//
+// The *utilwait.TimeoutError returned contains the reason why the pod is
+// not ready. It returns error immediately if the pod is failed or succeeded.
+func (h *Handler) WaitReady(name string, opts ...utilwait.Option) error {
+	// the wait by name waits for the pod to be created, get it first so a
+	// missing pod doesn't block until timeout.
+	if _, err := h.Get(name); err != nil {
+		return err
 	}
-	return false
+	cond := utilwait.Condition{Name: "ready", Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		pod := &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), pod); err != nil {
+			return false, "", err
+		}
+		return readiness(pod)
+	}}
+	return h.waitFor(utilwait.Target{Name: name}, cond, opts...)
 }
 
-// WaitReady waiting for the pod to be in the ready status.
-func (h *Handler) WaitReady(name string) error {
-	if h.IsReady(name) {
-		return nil
-	}
-
-	errCh := make(chan error, 1)
-	chkCh := make(chan struct{}, 1)
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL, syscall.SIGQUIT)
-	ctxCheck, cancelCheck := context.WithCancel(h.ctx)
-	ctxWatch, cancelWatch := context.WithCancel(h.ctx)
-	defer cancelCheck()
-	defer cancelWatch()
-
-	// this goroutine used to check whether pod is ready and exists.
-	// if pod already ready, return nil.
-	// if pod does not exist, return error.
-	// if pod exists but not ready, return nothing.
-	go func(context.Context) {
-		<-chkCh
-		for i := 0; i < 6; i++ {
-			// pod is already ready, return nil
-			if h.IsReady(name) {
-				errCh <- nil
-				return
-			}
-			// pod no longer exists, return err
-			_, err := h.Get(name)
-			if k8serrors.IsNotFound(err) {
-				errCh <- err
-				return
-			}
-			// pod exists but not ready, return northing.
-			if err == nil {
-				return
-			}
-			log.Error(err)
-			time.Sleep(time.Second * 10)
+// readiness returns whether the pod is ready and the reason if it's not.
+func readiness(pod *corev1.Pod) (bool, string, error) {
+	switch pod.Status.Phase {
+	case corev1.PodFailed, corev1.PodSucceeded:
+		return false, "", fmt.Errorf("pod %q is %s and will never be ready", pod.Name, pod.Status.Phase)
+	case corev1.PodRunning:
+	default:
+		return false, fmt.Sprintf("pod is %s", pod.Status.Phase), nil
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type != corev1.PodReady {
+			continue
 		}
-	}(ctxCheck)
-
-	// this goroutine used to watch pod.
-	go func(ctx context.Context) {
-		for {
-			timeout := int64(0)
-			listOptions := metav1.SingleObject(metav1.ObjectMeta{Name: name, Namespace: h.namespace})
-			listOptions.TimeoutSeconds = &timeout
-			watcher, err := h.clientset.CoreV1().Pods(h.namespace).Watch(h.ctx, listOptions)
-			if err != nil {
-				errCh <- err
-				return
-			}
-			chkCh <- struct{}{}
-			for event := range watcher.ResultChan() {
-				switch event.Type {
-				case watch.Modified:
-					if h.IsReady(name) {
-						watcher.Stop()
-						errCh <- nil
-						return
-					}
-				case watch.Deleted:
-					watcher.Stop()
-					errCh <- fmt.Errorf("pod/%s was deleted", name)
-					return
-				case watch.Bookmark:
-					log.Debug("watch pod: bookmark")
-				case watch.Error:
-					log.Debug("watch pod: error")
-				}
+		if cond.Status == corev1.ConditionTrue {
+			return true, "", nil
+		}
+		var notReady []string
+		for _, status := range pod.Status.ContainerStatuses {
+			if !status.Ready {
+				notReady = append(notReady, status.Name)
 			}
-			// If event channel is closed, it means the kube-apiserver has closed the connection.
-			log.Debug("watch pod: reconnect to kubernetes")
-			watcher.Stop()
 		}
-	}(ctxWatch)
-
-	select {
-	case sig := <-sigCh:
-		return fmt.Errorf("cancelled by signal: %s", sig.String())
-	case err := <-errCh:
-		return err
+		if len(notReady) != 0 {
+			return false, fmt.Sprintf("containers not ready: %s", strings.Join(notReady, ", ")), nil
+		}
+		return false, "condition Ready is not True", nil
 	}
+	return false, "condition Ready not found", nil
 }
 
 //// WaitReady waiting for the pod to be in the ready status.
@@ -708,10 +660,13 @@ func (h *Handler) PortForward(podName string, localPort, remotePort uint32, stop
 	if err != nil {
 		return err
 	}
-	apiPath := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/portforward", h.namespace, podName)
-	hostIP := strings.TrimLeft(h.config.Host, "https:/")
-	serverURL := url.URL{Scheme: "https", Path: apiPath, Host: hostIP}
-	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, &serverURL)
+	serverURL := h.restClient.Post().
+		Namespace(h.namespace).
+		Resource("pods").
+		Name(podName).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, serverURL)
 
 	var stopCh <-chan struct{}
 	if len(stopChan) == 0 {
@@ -734,10 +689,13 @@ func (h *Handler) PortForwardWithStream(podName string, localPort, remotePort ui
 	if err != nil {
 		return err
 	}
-	apiPath := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/portforward", h.namespace, podName)
-	hostIP := strings.TrimLeft(h.config.Host, "https:/")
-	serverURL := url.URL{Scheme: "https", Path: apiPath, Host: hostIP}
-	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, &serverURL)
+	serverURL := h.restClient.Post().
+		Namespace(h.namespace).
+		Resource("pods").
+		Name(podName).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, serverURL)
 
 	var stopCh <-chan struct{}
 	if len(stopChan) == 0 {
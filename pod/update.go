@@ -1,10 +1,12 @@
 package pod
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*corev1.Pod, error) {
 
 // updatePod
 func (h *Handler) updatePod(pod *corev1.Pod) (*corev1.Pod, error) {
+	pod.UID = ""
+	pod.ResourceVersion = ""
+	return h.updatePodWithResourceVersion(pod)
+}
+
+// updatePodWithResourceVersion updates the pod without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the pod has been changed since it was read.
+func (h *Handler) updatePodWithResourceVersion(pod *corev1.Pod) (*corev1.Pod, error) {
 	namespace := pod.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	pod.UID = ""
-	pod.ResourceVersion = ""
-	return h.clientset.CoreV1().Pods(namespace).Update(h.ctx, pod, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: pod.Name, Object: pod}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Pod, error) {
+		return h.clientset.CoreV1().Pods(namespace).Update(ctx, pod, h.Options.UpdateOptions)
+	})
 }
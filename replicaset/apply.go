@@ -1,8 +1,10 @@
 package replicaset
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*appsv1.ReplicaSet, er
 
 // applyReplicaset
 func (h *Handler) applyReplicaset(rs *appsv1.ReplicaSet) (*appsv1.ReplicaSet, error) {
-	_, err := h.createReplicaset(rs)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateReplicaset(rs)
+	namespace := rs.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return rs, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: rs.Name, Object: rs}
+	return h.intercept(op, func(ctx context.Context, _ string) (*appsv1.ReplicaSet, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createReplicaset(rs)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateReplicaset(rs)
+		}
+		return rs, err
+	})
 }
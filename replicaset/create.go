@@ -1,10 +1,12 @@
 package replicaset
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createReplicaset(rs *appsv1.ReplicaSet) (*appsv1.ReplicaSet, e
 	}
 	rs.ResourceVersion = ""
 	rs.UID = ""
-	return h.clientset.AppsV1().ReplicaSets(namespace).Create(h.ctx, rs, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: rs.Name, Object: rs}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.ReplicaSet, error) {
+		return h.clientset.AppsV1().ReplicaSets(namespace).Create(ctx, rs, h.Options.CreateOptions)
+	})
 }
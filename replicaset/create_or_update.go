@@ -0,0 +1,131 @@
+package replicaset
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing replicaset into it's desired state.
+type MutateFn func(rs *appsv1.ReplicaSet) error
+
+// CreateOrUpdate gets the replicaset from the kubernetes API server. If the
+// replicaset does not exist, it will be created after mutateFn is called.
+// If the replicaset exists, mutateFn is called on the live replicaset and the
+// replicaset will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live replicaset, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The rs must contain the name and namespace(optional) of the replicaset,
+// rs will be overwritten by the live replicaset returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(rs *appsv1.ReplicaSet, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getReplicaset(rs)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, rs); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createReplicaset(rs)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*rs = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*rs = *existing.DeepCopy()
+	if err := mutate(mutateFn, rs); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, rs) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateReplicasetWithResourceVersion(rs)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*rs = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the replicaset already exists,
+// only the difference between the live replicaset and the mutated replicaset
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(rs *appsv1.ReplicaSet, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getReplicaset(rs)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, rs); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createReplicaset(rs)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*rs = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*rs = *existing.DeepCopy()
+	if err := mutate(mutateFn, rs); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(rs); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, appsv1.ReplicaSet{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*rs = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, rs *appsv1.ReplicaSet) error {
+	name, namespace := rs.GetName(), rs.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(rs); err != nil {
+			return err
+		}
+	}
+	if rs.GetName() != name || rs.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate replicaset name and/or replicaset namespace")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package replicaset
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes replicaset by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.AppsV1().ReplicaSets(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.ReplicaSet, error) {
+		return nil, h.clientset.AppsV1().ReplicaSets(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes replicaset from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteReplicaset(rs *appsv1.ReplicaSet) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().ReplicaSets(namespace).Delete(h.ctx, rs.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: rs.Name, Object: rs}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.ReplicaSet, error) {
+		return nil, h.clientset.AppsV1().ReplicaSets(namespace).Delete(ctx, rs.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*appsv1.ReplicaSet, error) {
 
 // GetByName gets replicaset by name.
 func (h *Handler) GetByName(name string) (*appsv1.ReplicaSet, error) {
-	return h.clientset.AppsV1().ReplicaSets(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	rs, err := h.clientset.AppsV1().ReplicaSets(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return rs, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets replicaset from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getReplicaset(rs *appsv1.ReplicaSet) (*appsv1.ReplicaSet, erro
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().ReplicaSets(namespace).Get(h.ctx, rs.Name, h.Options.GetOptions)
+	newRS, err := h.clientset.AppsV1().ReplicaSets(namespace).Get(h.ctx, rs.Name, h.Options.GetOptions)
+	return newRS, utilerrors.Wrap("get", GVK, namespace, rs.Name, err)
 }
@@ -1,142 +1,73 @@
 package replicaset
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
-	log "github.com/sirupsen/logrus"
+	utilwait "github.com/forbearing/k8s/util/wait"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
-// IsReady check if the replicaset is ready
+// IsReady check if the replicaset is ready.
 func (h *Handler) IsReady(name string) bool {
-	checkGeneration := func(rs *appsv1.ReplicaSet) bool {
-		if rs.Generation != rs.Status.ObservedGeneration {
-			return false
-		}
-		return true
-	}
-	checkReplicas := func(rs *appsv1.ReplicaSet) bool {
-		if rs.Spec.Replicas == nil {
-			return false
-		}
-		if *rs.Spec.Replicas != rs.Status.ReadyReplicas {
-			return false
-		}
-		if *rs.Spec.Replicas != rs.Status.AvailableReplicas {
-			return false
-		}
-		if *rs.Spec.Replicas != rs.Status.Replicas {
-			return false
-		}
-		if *rs.Spec.Replicas != rs.Status.FullyLabeledReplicas {
-
-		}
-		return true
-	}
-
 	rs, err := h.Get(name)
 	if err != nil {
 		return false
 	}
-	if checkGeneration(rs) && checkReplicas(rs) {
-		return true
-	}
-	return false
+	ready, _, _ := readiness(rs)
+	return ready
 }
 
-// WaitReady waiting for the replicaset to be in the ready status.
-func (h *Handler) WaitReady(name string) error {
-	if h.IsReady(name) {
-		return nil
+// WaitReady waits for the replicaset to be in the ready status. It watches the
+// replicaset and honours the handler context, use WithContext() to pass the
+// per-call context, and utilwait.WithTimeout/WithProgress to set the timeout
+// and to report why the replicaset is not ready yet, eg:
+//
+//	err := handler.WithContext(ctx).WaitReady(name, utilwait.WithTimeout(5*time.Minute))
+//
+// WaitReady returns the NotFound error immediately if the replicaset doesn't
+// exist. Without utilwait.WithTimeout it waits until the handler context is
+// done, so always pass a timeout unless the context has a deadline.
+//
+// The *utilwait.TimeoutError returned contains the reason why the replicaset is
+// not ready.
+func (h *Handler) WaitReady(name string, opts ...utilwait.Option) error {
+	// the wait by name waits for the replicaset to be created, get it first so a
+	// missing replicaset doesn't block until timeout.
+	if _, err := h.Get(name); err != nil {
+		return err
 	}
-
-	errCh := make(chan error, 1)
-	chkCh := make(chan struct{}, 1)
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL, syscall.SIGQUIT)
-	ctxCheck, cancelCheck := context.WithCancel(h.ctx)
-	ctxWatch, cancelWatch := context.WithCancel(h.ctx)
-	defer cancelCheck()
-	defer cancelWatch()
-
-	// this goroutine used to check whether replicaset is ready and exists.
-	// if replicaset already ready, return nil.
-	// if replicaset does not exist, return error.
-	// if replicaset exists but not ready, return nothing.
-	go func(context.Context) {
-		<-chkCh
-		for i := 0; i < 6; i++ {
-			// replicaset is already ready, return nil
-			if h.IsReady(name) {
-				errCh <- nil
-				return
-			}
-			// replicaset no longer exists, return err
-			_, err := h.Get(name)
-			if k8serrors.IsNotFound(err) {
-				errCh <- err
-				return
-			}
-			// replicaset exists but not ready, return nothing.
-			if err == nil {
-				return
-			}
-			log.Error(err)
-			time.Sleep(time.Second * 10)
-		}
-	}(ctxCheck)
-
-	// this goroutine used to watch replicaset.
-	go func(ctx context.Context) {
-		for {
-			timeout := int64(0)
-			listOptions := metav1.SingleObject(metav1.ObjectMeta{Name: name, Namespace: h.namespace})
-			listOptions.TimeoutSeconds = &timeout
-			watcher, err := h.clientset.AppsV1().ReplicaSets(h.namespace).Watch(h.ctx, listOptions)
-			if err != nil {
-				errCh <- err
-				return
-			}
-			chkCh <- struct{}{}
-			for event := range watcher.ResultChan() {
-				switch event.Type {
-				case watch.Modified:
-					if h.IsReady(name) {
-						watcher.Stop()
-						errCh <- nil
-						return
-					}
-				case watch.Deleted:
-					watcher.Stop()
-					errCh <- fmt.Errorf("replicaset/%s was deleted", name)
-					return
-				case watch.Bookmark:
-					log.Debug("watch replicaset: bookmark")
-				case watch.Error:
-					log.Debug("watch replicaset: error")
-				}
-			}
-			// If event channel is closed, it means the kube-apiserver has closed the connection.
-			log.Debug("watch replicaset: reconnect to kubernetes")
-			watcher.Stop()
+	cond := utilwait.Condition{Name: "ready", Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		rs := &appsv1.ReplicaSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), rs); err != nil {
+			return false, "", err
 		}
-	}(ctxWatch)
+		return readiness(rs)
+	}}
+	return h.waitFor(utilwait.Target{Name: name}, cond, opts...)
+}
 
-	select {
-	case sig := <-sigCh:
-		return fmt.Errorf("cancelled by signal: %s", sig.String())
-	case err := <-errCh:
-		return err
+// readiness returns whether the replicaset is ready and the reason if it's not.
+func readiness(rs *appsv1.ReplicaSet) (bool, string, error) {
+	if rs.Generation != rs.Status.ObservedGeneration {
+		return false, "waiting for the replicaset spec update to be observed", nil
+	}
+	if rs.Spec.Replicas == nil {
+		return false, "spec.replicas is not set", nil
+	}
+	replicas := *rs.Spec.Replicas
+	switch {
+	case rs.Status.Replicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas created", rs.Status.Replicas, replicas), nil
+	case rs.Status.ReadyReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas ready", rs.Status.ReadyReplicas, replicas), nil
+	case rs.Status.AvailableReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas available", rs.Status.AvailableReplicas, replicas), nil
 	}
+	return true, "", nil
 }
 
 //// WaitReady wait the replicaset to be th ready status
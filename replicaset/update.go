@@ -1,10 +1,12 @@
 package replicaset
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*appsv1.ReplicaSet, e
 
 // updateReplicaset
 func (h *Handler) updateReplicaset(rs *appsv1.ReplicaSet) (*appsv1.ReplicaSet, error) {
+	rs.ResourceVersion = ""
+	rs.UID = ""
+	return h.updateReplicasetWithResourceVersion(rs)
+}
+
+// updateReplicasetWithResourceVersion updates the replicaset without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the replicaset has been changed since it was read.
+func (h *Handler) updateReplicasetWithResourceVersion(rs *appsv1.ReplicaSet) (*appsv1.ReplicaSet, error) {
 	namespace := rs.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	rs.ResourceVersion = ""
-	rs.UID = ""
-	return h.clientset.AppsV1().ReplicaSets(namespace).Update(h.ctx, rs, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: rs.Name, Object: rs}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.ReplicaSet, error) {
+		return h.clientset.AppsV1().ReplicaSets(namespace).Update(ctx, rs, h.Options.UpdateOptions)
+	})
 }
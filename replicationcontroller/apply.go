@@ -1,8 +1,10 @@
 package replicationcontroller
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*corev1.ReplicationCon
 
 // applyRS
 func (h *Handler) applyRS(rc *corev1.ReplicationController) (*corev1.ReplicationController, error) {
-	_, err := h.createRS(rc)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateRS(rc)
+	namespace := rc.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return rc, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: rc.Name, Object: rc}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.ReplicationController, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createRS(rc)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateRS(rc)
+		}
+		return rc, err
+	})
 }
@@ -1,10 +1,12 @@
 package replicationcontroller
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createRS(rc *corev1.ReplicationController) (*corev1.Replicatio
 	}
 	rc.ResourceVersion = ""
 	rc.UID = ""
-	return h.clientset.CoreV1().ReplicationControllers(namespace).Create(h.ctx, rc, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: rc.Name, Object: rc}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.ReplicationController, error) {
+		return h.clientset.CoreV1().ReplicationControllers(namespace).Create(ctx, rc, h.Options.CreateOptions)
+	})
 }
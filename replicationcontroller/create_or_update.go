@@ -0,0 +1,131 @@
+package replicationcontroller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing replicationcontroller into it's desired state.
+type MutateFn func(rc *corev1.ReplicationController) error
+
+// CreateOrUpdate gets the replicationcontroller from the kubernetes API server. If the
+// replicationcontroller does not exist, it will be created after mutateFn is called.
+// If the replicationcontroller exists, mutateFn is called on the live replicationcontroller and the
+// replicationcontroller will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live replicationcontroller, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The rc must contain the name and namespace(optional) of the replicationcontroller,
+// rc will be overwritten by the live replicationcontroller returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(rc *corev1.ReplicationController, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getRS(rc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, rc); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createRS(rc)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*rc = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*rc = *existing.DeepCopy()
+	if err := mutate(mutateFn, rc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, rc) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateRSWithResourceVersion(rc)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*rc = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the replicationcontroller already exists,
+// only the difference between the live replicationcontroller and the mutated replicationcontroller
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(rc *corev1.ReplicationController, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getRS(rc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, rc); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createRS(rc)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*rc = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*rc = *existing.DeepCopy()
+	if err := mutate(mutateFn, rc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(rc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, corev1.ReplicationController{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*rc = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, rc *corev1.ReplicationController) error {
+	name, namespace := rc.GetName(), rc.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(rc); err != nil {
+			return err
+		}
+	}
+	if rc.GetName() != name || rc.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate replicationcontroller name and/or replicationcontroller namespace")
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*corev1.ReplicationController, error) {
 
 // GetByName gets replicationcontroller by name.
 func (h *Handler) GetByName(name string) (*corev1.ReplicationController, error) {
-	return h.clientset.CoreV1().ReplicationControllers(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	rc, err := h.clientset.CoreV1().ReplicationControllers(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return rc, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets replicationcontroller from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getRS(rc *corev1.ReplicationController) (*corev1.ReplicationCo
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().ReplicationControllers(namespace).Get(h.ctx, rc.Name, h.Options.GetOptions)
+	newRC, err := h.clientset.CoreV1().ReplicationControllers(namespace).Get(h.ctx, rc.Name, h.Options.GetOptions)
+	return newRC, utilerrors.Wrap("get", GVK, namespace, rc.Name, err)
 }
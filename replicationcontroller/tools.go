@@ -1,141 +1,74 @@
 package replicationcontroller
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
-	log "github.com/sirupsen/logrus"
+	utilwait "github.com/forbearing/k8s/util/wait"
 	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
-// IsReady check if the replicationcontroller is ready
+// IsReady check if the replicationcontroller is ready.
 func (h *Handler) IsReady(name string) bool {
-	checkGeneration := func(rc *corev1.ReplicationController) bool {
-		if rc.Generation != rc.Status.ObservedGeneration {
-			return false
-		}
-		return true
-	}
-	checkReplicas := func(rc *corev1.ReplicationController) bool {
-		if rc.Spec.Replicas == nil {
-			return false
-		}
-		if *rc.Spec.Replicas != rc.Status.ReadyReplicas {
-			return false
-		}
-		if *rc.Spec.Replicas != rc.Status.AvailableReplicas {
-			return false
-		}
-		if *rc.Spec.Replicas != rc.Status.Replicas {
-			return false
-		}
-		if *rc.Spec.Replicas != rc.Status.FullyLabeledReplicas {
-			return false
-		}
-		return true
-	}
-
 	rc, err := h.Get(name)
 	if err != nil {
 		return false
 	}
-	if checkGeneration(rc) && checkReplicas(rc) {
-		return true
-	}
-	return false
+	ready, _, _ := readiness(rc)
+	return ready
 }
 
-// WaitReady waiting for the replicationcontroller to be in the ready status.
-func (h *Handler) WaitReady(name string) error {
-	if h.IsReady(name) {
-		return nil
+// WaitReady waits for the replicationcontroller to be in the ready status. It watches the
+// replicationcontroller and honours the handler context, use WithContext() to pass the
+// per-call context, and utilwait.WithTimeout/WithProgress to set the timeout
+// and to report why the replicationcontroller is not ready yet, eg:
+//
+//	err := handler.WithContext(ctx).WaitReady(name, utilwait.WithTimeout(5*time.Minute))
+//
+// WaitReady returns the NotFound error immediately if the replicationcontroller doesn't
+// exist. Without utilwait.WithTimeout it waits until the handler context is
+// done, so always pass a timeout unless the context has a deadline.
+//
+// The *utilwait.TimeoutError returned contains the reason why the replicationcontroller is
+// not ready.
+func (h *Handler) WaitReady(name string, opts ...utilwait.Option) error {
+	// the wait by name waits for the replicationcontroller to be created, get it first so a
+	// missing replicationcontroller doesn't block until timeout.
+	if _, err := h.Get(name); err != nil {
+		return err
 	}
-
-	errCh := make(chan error, 1)
-	chkCh := make(chan struct{}, 1)
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL, syscall.SIGQUIT)
-	ctxCheck, cancelCheck := context.WithCancel(h.ctx)
-	ctxWatch, cancelWatch := context.WithCancel(h.ctx)
-	defer cancelCheck()
-	defer cancelWatch()
-
-	// this goroutine used to check whether replicationcontroller is ready and exists.
-	// if replicationcontroller already ready, return nil.
-	// if replicationcontroller does not exist, return error.
-	// if replicationcontroller exists but not ready, return nothing.
-	go func(context.Context) {
-		<-chkCh
-		for i := 0; i < 6; i++ {
-			// replicationcontroller is already ready, return nil
-			if h.IsReady(name) {
-				errCh <- nil
-				return
-			}
-			// replicationcontroller no longer exists, return err
-			_, err := h.Get(name)
-			if k8serrors.IsNotFound(err) {
-				errCh <- err
-				return
-			}
-			// replicationcontroller exists but not ready, return nothing.
-			if err == nil {
-				return
-			}
-			log.Error(err)
-			time.Sleep(time.Second * 10)
-		}
-	}(ctxCheck)
-
-	// this goroutine used to watch replicationcontroller.
-	go func(ctx context.Context) {
-		for {
-			timeout := int64(0)
-			listOptions := metav1.SingleObject(metav1.ObjectMeta{Name: name, Namespace: h.namespace})
-			listOptions.TimeoutSeconds = &timeout
-			watcher, err := h.clientset.CoreV1().ReplicationControllers(h.namespace).Watch(h.ctx, listOptions)
-			if err != nil {
-				errCh <- err
-				return
-			}
-			chkCh <- struct{}{}
-			for event := range watcher.ResultChan() {
-				switch event.Type {
-				case watch.Modified:
-					if h.IsReady(name) {
-						watcher.Stop()
-						errCh <- nil
-						return
-					}
-				case watch.Deleted:
-					watcher.Stop()
-					errCh <- fmt.Errorf("replicationcontroller/%s was deleted", name)
-					return
-				case watch.Bookmark:
-					log.Debug("watch replicationcontroller: bookmark")
-				case watch.Error:
-					log.Debug("watch replicationcontroller: error")
-				}
-			}
-			// If event channel is closed, it means the kube-apiserver has closed the connection.
-			log.Debug("watch replicationcontroller: reconnect to kubernetes")
-			watcher.Stop()
+	cond := utilwait.Condition{Name: "ready", Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		rc := &corev1.ReplicationController{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), rc); err != nil {
+			return false, "", err
 		}
-	}(ctxWatch)
+		return readiness(rc)
+	}}
+	return h.waitFor(utilwait.Target{Name: name}, cond, opts...)
+}
 
-	select {
-	case sig := <-sigCh:
-		return fmt.Errorf("cancelled by signal: %s", sig.String())
-	case err := <-errCh:
-		return err
+// readiness returns whether the replicationcontroller is ready and the reason if it's not.
+func readiness(rc *corev1.ReplicationController) (bool, string, error) {
+	if rc.Generation != rc.Status.ObservedGeneration {
+		return false, "waiting for the replicationcontroller spec update to be observed", nil
+	}
+	if rc.Spec.Replicas == nil {
+		return false, "spec.replicas is not set", nil
+	}
+	replicas := *rc.Spec.Replicas
+	switch {
+	case rc.Status.Replicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas created", rc.Status.Replicas, replicas), nil
+	case rc.Status.FullyLabeledReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas fully labeled", rc.Status.FullyLabeledReplicas, replicas), nil
+	case rc.Status.ReadyReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas ready", rc.Status.ReadyReplicas, replicas), nil
+	case rc.Status.AvailableReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas available", rc.Status.AvailableReplicas, replicas), nil
 	}
+	return true, "", nil
 }
 
 //// WaitReady wait for the replicationcontroller to be in the ready status
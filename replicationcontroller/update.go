@@ -1,10 +1,12 @@
 package replicationcontroller
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*corev1.ReplicationCo
 
 // updateRS
 func (h *Handler) updateRS(rc *corev1.ReplicationController) (*corev1.ReplicationController, error) {
+	rc.ResourceVersion = ""
+	rc.UID = ""
+	return h.updateRSWithResourceVersion(rc)
+}
+
+// updateRSWithResourceVersion updates the replicationcontroller without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the replicationcontroller has been changed since it was read.
+func (h *Handler) updateRSWithResourceVersion(rc *corev1.ReplicationController) (*corev1.ReplicationController, error) {
 	namespace := rc.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	rc.ResourceVersion = ""
-	rc.UID = ""
-	return h.clientset.CoreV1().ReplicationControllers(namespace).Update(h.ctx, rc, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: rc.Name, Object: rc}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.ReplicationController, error) {
+		return h.clientset.CoreV1().ReplicationControllers(namespace).Update(ctx, rc, h.Options.UpdateOptions)
+	})
 }
@@ -1,8 +1,10 @@
 package role
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*rbacv1.Role, error) {
 
 // applyRole
 func (h *Handler) applyRole(role *rbacv1.Role) (*rbacv1.Role, error) {
-	_, err := h.createRole(role)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateRole(role)
+	namespace := role.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return role, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: role.Name, Object: role}
+	return h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.Role, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createRole(role)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateRole(role)
+		}
+		return role, err
+	})
 }
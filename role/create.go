@@ -1,10 +1,12 @@
 package role
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createRole(role *rbacv1.Role) (*rbacv1.Role, error) {
 	}
 	role.ResourceVersion = ""
 	role.UID = ""
-	return h.clientset.RbacV1().Roles(namespace).Create(h.ctx, role, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: role.Name, Object: role}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*rbacv1.Role, error) {
+		return h.clientset.RbacV1().Roles(namespace).Create(ctx, role, h.Options.CreateOptions)
+	})
 }
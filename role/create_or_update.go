@@ -0,0 +1,131 @@
+package role
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing role into it's desired state.
+type MutateFn func(role *rbacv1.Role) error
+
+// CreateOrUpdate gets the role from the kubernetes API server. If the
+// role does not exist, it will be created after mutateFn is called.
+// If the role exists, mutateFn is called on the live role and the
+// role will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live role, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The role must contain the name and namespace(optional) of the role,
+// role will be overwritten by the live role returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(role *rbacv1.Role, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getRole(role)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, role); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createRole(role)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*role = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*role = *existing.DeepCopy()
+	if err := mutate(mutateFn, role); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, role) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateRoleWithResourceVersion(role)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*role = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the role already exists,
+// only the difference between the live role and the mutated role
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(role *rbacv1.Role, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getRole(role)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, role); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createRole(role)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*role = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*role = *existing.DeepCopy()
+	if err := mutate(mutateFn, role); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(role); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, rbacv1.Role{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*role = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, role *rbacv1.Role) error {
+	name, namespace := role.GetName(), role.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(role); err != nil {
+			return err
+		}
+	}
+	if role.GetName() != name || role.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate role name and/or role namespace")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package role
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes role by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.RbacV1().Roles(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*rbacv1.Role, error) {
+		return nil, h.clientset.RbacV1().Roles(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes role from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteRole(role *rbacv1.Role) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.RbacV1().Roles(namespace).Delete(h.ctx, role.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: role.Name, Object: role}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*rbacv1.Role, error) {
+		return nil, h.clientset.RbacV1().Roles(namespace).Delete(ctx, role.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
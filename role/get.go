@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*rbacv1.Role, error) {
 
 // GetByName gets role by name.
 func (h *Handler) GetByName(name string) (*rbacv1.Role, error) {
-	return h.clientset.RbacV1().Roles(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	role, err := h.clientset.RbacV1().Roles(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return role, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets role from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getRole(role *rbacv1.Role) (*rbacv1.Role, error) {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.RbacV1().Roles(namespace).Get(h.ctx, role.Name, h.Options.GetOptions)
+	newRole, err := h.clientset.RbacV1().Roles(namespace).Get(h.ctx, role.Name, h.Options.GetOptions)
+	return newRole, utilerrors.Wrap("get", GVK, namespace, role.Name, err)
 }
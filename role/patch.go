@@ -1,6 +1,7 @@
 package role
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -117,8 +118,10 @@ func (h *Handler) strategicMergePatch(original *rbacv1.Role, patchData []byte) (
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.RbacV1().Roles(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*rbacv1.Role, error) {
+		return h.clientset.RbacV1().Roles(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonMergePatch use the "JSON Merge Patch" patch type to patch role.
@@ -140,8 +143,10 @@ func (h *Handler) jsonMergePatch(original *rbacv1.Role, patchData []byte) (*rbac
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.RbacV1().Roles(namespace).
-		Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*rbacv1.Role, error) {
+		return h.clientset.RbacV1().Roles(namespace).
+			Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonPatch use "JSON Patch" patch type to patch role.
@@ -156,8 +161,10 @@ func (h *Handler) jsonPatch(original *rbacv1.Role, patchData []byte) (*rbacv1.Ro
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.RbacV1().Roles(namespace).Patch(h.ctx,
-		original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.JSONPatchType, patchData, func(ctx context.Context, namespace string) (*rbacv1.Role, error) {
+		return h.clientset.RbacV1().Roles(namespace).Patch(ctx,
+			original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // diffMergePatch will tak the difference data between original and modified role object,
@@ -190,9 +197,13 @@ func (h *Handler) diffMergePatch(original, modified *rbacv1.Role, patchOptions .
 		namespace = h.namespace
 	}
 	if len(patchOptions) != 0 && patchOptions[0] == types.MergePatchType {
-		return h.clientset.RbacV1().Roles(namespace).
-			Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		return h.interceptPatch(namespace, original.Name, types.MergePatchType, patchData, func(ctx context.Context, namespace string) (*rbacv1.Role, error) {
+			return h.clientset.RbacV1().Roles(namespace).
+				Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		})
 	}
-	return h.clientset.RbacV1().Roles(namespace).
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch(namespace, original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, namespace string) (*rbacv1.Role, error) {
+		return h.clientset.RbacV1().Roles(namespace).
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
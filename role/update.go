@@ -1,10 +1,12 @@
 package role
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*rbacv1.Role, error)
 
 // updateRole
 func (h *Handler) updateRole(role *rbacv1.Role) (*rbacv1.Role, error) {
+	role.ResourceVersion = ""
+	role.UID = ""
+	return h.updateRoleWithResourceVersion(role)
+}
+
+// updateRoleWithResourceVersion updates the role without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the role has been changed since it was read.
+func (h *Handler) updateRoleWithResourceVersion(role *rbacv1.Role) (*rbacv1.Role, error) {
 	namespace := role.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	role.ResourceVersion = ""
-	role.UID = ""
-	return h.clientset.RbacV1().Roles(namespace).Update(h.ctx, role, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: role.Name, Object: role}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*rbacv1.Role, error) {
+		return h.clientset.RbacV1().Roles(namespace).Update(ctx, role, h.Options.UpdateOptions)
+	})
 }
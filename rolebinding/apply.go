@@ -1,8 +1,10 @@
 package rolebinding
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*rbacv1.RoleBinding, e
 
 // applyRolebinding
 func (h *Handler) applyRolebinding(rb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
-	_, err := h.createRolebinding(rb)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateRolebinding(rb)
+	namespace := rb.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return rb, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: rb.Name, Object: rb}
+	return h.intercept(op, func(ctx context.Context, _ string) (*rbacv1.RoleBinding, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createRolebinding(rb)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateRolebinding(rb)
+		}
+		return rb, err
+	})
 }
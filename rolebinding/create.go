@@ -1,10 +1,12 @@
 package rolebinding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createRolebinding(rb *rbacv1.RoleBinding) (*rbacv1.RoleBinding
 	}
 	rb.ResourceVersion = ""
 	rb.UID = ""
-	return h.clientset.RbacV1().RoleBindings(namespace).Create(h.ctx, rb, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: rb.Name, Object: rb}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*rbacv1.RoleBinding, error) {
+		return h.clientset.RbacV1().RoleBindings(namespace).Create(ctx, rb, h.Options.CreateOptions)
+	})
 }
@@ -0,0 +1,131 @@
+package rolebinding
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing rolebinding into it's desired state.
+type MutateFn func(rb *rbacv1.RoleBinding) error
+
+// CreateOrUpdate gets the rolebinding from the kubernetes API server. If the
+// rolebinding does not exist, it will be created after mutateFn is called.
+// If the rolebinding exists, mutateFn is called on the live rolebinding and the
+// rolebinding will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live rolebinding, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The rb must contain the name and namespace(optional) of the rolebinding,
+// rb will be overwritten by the live rolebinding returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(rb *rbacv1.RoleBinding, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getRolebinding(rb)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, rb); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createRolebinding(rb)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*rb = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*rb = *existing.DeepCopy()
+	if err := mutate(mutateFn, rb); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, rb) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateRolebindingWithResourceVersion(rb)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*rb = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the rolebinding already exists,
+// only the difference between the live rolebinding and the mutated rolebinding
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(rb *rbacv1.RoleBinding, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getRolebinding(rb)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, rb); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createRolebinding(rb)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*rb = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*rb = *existing.DeepCopy()
+	if err := mutate(mutateFn, rb); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(rb); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, rbacv1.RoleBinding{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*rb = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, rb *rbacv1.RoleBinding) error {
+	name, namespace := rb.GetName(), rb.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(rb); err != nil {
+			return err
+		}
+	}
+	if rb.GetName() != name || rb.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate rolebinding name and/or rolebinding namespace")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package rolebinding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes rolebinding by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.RbacV1().RoleBindings(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*rbacv1.RoleBinding, error) {
+		return nil, h.clientset.RbacV1().RoleBindings(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes rolebinding from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteRolebinding(rb *rbacv1.RoleBinding) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.RbacV1().RoleBindings(namespace).Delete(h.ctx, rb.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: rb.Name, Object: rb}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*rbacv1.RoleBinding, error) {
+		return nil, h.clientset.RbacV1().RoleBindings(namespace).Delete(ctx, rb.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*rbacv1.RoleBinding, error) {
 
 // GetByName gets rolebinding by name.
 func (h *Handler) GetByName(name string) (*rbacv1.RoleBinding, error) {
-	return h.clientset.RbacV1().RoleBindings(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	rb, err := h.clientset.RbacV1().RoleBindings(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return rb, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets rolebinding from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getRolebinding(rb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, e
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.RbacV1().RoleBindings(namespace).Get(h.ctx, rb.Name, h.Options.GetOptions)
+	newRB, err := h.clientset.RbacV1().RoleBindings(namespace).Get(h.ctx, rb.Name, h.Options.GetOptions)
+	return newRB, utilerrors.Wrap("get", GVK, namespace, rb.Name, err)
 }
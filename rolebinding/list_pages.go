@@ -0,0 +1,189 @@
+package rolebinding
+
+import (
+	"context"
+
+	utilerrors "github.com/forbearing/k8s/util/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedrbacv1 "k8s.io/client-go/kubernetes/typed/rbac/v1"
+)
+
+// DefaultPageSize is the number of rolebindings requested per page when
+// no limit is set by SetLimit().
+const DefaultPageSize = 500
+
+// ListPages list the rolebindings in the handler namespace page by page, and
+// calls fn for every page. Use WithNamespace(metav1.NamespaceAll) to list the
+// rolebindings in all namespaces. ListPages stops and returns the error if fn
+// returns an error.
+//
+// The label selector and field selector of listOptions are used to filter the
+// rolebindings, the page size is listOptions.Limit, default to the limit set by
+// SetLimit(), then DefaultPageSize, eg:
+//
+//	err := handler.ListPages(metav1.ListOptions{LabelSelector: "app=nginx"}, func(page []*rbacv1.RoleBinding) error {
+//	    ...
+//	})
+//
+// ListPages follows the continue token returned by kubernetes API server. If
+// the continue token expired, ListPages relists from the beginning and skips
+// the rolebindings that already passed to fn.
+func (h *Handler) ListPages(listOptions metav1.ListOptions, fn func(page []*rbacv1.RoleBinding) error) error {
+	return h.newPager(listOptions).forEach(fn)
+}
+
+// ListIterator iterates rolebindings returned by the kubernetes API server one
+// by one, only one page of rolebindings is kept in memory.
+type ListIterator struct {
+	pager *pager
+	page  []*rbacv1.RoleBinding
+	index int
+	err   error
+}
+
+// ListIter returns a ListIterator that iterates the rolebindings in the handler
+// namespace, listOptions is used like ListPages.
+func (h *Handler) ListIter(listOptions metav1.ListOptions) *ListIterator {
+	return &ListIterator{pager: h.newPager(listOptions), index: -1}
+}
+
+// Next advances the iterator to the next rolebinding, it returns false when
+// there are no more rolebindings or an error occurs.
+func (it *ListIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= len(it.page) {
+		if it.pager.done {
+			return false
+		}
+		if it.page, it.err = it.pager.next(); it.err != nil {
+			return false
+		}
+		it.index = 0
+	}
+	return true
+}
+
+// Object returns the current rolebinding.
+func (it *ListIterator) Object() *rbacv1.RoleBinding {
+	if it.index < 0 || it.index >= len(it.page) {
+		return nil
+	}
+	return it.page[it.index]
+}
+
+// Err returns the error occurred during the iteration.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// pager requests rolebindings from kubernetes API server page by page.
+type pager struct {
+	ctx         context.Context
+	client      typedrbacv1.RoleBindingInterface
+	namespace   string
+	listOptions metav1.ListOptions
+
+	// lastKey is the "namespace/name" of the last rolebinding returned by pager,
+	// the kubernetes API server always returns rolebindings ordered by the key.
+	lastKey string
+	// relist is true after the continue token expired, rolebindings which key
+	// is not greater than lastKey will be skipped.
+	relist bool
+	done   bool
+}
+
+// newPager returns a pager that lists the rolebindings in the handler namespace.
+func (h *Handler) newPager(listOptions metav1.ListOptions) *pager {
+	listOptions.Continue = ""
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = h.Options.ListOptions.Limit
+	}
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = DefaultPageSize
+	}
+	return &pager{
+		ctx:         h.ctx,
+		namespace:   h.namespace,
+		client:      h.clientset.RbacV1().RoleBindings(h.namespace),
+		listOptions: listOptions,
+	}
+}
+
+// next returns the next page of rolebindings.
+func (p *pager) next() ([]*rbacv1.RoleBinding, error) {
+	if p.done {
+		return nil, nil
+	}
+	rbList, err := p.client.List(p.ctx, p.listOptions)
+	if err != nil {
+		// The continue token expired, relist from the beginning.
+		if k8serrors.IsResourceExpired(err) && len(p.listOptions.Continue) != 0 {
+			p.listOptions.Continue = ""
+			p.listOptions.ResourceVersion = ""
+			p.relist = true
+			return nil, nil
+		}
+		return nil, utilerrors.Wrap("list", GVK, p.namespace, "", err)
+	}
+	p.listOptions.Continue = rbList.Continue
+	if len(p.listOptions.Continue) == 0 {
+		p.done = true
+	}
+
+	var page []*rbacv1.RoleBinding
+	for i := range rbList.Items {
+		rb := &rbList.Items[i]
+		key := rb.Namespace + "/" + rb.Name
+		if p.relist && key <= p.lastKey {
+			continue
+		}
+		page = append(page, rb)
+		p.lastKey = key
+	}
+	return page, nil
+}
+
+// forEach calls fn for every page of rolebindings until all rolebindings are listed,
+// it stops and returns the error if fn returns an error.
+func (p *pager) forEach(fn func(page []*rbacv1.RoleBinding) error) error {
+	for {
+		page, err := p.next()
+		if err != nil {
+			return err
+		}
+		if len(page) != 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+		if p.done {
+			return nil
+		}
+	}
+}
+
+// listAll follows the continue tokens to list all rolebindings.
+func (h *Handler) listAll(namespace string, listOptions metav1.ListOptions) ([]*rbacv1.RoleBinding, error) {
+	listOptions.Continue = ""
+	p := &pager{
+		ctx:         h.ctx,
+		namespace:   namespace,
+		client:      h.clientset.RbacV1().RoleBindings(namespace),
+		listOptions: listOptions,
+	}
+
+	var objList []*rbacv1.RoleBinding
+	for !p.done {
+		page, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		objList = append(objList, page...)
+	}
+	return objList, nil
+}
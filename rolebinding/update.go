@@ -1,10 +1,12 @@
 package rolebinding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*rbacv1.RoleBinding,
 
 // updateRolebinding
 func (h *Handler) updateRolebinding(rb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	rb.ResourceVersion = ""
+	rb.UID = ""
+	return h.updateRolebindingWithResourceVersion(rb)
+}
+
+// updateRolebindingWithResourceVersion updates the rolebinding without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the rolebinding has been changed since it was read.
+func (h *Handler) updateRolebindingWithResourceVersion(rb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
 	namespace := rb.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	rb.ResourceVersion = ""
-	rb.UID = ""
-	return h.clientset.RbacV1().RoleBindings(namespace).Update(h.ctx, rb, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: rb.Name, Object: rb}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*rbacv1.RoleBinding, error) {
+		return h.clientset.RbacV1().RoleBindings(namespace).Update(ctx, rb, h.Options.UpdateOptions)
+	})
 }
@@ -1,8 +1,10 @@
 package secret
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*corev1.Secret, error)
 
 // applySecret
 func (h *Handler) applySecret(secret *corev1.Secret) (*corev1.Secret, error) {
-	_, err := h.createSecret(secret)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateSecret(secret)
+	namespace := secret.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return secret, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: secret.Name, Object: secret}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.Secret, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createSecret(secret)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateSecret(secret)
+		}
+		return secret, err
+	})
 }
@@ -1,10 +1,12 @@
 package secret
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createSecret(secret *corev1.Secret) (*corev1.Secret, error) {
 	}
 	secret.ResourceVersion = ""
 	secret.UID = ""
-	return h.clientset.CoreV1().Secrets(namespace).Create(h.ctx, secret, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: secret.Name, Object: secret}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Secret, error) {
+		return h.clientset.CoreV1().Secrets(namespace).Create(ctx, secret, h.Options.CreateOptions)
+	})
 }
@@ -0,0 +1,131 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing secret into it's desired state.
+type MutateFn func(secret *corev1.Secret) error
+
+// CreateOrUpdate gets the secret from the kubernetes API server. If the
+// secret does not exist, it will be created after mutateFn is called.
+// If the secret exists, mutateFn is called on the live secret and the
+// secret will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live secret, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The secret must contain the name and namespace(optional) of the secret,
+// secret will be overwritten by the live secret returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(secret *corev1.Secret, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getSecret(secret)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, secret); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createSecret(secret)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*secret = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*secret = *existing.DeepCopy()
+	if err := mutate(mutateFn, secret); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, secret) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateSecretWithResourceVersion(secret)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*secret = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the secret already exists,
+// only the difference between the live secret and the mutated secret
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(secret *corev1.Secret, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getSecret(secret)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, secret); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createSecret(secret)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*secret = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*secret = *existing.DeepCopy()
+	if err := mutate(mutateFn, secret); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(secret); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, corev1.Secret{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*secret = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, secret *corev1.Secret) error {
+	name, namespace := secret.GetName(), secret.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(secret); err != nil {
+			return err
+		}
+	}
+	if secret.GetName() != name || secret.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate secret name and/or secret namespace")
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package secret
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes secret by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.CoreV1().Secrets(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Secret, error) {
+		return nil, h.clientset.CoreV1().Secrets(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes secret from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteSecret(secret *corev1.Secret) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().Secrets(namespace).Delete(h.ctx, secret.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: secret.Name, Object: secret}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Secret, error) {
+		return nil, h.clientset.CoreV1().Secrets(namespace).Delete(ctx, secret.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
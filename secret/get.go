@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*corev1.Secret, error) {
 
 // GetByName gets secret by name.
 func (h *Handler) GetByName(name string) (*corev1.Secret, error) {
-	return h.clientset.CoreV1().Secrets(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	secret, err := h.clientset.CoreV1().Secrets(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return secret, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets secret from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getSecret(secret *corev1.Secret) (*corev1.Secret, error) {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().Secrets(namespace).Get(h.ctx, secret.Name, h.Options.GetOptions)
+	newSecret, err := h.clientset.CoreV1().Secrets(namespace).Get(h.ctx, secret.Name, h.Options.GetOptions)
+	return newSecret, utilerrors.Wrap("get", GVK, namespace, secret.Name, err)
 }
@@ -1,10 +1,12 @@
 package secret
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*corev1.Secret, error
 
 // updateSecret
 func (h *Handler) updateSecret(secret *corev1.Secret) (*corev1.Secret, error) {
+	secret.ResourceVersion = ""
+	secret.UID = ""
+	return h.updateSecretWithResourceVersion(secret)
+}
+
+// updateSecretWithResourceVersion updates the secret without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the secret has been changed since it was read.
+func (h *Handler) updateSecretWithResourceVersion(secret *corev1.Secret) (*corev1.Secret, error) {
 	namespace := secret.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	secret.ResourceVersion = ""
-	secret.UID = ""
-	return h.clientset.CoreV1().Secrets(namespace).Update(h.ctx, secret, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: secret.Name, Object: secret}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Secret, error) {
+		return h.clientset.CoreV1().Secrets(namespace).Update(ctx, secret, h.Options.UpdateOptions)
+	})
 }
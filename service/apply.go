@@ -1,8 +1,10 @@
 package service
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*corev1.Service, error
 
 // applyService
 func (h *Handler) applyService(svc *corev1.Service) (*corev1.Service, error) {
-	_, err := h.createService(svc)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateService(svc)
+	namespace := svc.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return svc, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: svc.Name, Object: svc}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.Service, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createService(svc)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateService(svc)
+		}
+		return svc, err
+	})
 }
@@ -1,10 +1,12 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createService(svc *corev1.Service) (*corev1.Service, error) {
 	}
 	svc.ResourceVersion = ""
 	svc.UID = ""
-	return h.clientset.CoreV1().Services(namespace).Create(h.ctx, svc, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: svc.Name, Object: svc}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Service, error) {
+		return h.clientset.CoreV1().Services(namespace).Create(ctx, svc, h.Options.CreateOptions)
+	})
 }
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing service into it's desired state.
+type MutateFn func(svc *corev1.Service) error
+
+// CreateOrUpdate gets the service from the kubernetes API server. If the
+// service does not exist, it will be created after mutateFn is called.
+// If the service exists, mutateFn is called on the live service and the
+// service will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live service, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The svc must contain the name and namespace(optional) of the service,
+// svc will be overwritten by the live service returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(svc *corev1.Service, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getService(svc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, svc); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createService(svc)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*svc = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*svc = *existing.DeepCopy()
+	if err := mutate(mutateFn, svc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, svc) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateServiceWithResourceVersion(svc)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*svc = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the service already exists,
+// only the difference between the live service and the mutated service
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(svc *corev1.Service, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getService(svc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, svc); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createService(svc)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*svc = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*svc = *existing.DeepCopy()
+	if err := mutate(mutateFn, svc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(svc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, corev1.Service{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*svc = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, svc *corev1.Service) error {
+	name, namespace := svc.GetName(), svc.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(svc); err != nil {
+			return err
+		}
+	}
+	if svc.GetName() != name || svc.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate service name and/or service namespace")
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*corev1.Service, error) {
 
 // GetByName gets service by name.
 func (h *Handler) GetByName(name string) (*corev1.Service, error) {
-	return h.clientset.CoreV1().Services(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	svc, err := h.clientset.CoreV1().Services(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return svc, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets service from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getService(svc *corev1.Service) (*corev1.Service, error) {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().Services(namespace).Get(h.ctx, svc.Name, h.Options.GetOptions)
+	newSvc, err := h.clientset.CoreV1().Services(namespace).Get(h.ctx, svc.Name, h.Options.GetOptions)
+	return newSvc, utilerrors.Wrap("get", GVK, namespace, svc.Name, err)
 }
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"github.com/forbearing/k8s/util/portforward"
+	"github.com/forbearing/k8s/util/selector"
+)
+
+// ForwardPorts forwards the local ports to a running and ready pod backing the
+// service in background, it returns after the ports are forwarded. The remote
+// ports are the ports or the names of the service ports, they are translated
+// to the target ports of the pod like "kubectl port-forward svc/name", see
+// package util/portforward. The forwarding stops when the handler context is
+// done or the forwarder is closed, eg:
+//
+//	f, err := handler.ForwardPorts("nginx", []string{":80"}, nil)
+//	defer f.Close()
+//	ports, _ := f.Ports()
+func (h *Handler) ForwardPorts(name string, ports []string, opts *portforward.Options) (*portforward.Forwarder, error) {
+	svc, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	podSelector, err := selector.ForObject(svc)
+	if err != nil {
+		return nil, err
+	}
+	target := &portforward.Target{Selector: podSelector, Service: svc}
+	f, err := portforward.Forward(h.ctx, h.config, h.clientset, svc.Namespace, target, ports, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.WaitReady(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -1,10 +1,12 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*corev1.Service, erro
 
 // updateService
 func (h *Handler) updateService(svc *corev1.Service) (*corev1.Service, error) {
+	svc.ResourceVersion = ""
+	svc.UID = ""
+	return h.updateServiceWithResourceVersion(svc)
+}
+
+// updateServiceWithResourceVersion updates the service without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the service has been changed since it was read.
+func (h *Handler) updateServiceWithResourceVersion(svc *corev1.Service) (*corev1.Service, error) {
 	namespace := svc.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	svc.ResourceVersion = ""
-	svc.UID = ""
-	return h.clientset.CoreV1().Services(namespace).Update(h.ctx, svc, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: svc.Name, Object: svc}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.Service, error) {
+		return h.clientset.CoreV1().Services(namespace).Update(ctx, svc, h.Options.UpdateOptions)
+	})
 }
@@ -1,8 +1,10 @@
 package serviceaccount
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*corev1.ServiceAccount
 
 // applySA
 func (h *Handler) applySA(sa *corev1.ServiceAccount) (*corev1.ServiceAccount, error) {
-	_, err := h.createSA(sa)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateSA(sa)
+	namespace := sa.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return sa, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: sa.Name, Object: sa}
+	return h.intercept(op, func(ctx context.Context, _ string) (*corev1.ServiceAccount, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createSA(sa)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateSA(sa)
+		}
+		return sa, err
+	})
 }
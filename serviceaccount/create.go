@@ -1,10 +1,12 @@
 package serviceaccount
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createSA(sa *corev1.ServiceAccount) (*corev1.ServiceAccount, e
 	}
 	sa.ResourceVersion = ""
 	sa.UID = ""
-	return h.clientset.CoreV1().ServiceAccounts(namespace).Create(h.ctx, sa, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: sa.Name, Object: sa}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.ServiceAccount, error) {
+		return h.clientset.CoreV1().ServiceAccounts(namespace).Create(ctx, sa, h.Options.CreateOptions)
+	})
 }
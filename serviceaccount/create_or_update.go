@@ -0,0 +1,131 @@
+package serviceaccount
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing serviceaccount into it's desired state.
+type MutateFn func(sa *corev1.ServiceAccount) error
+
+// CreateOrUpdate gets the serviceaccount from the kubernetes API server. If the
+// serviceaccount does not exist, it will be created after mutateFn is called.
+// If the serviceaccount exists, mutateFn is called on the live serviceaccount and the
+// serviceaccount will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live serviceaccount, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The sa must contain the name and namespace(optional) of the serviceaccount,
+// sa will be overwritten by the live serviceaccount returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(sa *corev1.ServiceAccount, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getSA(sa)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, sa); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createSA(sa)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*sa = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*sa = *existing.DeepCopy()
+	if err := mutate(mutateFn, sa); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, sa) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateSAWithResourceVersion(sa)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*sa = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the serviceaccount already exists,
+// only the difference between the live serviceaccount and the mutated serviceaccount
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(sa *corev1.ServiceAccount, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getSA(sa)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, sa); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createSA(sa)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*sa = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*sa = *existing.DeepCopy()
+	if err := mutate(mutateFn, sa); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(sa); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, corev1.ServiceAccount{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*sa = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, sa *corev1.ServiceAccount) error {
+	name, namespace := sa.GetName(), sa.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(sa); err != nil {
+			return err
+		}
+	}
+	if sa.GetName() != name || sa.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate serviceaccount name and/or serviceaccount namespace")
+	}
+	return nil
+}
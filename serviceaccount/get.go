@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*corev1.ServiceAccount, error) {
 
 // GetByName gets serviceaccount by name.
 func (h *Handler) GetByName(name string) (*corev1.ServiceAccount, error) {
-	return h.clientset.CoreV1().ServiceAccounts(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	sa, err := h.clientset.CoreV1().ServiceAccounts(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return sa, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets serviceaccount from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getSA(sa *corev1.ServiceAccount) (*corev1.ServiceAccount, erro
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.CoreV1().ServiceAccounts(namespace).Get(h.ctx, sa.Name, h.Options.GetOptions)
+	newSA, err := h.clientset.CoreV1().ServiceAccounts(namespace).Get(h.ctx, sa.Name, h.Options.GetOptions)
+	return newSA, utilerrors.Wrap("get", GVK, namespace, sa.Name, err)
 }
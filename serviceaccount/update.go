@@ -1,10 +1,12 @@
 package serviceaccount
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*corev1.ServiceAccoun
 
 // updateSA
 func (h *Handler) updateSA(sa *corev1.ServiceAccount) (*corev1.ServiceAccount, error) {
+	sa.ResourceVersion = ""
+	sa.UID = ""
+	return h.updateSAWithResourceVersion(sa)
+}
+
+// updateSAWithResourceVersion updates the serviceaccount without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the serviceaccount has been changed since it was read.
+func (h *Handler) updateSAWithResourceVersion(sa *corev1.ServiceAccount) (*corev1.ServiceAccount, error) {
 	namespace := sa.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	sa.ResourceVersion = ""
-	sa.UID = ""
-	return h.clientset.CoreV1().ServiceAccounts(namespace).Update(h.ctx, sa, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: sa.Name, Object: sa}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*corev1.ServiceAccount, error) {
+		return h.clientset.CoreV1().ServiceAccounts(namespace).Update(ctx, sa, h.Options.UpdateOptions)
+	})
 }
@@ -1,8 +1,10 @@
 package statefulset
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,18 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*appsv1.StatefulSet, e
 
 // applyStatefulset
 func (h *Handler) applyStatefulset(sts *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
-	_, err := h.createStatefulset(sts)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateStatefulset(sts)
+	namespace := sts.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = h.namespace
 	}
-	return sts, err
+	op := &types.Operation{Verb: types.VerbApply, Namespace: namespace, Name: sts.Name, Object: sts}
+	return h.intercept(op, func(ctx context.Context, _ string) (*appsv1.StatefulSet, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createStatefulset(sts)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateStatefulset(sts)
+		}
+		return sts, err
+	})
 }
@@ -1,10 +1,12 @@
 package statefulset
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -98,5 +100,8 @@ func (h *Handler) createStatefulset(sts *appsv1.StatefulSet) (*appsv1.StatefulSe
 	}
 	sts.ResourceVersion = ""
 	sts.UID = ""
-	return h.clientset.AppsV1().StatefulSets(namespace).Create(h.ctx, sts, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Namespace: namespace, Name: sts.Name, Object: sts}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.StatefulSet, error) {
+		return h.clientset.AppsV1().StatefulSets(namespace).Create(ctx, sts, h.Options.CreateOptions)
+	})
 }
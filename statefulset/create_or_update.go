@@ -0,0 +1,131 @@
+package statefulset
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing statefulset into it's desired state.
+type MutateFn func(sts *appsv1.StatefulSet) error
+
+// CreateOrUpdate gets the statefulset from the kubernetes API server. If the
+// statefulset does not exist, it will be created after mutateFn is called.
+// If the statefulset exists, mutateFn is called on the live statefulset and the
+// statefulset will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live statefulset, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The sts must contain the name and namespace(optional) of the statefulset,
+// sts will be overwritten by the live statefulset returned by the kubernetes
+// API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(sts *appsv1.StatefulSet, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getStatefulset(sts)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, sts); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createStatefulset(sts)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*sts = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*sts = *existing.DeepCopy()
+	if err := mutate(mutateFn, sts); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, sts) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateStatefulsetWithResourceVersion(sts)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*sts = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the statefulset already exists,
+// only the difference between the live statefulset and the mutated statefulset
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(sts *appsv1.StatefulSet, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getStatefulset(sts)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, sts); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createStatefulset(sts)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*sts = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*sts = *existing.DeepCopy()
+	if err := mutate(mutateFn, sts); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(sts); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, appsv1.StatefulSet{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*sts = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, sts *appsv1.StatefulSet) error {
+	name, namespace := sts.GetName(), sts.GetNamespace()
+	if mutateFn != nil {
+		if err := mutateFn(sts); err != nil {
+			return err
+		}
+	}
+	if sts.GetName() != name || sts.GetNamespace() != namespace {
+		return fmt.Errorf("MutateFn cannot mutate statefulset name and/or statefulset namespace")
+	}
+	return nil
+}
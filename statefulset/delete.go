@@ -1,10 +1,12 @@
 package statefulset
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes statefulset by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.AppsV1().StatefulSets(h.namespace).Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: h.namespace, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.StatefulSet, error) {
+		return nil, h.clientset.AppsV1().StatefulSets(namespace).Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes statefulset from yaml or json file.
@@ -104,5 +110,9 @@ func (h *Handler) deleteStatefulset(sts *appsv1.StatefulSet) error {
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().StatefulSets(namespace).Delete(h.ctx, sts.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Namespace: namespace, Name: sts.Name, Object: sts}
+	_, err := h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.StatefulSet, error) {
+		return nil, h.clientset.AppsV1().StatefulSets(namespace).Delete(ctx, sts.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -0,0 +1,24 @@
+package statefulset
+
+import (
+	utilexec "github.com/forbearing/k8s/util/exec"
+	"github.com/forbearing/k8s/util/selector"
+)
+
+// ExecAll executes the command in all running pods of the statefulset
+// concurrently and captures the stdout, stderr and exit code of every pod, see
+// package util/exec. The results are sorted by pod name and the error of every
+// pod is returned by Result.Err, eg:
+//
+//	results, err := handler.ExecAll(name, []string{"cat", "/etc/hostname"}, &utilexec.Options{Concurrency: 5})
+func (h *Handler) ExecAll(name string, command []string, opts *utilexec.Options) ([]*utilexec.Result, error) {
+	sts, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	podSelector, err := selector.ForObject(sts)
+	if err != nil {
+		return nil, err
+	}
+	return utilexec.ExecAll(h.ctx, h.config, h.clientset, sts.Namespace, podSelector, command, opts)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*appsv1.StatefulSet, error) {
 
 // GetByName gets statefulset by name.
 func (h *Handler) GetByName(name string) (*appsv1.StatefulSet, error) {
-	return h.clientset.AppsV1().StatefulSets(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	sts, err := h.clientset.AppsV1().StatefulSets(h.namespace).Get(h.ctx, name, h.Options.GetOptions)
+	return sts, utilerrors.Wrap("get", GVK, h.namespace, name, err)
 }
 
 // GetFromFile gets statefulset from yaml or json file.
@@ -106,5 +108,6 @@ func (h *Handler) getStatefulset(sts *appsv1.StatefulSet) (*appsv1.StatefulSet,
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	return h.clientset.AppsV1().StatefulSets(namespace).Get(h.ctx, sts.Name, h.Options.GetOptions)
+	newSTS, err := h.clientset.AppsV1().StatefulSets(namespace).Get(h.ctx, sts.Name, h.Options.GetOptions)
+	return newSTS, utilerrors.Wrap("get", GVK, namespace, sts.Name, err)
 }
@@ -0,0 +1,294 @@
+package statefulset
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/forbearing/k8s/util/podtemplate"
+	utilwait "github.com/forbearing/k8s/util/wait"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ChangeCauseAnnotation records the change cause of the revision.
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+	// RestartedAtAnnotation is the pod template annotation set by Restart,
+	// it's the same as "kubectl rollout restart".
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// Revision is a revision of the statefulset rollout history, it's recorded
+// by the ControllerRevision owned by the statefulset.
+type Revision struct {
+	Revision    int64
+	ChangeCause string
+	// ControllerRevision is the name of the ControllerRevision of the revision.
+	ControllerRevision string
+	Template           corev1.PodTemplateSpec
+
+	// data is the patch stored in the ControllerRevision, undo applies it.
+	data []byte
+}
+
+// RolloutHistory returns the rollout history of the statefulset sorted by
+// revision, it works like "kubectl rollout history statefulset/name".
+func (h *Handler) RolloutHistory(name string) ([]Revision, error) {
+	sts, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return h.rolloutHistory(sts)
+}
+
+func (h *Handler) rolloutHistory(sts *appsv1.StatefulSet) ([]Revision, error) {
+	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	crList, err := h.clientset.AppsV1().ControllerRevisions(sts.Namespace).List(h.ctx,
+		metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	var revisions []Revision
+	for i := range crList.Items {
+		cr := &crList.Items[i]
+		if owner := metav1.GetControllerOf(cr); owner == nil || owner.UID != sts.UID {
+			continue
+		}
+		// the data of the ControllerRevision is a strategic merge patch that
+		// replaces the pod template of the statefulset.
+		patch := &struct {
+			Spec struct {
+				Template corev1.PodTemplateSpec `json:"template"`
+			} `json:"spec"`
+		}{}
+		if err := json.Unmarshal(cr.Data.Raw, patch); err != nil {
+			return nil, fmt.Errorf("controllerrevision %q: %w", cr.Name, err)
+		}
+		revisions = append(revisions, Revision{
+			Revision:           cr.Revision,
+			ChangeCause:        cr.Annotations[ChangeCauseAnnotation],
+			ControllerRevision: cr.Name,
+			Template:           patch.Spec.Template,
+			data:               cr.Data.Raw,
+		})
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision < revisions[j].Revision })
+	return revisions, nil
+}
+
+// RolloutDiff returns what changed in the pod template between the two
+// revisions, zero revision means the latest revision, eg:
+//
+//	diff, err := handler.RolloutDiff("web", 1, 0)
+func (h *Handler) RolloutDiff(name string, from, to int64) (*podtemplate.Diff, error) {
+	revisions, err := h.RolloutHistory(name)
+	if err != nil {
+		return nil, err
+	}
+	fromRevision, err := findRevision(revisions, from)
+	if err != nil {
+		return nil, fmt.Errorf("statefulset %q: %w", name, err)
+	}
+	toRevision, err := findRevision(revisions, to)
+	if err != nil {
+		return nil, fmt.Errorf("statefulset %q: %w", name, err)
+	}
+	return podtemplate.Compare(&fromRevision.Template, &toRevision.Template), nil
+}
+
+// RolloutUndo rolls back the statefulset to the revision, zero revision means
+// the previous revision. It works like "kubectl rollout undo statefulset/name
+// --to-revision=revision". The statefulset is returned unchanged if the pod
+// template of the revision is the same as the current one.
+func (h *Handler) RolloutUndo(name string, revision int64) (*appsv1.StatefulSet, error) {
+	sts, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := h.rolloutHistory(sts)
+	if err != nil {
+		return nil, err
+	}
+	if revision == 0 {
+		// the previous revision is the one before the latest revision.
+		if len(revisions) < 2 {
+			return nil, fmt.Errorf("statefulset %q: no rollout history found", name)
+		}
+		revision = revisions[len(revisions)-2].Revision
+	}
+	target, err := findRevision(revisions, revision)
+	if err != nil {
+		return nil, fmt.Errorf("statefulset %q: %w", name, err)
+	}
+	if apiequality.Semantic.DeepEqual(&sts.Spec.Template, &target.Template) {
+		return sts, nil
+	}
+	return h.Patch(sts, target.data, types.StrategicMergePatchType)
+}
+
+// findRevision returns the revision, zero revision means the latest revision.
+func findRevision(revisions []Revision, revision int64) (*Revision, error) {
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("no rollout history found")
+	}
+	if revision == 0 {
+		return &revisions[len(revisions)-1], nil
+	}
+	for i := range revisions {
+		if revisions[i].Revision == revision {
+			return &revisions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find the specified revision %d", revision)
+}
+
+// Restart restarts the pods of the statefulset by setting the restartedAt
+// annotation of the pod template, it works like "kubectl rollout restart".
+func (h *Handler) Restart(name string) (*appsv1.StatefulSet, error) {
+	sts, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return h.patch(sts, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{RestartedAtAnnotation: time.Now().Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+}
+
+// SetPartition sets the partition of the RollingUpdate strategy, only the pods
+// whose ordinal is greater than or equal to the partition are updated.
+func (h *Handler) SetPartition(name string, partition int32) (*appsv1.StatefulSet, error) {
+	sts, err := h.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return nil, fmt.Errorf("statefulset %q: partition is not supported by the OnDelete update strategy", name)
+	}
+	return h.patch(sts, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"updateStrategy": map[string]interface{}{
+				"type":          appsv1.RollingUpdateStatefulSetStrategyType,
+				"rollingUpdate": map[string]interface{}{"partition": partition},
+			},
+		},
+	})
+}
+
+// RolloutPartitioned rolls out the statefulset step by step, it steps the
+// partition down by step until zero, and waits for the updated pods to be
+// ready between the steps, eg:
+//
+//	err := handler.WithContext(ctx).RolloutPartitioned("web", 1, utilwait.WithTimeout(5*time.Minute))
+//
+// It starts from the current partition, set the partition to the number of
+// replicas by SetPartition before changing the pod template to canary the update.
+//
+// The opts is applied to the wait of every step, so utilwait.WithTimeout limits
+// a single step rather than the whole rollout, use WithContext() with a deadline
+// to limit the whole rollout. The OnDelete update strategy is rejected, the pods
+// are not updated until they are deleted, the wait would block until timeout.
+func (h *Handler) RolloutPartitioned(name string, step int32, opts ...utilwait.Option) error {
+	if step <= 0 {
+		return fmt.Errorf("invalid partition step %d, it must be positive", step)
+	}
+	sts, err := h.Get(name)
+	if err != nil {
+		return err
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return fmt.Errorf("statefulset %q: partitioned rollout is not supported by the OnDelete update strategy", name)
+	}
+	partition := int32(0)
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		partition = *ru.Partition
+	}
+	return stepPartition(partition, step,
+		func(partition int32) error { return h.waitPartition(name, partition, opts...) },
+		func(partition int32) error {
+			_, err := h.SetPartition(name, partition)
+			return err
+		})
+}
+
+// stepPartition waits for the partition, then steps the partition down by step
+// and waits again until the partition is zero.
+func stepPartition(partition, step int32, wait, set func(partition int32) error) error {
+	for {
+		if err := wait(partition); err != nil {
+			return err
+		}
+		if partition == 0 {
+			return nil
+		}
+		if partition -= step; partition < 0 {
+			partition = 0
+		}
+		if err := set(partition); err != nil {
+			return err
+		}
+	}
+}
+
+// waitPartition waits for the pods whose ordinal is greater than or equal to
+// the partition to be updated and all pods to be ready.
+func (h *Handler) waitPartition(name string, partition int32, opts ...utilwait.Option) error {
+	cond := utilwait.Condition{Name: fmt.Sprintf("partition=%d", partition), Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		sts := &appsv1.StatefulSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), sts); err != nil {
+			return false, "", err
+		}
+		return partitionReadiness(sts, partition)
+	}}
+	return h.waitFor(utilwait.Target{Name: name}, cond, opts...)
+}
+
+// partitionReadiness returns whether the pods whose ordinal is greater than or
+// equal to the partition are updated and all pods are ready, and the reason if not.
+func partitionReadiness(sts *appsv1.StatefulSet, partition int32) (bool, string, error) {
+	if partition == 0 {
+		return readiness(sts)
+	}
+	if sts.Generation != sts.Status.ObservedGeneration {
+		return false, "waiting for the statefulset spec update to be observed", nil
+	}
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	expected := replicas - partition
+	if expected < 0 {
+		expected = 0
+	}
+	switch {
+	case sts.Status.UpdatedReplicas < expected:
+		return false, fmt.Sprintf("%d/%d replicas updated", sts.Status.UpdatedReplicas, expected), nil
+	case sts.Status.ReadyReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas ready", sts.Status.ReadyReplicas, replicas), nil
+	}
+	return true, "", nil
+}
+
+// patch marshals the patch and patches the statefulset by strategic merge patch.
+func (h *Handler) patch(sts *appsv1.StatefulSet, patch interface{}) (*appsv1.StatefulSet, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return h.Patch(sts, data, types.StrategicMergePatchType)
+}
@@ -0,0 +1,224 @@
+package statefulset
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/forbearing/k8s/types"
+	utilwait "github.com/forbearing/k8s/util/wait"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+// newStatefulSet returns the statefulset whose pods with ordinal greater than
+// or equal to the partition are updated and all pods are ready.
+func newStatefulSet(replicas, partition int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test", Generation: 1},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 1,
+			Replicas:           replicas,
+			CurrentReplicas:    replicas,
+			UpdatedReplicas:    replicas - partition,
+			ReadyReplicas:      replicas,
+			AvailableReplicas:  replicas,
+			CurrentRevision:    "web-1",
+			UpdateRevision:     "web-1",
+		},
+	}
+}
+
+func toUnstructured(t *testing.T, sts *appsv1.StatefulSet) *unstructured.Unstructured {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(sts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func TestPartitionReadiness(t *testing.T) {
+	tests := []struct {
+		name      string
+		sts       *appsv1.StatefulSet
+		partition int32
+		ready     bool
+		reason    string
+	}{
+		{
+			name:      "updated",
+			sts:       newStatefulSet(5, 3),
+			partition: 3,
+			ready:     true,
+		},
+		{
+			name: "not updated",
+			sts: func() *appsv1.StatefulSet {
+				sts := newStatefulSet(5, 3)
+				sts.Status.UpdatedReplicas = 1
+				return sts
+			}(),
+			partition: 3,
+			reason:    "1/2 replicas updated",
+		},
+		{
+			name: "not ready",
+			sts: func() *appsv1.StatefulSet {
+				sts := newStatefulSet(5, 3)
+				sts.Status.ReadyReplicas = 4
+				return sts
+			}(),
+			partition: 3,
+			reason:    "4/5 replicas ready",
+		},
+		{
+			name: "spec update not observed",
+			sts: func() *appsv1.StatefulSet {
+				sts := newStatefulSet(5, 3)
+				sts.Generation = 2
+				return sts
+			}(),
+			partition: 3,
+			reason:    "waiting for the statefulset spec update to be observed",
+		},
+		{
+			name:      "partition greater than replicas",
+			sts:       newStatefulSet(3, 3),
+			partition: 5,
+			ready:     true,
+		},
+		{
+			name: "zero partition waits for the rollout",
+			sts: func() *appsv1.StatefulSet {
+				sts := newStatefulSet(5, 0)
+				sts.Status.UpdateRevision = "web-2"
+				return sts
+			}(),
+			reason: "waiting for revision web-2 to be current, current revision is web-1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ready, reason, err := partitionReadiness(test.sts, test.partition)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ready != test.ready || reason != test.reason {
+				t.Errorf("expected ready %t with reason %q, got %t with reason %q", test.ready, test.reason, ready, reason)
+			}
+		})
+	}
+}
+
+func TestStepPartition(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}
+	tests := []struct {
+		name      string
+		replicas  int32
+		partition int32
+		step      int32
+		// rollout is false if the pods are never updated after the partition
+		// changed, the wait of the step times out.
+		rollout bool
+		waits   []int32
+		sets    []int32
+		timeout bool
+	}{
+		{
+			name:      "step down to zero",
+			replicas:  5,
+			partition: 5,
+			step:      2,
+			rollout:   true,
+			waits:     []int32{5, 3, 1, 0},
+			sets:      []int32{3, 1, 0},
+		},
+		{
+			name:      "step greater than partition",
+			replicas:  3,
+			partition: 2,
+			step:      5,
+			rollout:   true,
+			waits:     []int32{2, 0},
+			sets:      []int32{0},
+		},
+		{
+			name:     "zero partition",
+			replicas: 3,
+			step:     1,
+			rollout:  true,
+			waits:    []int32{0},
+		},
+		{
+			name:      "step times out",
+			replicas:  3,
+			partition: 3,
+			step:      1,
+			waits:     []int32{3, 2},
+			sets:      []int32{2},
+			timeout:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{gvr: "StatefulSetList"},
+				toUnstructured(t, newStatefulSet(test.replicas, test.partition)))
+			handler := &Handler{
+				ctx:           context.TODO(),
+				namespace:     "test",
+				observer:      types.NopObserver{},
+				dynamicClient: client,
+				Options:       &types.HandlerOptions{},
+			}
+
+			var waits, sets []int32
+			wait := func(partition int32) error {
+				waits = append(waits, partition)
+				return handler.waitPartition("web", partition, utilwait.WithTimeout(200*time.Millisecond))
+			}
+			// set changes the partition and simulates the statefulset controller.
+			set := func(partition int32) error {
+				sets = append(sets, partition)
+				sts := newStatefulSet(test.replicas, partition)
+				if !test.rollout {
+					sts.Status.UpdatedReplicas = test.replicas - test.partition
+				}
+				_, err := client.Resource(gvr).Namespace("test").Update(context.TODO(), toUnstructured(t, sts), metav1.UpdateOptions{})
+				return err
+			}
+
+			err := stepPartition(test.partition, test.step, wait, set)
+			var timeoutErr *utilwait.TimeoutError
+			if test.timeout {
+				if !errors.As(err, &timeoutErr) {
+					t.Fatalf("expected *utilwait.TimeoutError, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(waits, test.waits) {
+				t.Errorf("expected waits for partitions %v, got %v", test.waits, waits)
+			}
+			if !reflect.DeepEqual(sets, test.sets) {
+				t.Errorf("expected partitions set %v, got %v", test.sets, sets)
+			}
+		})
+	}
+}
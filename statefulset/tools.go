@@ -1,152 +1,80 @@
 package statefulset
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
-	log "github.com/sirupsen/logrus"
+	utilwait "github.com/forbearing/k8s/util/wait"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // IsReady check if the statefulset is ready.
-// ref: https://github.com/kubernetes/kubernetes/blob/a1128e380c2cf1c2d7443694673d9f1dd63eb518/staging/src/k8s.io/kubectl/pkg/polymorphichelpers/rollout_status.go#L120
 func (h *Handler) IsReady(name string) bool {
-	checkGeneration := func(sts *appsv1.StatefulSet) bool {
-		if sts.Generation != sts.Status.ObservedGeneration {
-			return false
-		}
-		return true
-	}
-	checkReplicas := func(sts *appsv1.StatefulSet) bool {
-		if sts.Spec.Replicas == nil {
-			return false
-		}
-		if *sts.Spec.Replicas != sts.Status.ReadyReplicas {
-			return false
-		}
-		if *sts.Spec.Replicas != sts.Status.AvailableReplicas {
-			return false
-		}
-		if *sts.Spec.Replicas != sts.Status.CurrentReplicas {
-			return false
-		}
-		if *sts.Spec.Replicas != sts.Status.UpdatedReplicas {
-			return false
-		}
-		if *sts.Spec.Replicas != sts.Status.Replicas {
-			return false
-		}
-		return true
-	}
-	checkRevision := func(sts *appsv1.StatefulSet) bool {
-		if sts.Status.UpdateRevision != sts.Status.CurrentRevision {
-			return false
-		}
-		return true
-	}
-
 	sts, err := h.Get(name)
 	if err != nil {
 		return false
 	}
-	if checkGeneration(sts) && checkReplicas(sts) && checkRevision(sts) {
-		return true
-	}
-	return false
+	ready, _, _ := readiness(sts)
+	return ready
 }
 
-// WaitReady waiting for the statefulset to be in the ready status.
-func (h *Handler) WaitReady(name string) error {
-	if h.IsReady(name) {
-		return nil
+// WaitReady waits for the statefulset to be in the ready status. It watches the
+// statefulset and honours the handler context, use WithContext() to pass the
+// per-call context, and utilwait.WithTimeout/WithProgress to set the timeout
+// and to report why the statefulset is not ready yet, eg:
+//
+//	err := handler.WithContext(ctx).WaitReady(name, utilwait.WithTimeout(5*time.Minute))
+//
+// WaitReady returns the NotFound error immediately if the statefulset doesn't
+// exist. Without utilwait.WithTimeout it waits until the handler context is
+// done, so always pass a timeout unless the context has a deadline.
+//
+// The *utilwait.TimeoutError returned contains the reason why the statefulset is
+// not ready.
+func (h *Handler) WaitReady(name string, opts ...utilwait.Option) error {
+	// the wait by name waits for the statefulset to be created, get it first so a
+	// missing statefulset doesn't block until timeout.
+	if _, err := h.Get(name); err != nil {
+		return err
 	}
-
-	errCh := make(chan error, 1)
-	chkCh := make(chan struct{}, 1)
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL, syscall.SIGQUIT)
-	ctxCheck, cancelCheck := context.WithCancel(h.ctx)
-	ctxWatch, cancelWatch := context.WithCancel(h.ctx)
-	defer cancelCheck()
-	defer cancelWatch()
-
-	// this goroutine used to check whether statefulset is ready and exists.
-	// if statefulset already ready, return nil.
-	// if statefulset does not exist, return error.
-	// if statefulset exists but not ready, return nothing.
-	go func(context.Context) {
-		<-chkCh
-		for i := 0; i < 6; i++ {
-			// statefulset is already ready, return nil
-			if h.IsReady(name) {
-				errCh <- nil
-				return
-			}
-			// statefulset no longer exists, return err
-			_, err := h.Get(name)
-			if k8serrors.IsNotFound(err) {
-				errCh <- err
-				return
-			}
-			// statefulset exists but not ready, return nothing.
-			if err == nil {
-				return
-			}
-			log.Error(err)
-			time.Sleep(time.Second * 10)
+	cond := utilwait.Condition{Name: "ready", Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		sts := &appsv1.StatefulSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), sts); err != nil {
+			return false, "", err
 		}
-	}(ctxCheck)
-
-	// this goroutine used to watch statefulset.
-	go func(ctx context.Context) {
-		for {
-			timeout := int64(0)
-			listOptions := metav1.SingleObject(metav1.ObjectMeta{Name: name, Namespace: h.namespace})
-			listOptions.TimeoutSeconds = &timeout
-			watcher, err := h.clientset.AppsV1().StatefulSets(h.namespace).Watch(h.ctx, listOptions)
-			if err != nil {
-				errCh <- err
-				return
-			}
-			chkCh <- struct{}{}
-			for event := range watcher.ResultChan() {
-				switch event.Type {
-				case watch.Modified:
-					if h.IsReady(name) {
-						watcher.Stop()
-						errCh <- nil
-						return
-					}
-				case watch.Deleted:
-					watcher.Stop()
-					errCh <- fmt.Errorf("statefulset/%s was deleted", name)
-					return
-				case watch.Bookmark:
-					log.Debug("watch statefulset: bookmark")
-				case watch.Error:
-					log.Debug("watch statefulset: error")
-				}
-			}
-			// If event channel is closed, it means the kube-apiserver has closed the connection.
-			log.Debug("watch statefulset: reconnect to kubernetes")
-			watcher.Stop()
-		}
-	}(ctxWatch)
+		return readiness(sts)
+	}}
+	return h.waitFor(utilwait.Target{Name: name}, cond, opts...)
+}
 
-	select {
-	case sig := <-sigCh:
-		return fmt.Errorf("cancelled by signal: %s", sig.String())
-	case err := <-errCh:
-		return err
+// readiness returns whether the statefulset is ready and the reason if it's not.
+func readiness(sts *appsv1.StatefulSet) (bool, string, error) {
+	if sts.Generation != sts.Status.ObservedGeneration {
+		return false, "waiting for the statefulset spec update to be observed", nil
+	}
+	if sts.Spec.Replicas == nil {
+		return false, "spec.replicas is not set", nil
+	}
+	replicas := *sts.Spec.Replicas
+	switch {
+	case sts.Status.UpdatedReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas updated", sts.Status.UpdatedReplicas, replicas), nil
+	case sts.Status.CurrentReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas current", sts.Status.CurrentReplicas, replicas), nil
+	case sts.Status.Replicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas created", sts.Status.Replicas, replicas), nil
+	case sts.Status.ReadyReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas ready", sts.Status.ReadyReplicas, replicas), nil
+	case sts.Status.AvailableReplicas != replicas:
+		return false, fmt.Sprintf("%d/%d replicas available", sts.Status.AvailableReplicas, replicas), nil
+	case sts.Status.UpdateRevision != sts.Status.CurrentRevision:
+		return false, fmt.Sprintf("waiting for revision %s to be current, current revision is %s",
+			sts.Status.UpdateRevision, sts.Status.CurrentRevision), nil
 	}
+	return true, "", nil
 }
 
 //// WaitReady wait the statefulset to be in the ready status.
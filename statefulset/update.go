@@ -1,10 +1,12 @@
 package statefulset
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -92,11 +94,21 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*appsv1.StatefulSet,
 
 // updateStatefulset
 func (h *Handler) updateStatefulset(sts *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+	sts.ResourceVersion = ""
+	sts.UID = ""
+	return h.updateStatefulsetWithResourceVersion(sts)
+}
+
+// updateStatefulsetWithResourceVersion updates the statefulset without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the statefulset has been changed since it was read.
+func (h *Handler) updateStatefulsetWithResourceVersion(sts *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
 	namespace := sts.GetNamespace()
 	if len(namespace) == 0 {
 		namespace = h.namespace
 	}
-	sts.ResourceVersion = ""
-	sts.UID = ""
-	return h.clientset.AppsV1().StatefulSets(namespace).Update(h.ctx, sts, h.Options.UpdateOptions)
+	op := &types.Operation{Verb: types.VerbUpdate, Namespace: namespace, Name: sts.Name, Object: sts}
+	return h.intercept(op, func(ctx context.Context, namespace string) (*appsv1.StatefulSet, error) {
+		return h.clientset.AppsV1().StatefulSets(namespace).Update(ctx, sts, h.Options.UpdateOptions)
+	})
 }
@@ -1,8 +1,10 @@
 package storageclass
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/forbearing/k8s/types"
 	storagev1 "k8s.io/api/storage/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,9 +87,14 @@ func (h *Handler) ApplyFromMap(u map[string]interface{}) (*storagev1.StorageClas
 
 // applySC
 func (h *Handler) applySC(sc *storagev1.StorageClass) (*storagev1.StorageClass, error) {
-	_, err := h.createSC(sc)
-	if k8serrors.IsAlreadyExists(err) {
-		return h.updateSC(sc)
-	}
-	return sc, err
+	op := &types.Operation{Verb: types.VerbApply, Name: sc.Name, Object: sc}
+	return h.intercept(op, func(ctx context.Context, _ string) (*storagev1.StorageClass, error) {
+		// create and update are parts of apply, don't run the interceptors again.
+		handler := h.withoutInterceptors(ctx)
+		_, err := handler.createSC(sc)
+		if k8serrors.IsAlreadyExists(err) {
+			return handler.updateSC(sc)
+		}
+		return sc, err
+	})
 }
@@ -1,10 +1,12 @@
 package storageclass
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,8 @@ func (h *Handler) CreateFromMap(u map[string]interface{}) (*storagev1.StorageCla
 func (h *Handler) createSC(sc *storagev1.StorageClass) (*storagev1.StorageClass, error) {
 	sc.ResourceVersion = ""
 	sc.UID = ""
-	return h.clientset.StorageV1().StorageClasses().Create(h.ctx, sc, h.Options.CreateOptions)
+	op := &types.Operation{Verb: types.VerbCreate, Name: sc.Name, Object: sc}
+	return h.intercept(op, func(ctx context.Context, _ string) (*storagev1.StorageClass, error) {
+		return h.clientset.StorageV1().StorageClasses().Create(ctx, sc, h.Options.CreateOptions)
+	})
 }
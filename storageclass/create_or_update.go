@@ -0,0 +1,130 @@
+package storageclass
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+/*
+reference:
+	https://github.com/kubernetes-sigs/controller-runtime/blob/master/pkg/controller/controllerutil/controllerutil.go
+*/
+
+// MutateFn is a function which mutates the existing storageclass into it's desired state.
+type MutateFn func(sc *storagev1.StorageClass) error
+
+// CreateOrUpdate gets the storageclass from the kubernetes API server. If the
+// storageclass does not exist, it will be created after mutateFn is called.
+// If the storageclass exists, mutateFn is called on the live storageclass and the
+// storageclass will be updated only when mutateFn changed it. The update carries the
+// resourceVersion of the live storageclass, so a concurrent write is not overwritten,
+// a Conflict error is returned instead.
+//
+// The sc must contain the name of the storageclass, sc will be overwritten
+// by the live storageclass returned by the kubernetes API server.
+//
+// It returns the executed operation and an error.
+func (h *Handler) CreateOrUpdate(sc *storagev1.StorageClass, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getSC(sc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, sc); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createSC(sc)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*sc = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*sc = *existing.DeepCopy()
+	if err := mutate(mutateFn, sc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if equality.Semantic.DeepEqual(existing, sc) {
+		return types.OperationResultUnchanged, nil
+	}
+	updated, err := h.updateSCWithResourceVersion(sc)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*sc = *updated
+	return types.OperationResultUpdated, nil
+}
+
+// CreateOrPatch works like CreateOrUpdate, but if the storageclass already exists,
+// only the difference between the live storageclass and the mutated storageclass
+// will be sent to the kubernetes API server by "Strategic Merge Patch".
+//
+// Note: CreateOrPatch only patch the main resource, changes made by mutateFn
+// to the "status" subresource are ignored by the kubernetes API server.
+func (h *Handler) CreateOrPatch(sc *storagev1.StorageClass, mutateFn MutateFn) (types.OperationResult, error) {
+	existing, err := h.getSC(sc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return types.OperationResultUnchanged, err
+		}
+		if err := mutate(mutateFn, sc); err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		created, err := h.createSC(sc)
+		if err != nil {
+			return types.OperationResultUnchanged, err
+		}
+		*sc = *created
+		return types.OperationResultCreated, nil
+	}
+
+	*sc = *existing.DeepCopy()
+	if err := mutate(mutateFn, sc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+
+	var (
+		existingJson []byte
+		modifiedJson []byte
+		patchData    []byte
+	)
+	if existingJson, err = json.Marshal(existing); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if modifiedJson, err = json.Marshal(sc); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if patchData, err = strategicpatch.CreateTwoWayMergePatch(existingJson, modifiedJson, storagev1.StorageClass{}); err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	if len(patchData) == 0 || string(patchData) == "{}" {
+		return types.OperationResultUnchanged, nil
+	}
+	patched, err := h.strategicMergePatch(existing, patchData)
+	if err != nil {
+		return types.OperationResultUnchanged, err
+	}
+	*sc = *patched
+	return types.OperationResultUpdated, nil
+}
+
+// mutate wraps a MutateFn and applies validation to its result.
+func mutate(mutateFn MutateFn, sc *storagev1.StorageClass) error {
+	name := sc.GetName()
+	if mutateFn != nil {
+		if err := mutateFn(sc); err != nil {
+			return err
+		}
+	}
+	if sc.GetName() != name {
+		return fmt.Errorf("MutateFn cannot mutate storageclass name")
+	}
+	return nil
+}
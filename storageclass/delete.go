@@ -1,10 +1,12 @@
 package storageclass
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +45,11 @@ func (h *Handler) Delete(obj interface{}) error {
 
 // DeleteByName deletes storageclass by name.
 func (h *Handler) DeleteByName(name string) error {
-	return h.clientset.StorageV1().StorageClasses().Delete(h.ctx, name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: name}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*storagev1.StorageClass, error) {
+		return nil, h.clientset.StorageV1().StorageClasses().Delete(ctx, name, h.Options.DeleteOptions)
+	})
+	return err
 }
 
 // DeleteFromFile deletes storageclass from yaml or json file.
@@ -100,5 +106,9 @@ func (h *Handler) DeleteFromMap(u map[string]interface{}) error {
 
 // deleteSC
 func (h *Handler) deleteSC(sc *storagev1.StorageClass) error {
-	return h.clientset.StorageV1().StorageClasses().Delete(h.ctx, sc.Name, h.Options.DeleteOptions)
+	op := &types.Operation{Verb: types.VerbDelete, Name: sc.Name, Object: sc}
+	_, err := h.intercept(op, func(ctx context.Context, _ string) (*storagev1.StorageClass, error) {
+		return nil, h.clientset.StorageV1().StorageClasses().Delete(ctx, sc.Name, h.Options.DeleteOptions)
+	})
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	utilerrors "github.com/forbearing/k8s/util/errors"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,7 +44,8 @@ func (h *Handler) Get(obj interface{}) (*storagev1.StorageClass, error) {
 
 // GetByName gets storageclass by name.
 func (h *Handler) GetByName(name string) (*storagev1.StorageClass, error) {
-	return h.clientset.StorageV1().StorageClasses().Get(h.ctx, name, h.Options.GetOptions)
+	sc, err := h.clientset.StorageV1().StorageClasses().Get(h.ctx, name, h.Options.GetOptions)
+	return sc, utilerrors.Wrap("get", GVK, "", name, err)
 }
 
 // GetFromFile gets storageclass from yaml or json file.
@@ -102,5 +104,6 @@ func (h *Handler) GetFromMap(u map[string]interface{}) (*storagev1.StorageClass,
 // It's necessary to get a new storageclass resource from a old storageclass resource,
 // because old storageclass usually don't have storageclass.Status field.
 func (h *Handler) getSC(sc *storagev1.StorageClass) (*storagev1.StorageClass, error) {
-	return h.clientset.StorageV1().StorageClasses().Get(h.ctx, sc.Name, h.Options.GetOptions)
+	newSC, err := h.clientset.StorageV1().StorageClasses().Get(h.ctx, sc.Name, h.Options.GetOptions)
+	return newSC, utilerrors.Wrap("get", GVK, "", sc.Name, err)
 }
@@ -1,6 +1,7 @@
 package storageclass
 
 import (
+	"github.com/forbearing/k8s/util/selector"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/fields"
 )
@@ -14,13 +15,12 @@ func (h *Handler) List() ([]*storagev1.StorageClass, error) {
 // Multiple labels separated by comma(",") eg: "name=myapp,role=devops",
 // and there is an "And" relationship between multiple labels.
 func (h *Handler) ListByLabel(labels string) ([]*storagev1.StorageClass, error) {
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.LabelSelector = labels
-	scList, err := h.clientset.StorageV1().StorageClasses().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateLabelSelector(labels); err != nil {
 		return nil, err
 	}
-	return extractList(scList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.LabelSelector = labels
+	return h.listAll(*listOptions)
 }
 
 // ListByField list storageclasses by field, work like `kubectl get xxx --field-selector=xxx`.
@@ -29,26 +29,15 @@ func (h *Handler) ListByField(field string) ([]*storagev1.StorageClass, error) {
 	if err != nil {
 		return nil, err
 	}
-	listOptions := h.Options.ListOptions.DeepCopy()
-	listOptions.FieldSelector = fieldSelector.String()
-
-	scList, err := h.clientset.StorageV1().StorageClasses().List(h.ctx, *listOptions)
-	if err != nil {
+	if err := selector.ValidateFieldSelector(Kind, field); err != nil {
 		return nil, err
 	}
-	return extractList(scList), nil
+	listOptions := h.Options.ListOptions.DeepCopy()
+	listOptions.FieldSelector = fieldSelector.String()
+	return h.listAll(*listOptions)
 }
 
 // ListAll list all storageclasses in the k8s cluster.
 func (h *Handler) ListAll() ([]*storagev1.StorageClass, error) {
 	return h.ListByLabel("")
 }
-
-// extractList
-func extractList(scList *storagev1.StorageClassList) []*storagev1.StorageClass {
-	var objList []*storagev1.StorageClass
-	for i := range scList.Items {
-		objList = append(objList, &scList.Items[i])
-	}
-	return objList
-}
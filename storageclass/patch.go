@@ -1,6 +1,7 @@
 package storageclass
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -112,8 +113,10 @@ func (h *Handler) strategicMergePatch(original *storagev1.StorageClass, patchDat
 	if len(patchData) == 0 || string(patchData) == "{}" {
 		return original, nil
 	}
-	return h.clientset.StorageV1().StorageClasses().
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch("", original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, _ string) (*storagev1.StorageClass, error) {
+		return h.clientset.StorageV1().StorageClasses().
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonMergePatch use the "JSON Merge Patch" patch type to patch storageclass.
@@ -130,8 +133,10 @@ func (h *Handler) jsonMergePatch(original *storagev1.StorageClass, patchData []b
 	if len(patchData) == 0 || string(patchData) == "{}" {
 		return original, nil
 	}
-	return h.clientset.StorageV1().StorageClasses().
-		Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch("", original.Name, types.MergePatchType, patchData, func(ctx context.Context, _ string) (*storagev1.StorageClass, error) {
+		return h.clientset.StorageV1().StorageClasses().
+			Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // jsonPatch use "JSON Patch" patch type to patch storageclass.
@@ -142,8 +147,10 @@ func (h *Handler) jsonMergePatch(original *storagev1.StorageClass, patchData []b
 //     https://kubernetes.io/docs/tasks/manage-kubernetes-objects/update-api-object-kubectl-patch/#before-you-begin
 //     https://tools.ietf.org/html/rfc7386
 func (h *Handler) jsonPatch(original *storagev1.StorageClass, patchData []byte) (*storagev1.StorageClass, error) {
-	return h.clientset.StorageV1().StorageClasses().Patch(h.ctx,
-		original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch("", original.Name, types.JSONPatchType, patchData, func(ctx context.Context, _ string) (*storagev1.StorageClass, error) {
+		return h.clientset.StorageV1().StorageClasses().Patch(ctx,
+			original.Name, types.JSONPatchType, patchData, h.Options.PatchOptions)
+	})
 }
 
 // diffMergePatch will tak the difference data between original and modified storageclass object,
@@ -172,9 +179,13 @@ func (h *Handler) diffMergePatch(original, modified *storagev1.StorageClass, pat
 	}
 
 	if len(patchOptions) != 0 && patchOptions[0] == types.MergePatchType {
-		return h.clientset.StorageV1().StorageClasses().
-			Patch(h.ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		return h.interceptPatch("", original.Name, types.MergePatchType, patchData, func(ctx context.Context, _ string) (*storagev1.StorageClass, error) {
+			return h.clientset.StorageV1().StorageClasses().
+				Patch(ctx, original.Name, types.MergePatchType, patchData, h.Options.PatchOptions)
+		})
 	}
-	return h.clientset.StorageV1().StorageClasses().
-		Patch(h.ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	return h.interceptPatch("", original.Name, types.StrategicMergePatchType, patchData, func(ctx context.Context, _ string) (*storagev1.StorageClass, error) {
+		return h.clientset.StorageV1().StorageClasses().
+			Patch(ctx, original.Name, types.StrategicMergePatchType, patchData, h.Options.PatchOptions)
+	})
 }
@@ -1,10 +1,12 @@
 package storageclass
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/forbearing/k8s/types"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -94,5 +96,15 @@ func (h *Handler) UpdateFromMap(u map[string]interface{}) (*storagev1.StorageCla
 func (h *Handler) updateSC(sc *storagev1.StorageClass) (*storagev1.StorageClass, error) {
 	sc.ResourceVersion = ""
 	sc.UID = ""
-	return h.clientset.StorageV1().StorageClasses().Update(h.ctx, sc, h.Options.UpdateOptions)
+	return h.updateSCWithResourceVersion(sc)
+}
+
+// updateSCWithResourceVersion updates the storageclass without clearing its
+// resourceVersion, the kubernetes API server rejects the update with a
+// Conflict error if the storageclass has been changed since it was read.
+func (h *Handler) updateSCWithResourceVersion(sc *storagev1.StorageClass) (*storagev1.StorageClass, error) {
+	op := &types.Operation{Verb: types.VerbUpdate, Name: sc.Name, Object: sc}
+	return h.intercept(op, func(ctx context.Context, _ string) (*storagev1.StorageClass, error) {
+		return h.clientset.StorageV1().StorageClasses().Update(ctx, sc, h.Options.UpdateOptions)
+	})
 }
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// Verb is the verb of a mutating operation.
+type Verb string
+
+const (
+	VerbCreate Verb = "create"
+	VerbUpdate Verb = "update"
+	VerbApply  Verb = "apply"
+	VerbPatch  Verb = "patch"
+	VerbDelete Verb = "delete"
+)
+
+// Operation describes a mutating call to the kubernetes API server made by
+// a handler's Create/Update/Apply/Patch/Delete method.
+type Operation struct {
+	Verb Verb
+	GVK  schema.GroupVersionKind
+	// Namespace is empty for cluster scope k8s resources.
+	Namespace string
+	Name      string
+	// Subresource is the subresource of the call, such like "status".
+	Subresource string
+	// DryRun is true if the handler was created by WithDryRun, the k8s
+	// object is not persisted by the kubernetes API server.
+	DryRun bool
+
+	// Object is the k8s object sent to kubernetes API server by create, update
+	// and apply, or the k8s object to be deleted. It's nil for patch and
+	// delete by name. Interceptors can modify Object in place before calling
+	// invoke, eg: inject labels or annotations.
+	Object runtime.Object
+	// PatchType and Patch are the patch type and patch data of patch.
+	PatchType k8stypes.PatchType
+	Patch     []byte
+
+	// Result is the k8s object returned by the kubernetes API server, it's
+	// set after invoke returned successfully, always nil for delete.
+	Result runtime.Object
+	// Warnings are the warnings returned by the kubernetes API server, it's
+	// set after invoke returned, see WithWarningHandler.
+	Warnings []Warning
+}
+
+// IsDryRun returns true if the options of verb has dry run set.
+func (o *HandlerOptions) IsDryRun(verb Verb) bool {
+	if o == nil {
+		return false
+	}
+	switch verb {
+	case VerbCreate:
+		return len(o.CreateOptions.DryRun) != 0
+	case VerbUpdate:
+		return len(o.UpdateOptions.DryRun) != 0
+	case VerbApply:
+		return len(o.ApplyOptions.DryRun) != 0 || len(o.CreateOptions.DryRun) != 0
+	case VerbPatch:
+		return len(o.PatchOptions.DryRun) != 0
+	case VerbDelete:
+		return len(o.DeleteOptions.DryRun) != 0
+	}
+	return false
+}
+
+// Invoker performs the operation, the ctx passed to Invoker is used to
+// send the request to the kubernetes API server.
+type Invoker func(ctx context.Context, op *Operation) error
+
+// Interceptor runs around a mutating operation. It must call invoke to
+// continue the operation, and can do something before and after invoke, eg:
+//
+//	// audit log
+//	func(ctx context.Context, op *types.Operation, invoke types.Invoker) error {
+//	    err := invoke(ctx, op)
+//	    log.Printf("%s %s %s/%s: %v", op.Verb, op.GVK.Kind, op.Namespace, op.Name, err)
+//	    return err
+//	}
+//
+//	// policy check
+//	func(ctx context.Context, op *types.Operation, invoke types.Invoker) error {
+//	    if op.Verb == types.VerbDelete && op.Namespace == "kube-system" {
+//	        return errors.New("never delete in kube-system")
+//	    }
+//	    return invoke(ctx, op)
+//	}
+//
+// Returning an error without calling invoke rejects the operation.
+type Interceptor func(ctx context.Context, op *Operation, invoke Invoker) error
+
+// WithInterceptors appends the interceptors to the handler, the interceptors
+// run in the order they were added, the first one is the outermost one.
+func WithInterceptors(interceptors ...Interceptor) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.Interceptors = append(config.Interceptors, interceptors...)
+	}
+}
+
+// Intercept runs the interceptors around invoke.
+func Intercept(ctx context.Context, interceptors []Interceptor, op *Operation, invoke Invoker) error {
+	if len(interceptors) == 0 {
+		return invoke(ctx, op)
+	}
+	return interceptors[0](ctx, op, func(ctx context.Context, op *Operation) error {
+		return Intercept(ctx, interceptors[1:], op, invoke)
+	})
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// NamespaceMode controls how a handler treats the k8s objects whose namespace
+// differs from the namespaces the handler is allowed to operate on.
+type NamespaceMode int
+
+const (
+	// NamespaceModeDefault is the default mode, the namespace defined in the
+	// k8s object has higher precedence than the handler namespace.
+	NamespaceModeDefault NamespaceMode = iota
+	// NamespaceModeReject rejects the operations on k8s objects whose namespace
+	// is not allowed, and the operations on cluster scope k8s objects.
+	NamespaceModeReject
+	// NamespaceModeRewrite rewrites the namespace of the k8s objects to be
+	// created, updated or applied to the handler namespace if the namespace
+	// is not allowed. Patch and delete can't be rewritten, they are rejected
+	// like NamespaceModeReject. Cluster scope k8s objects are always rejected.
+	NamespaceModeRewrite
+)
+
+// WithStrictNamespace restricts the handler to only create/update/apply/patch/delete
+// k8s objects in the allowed namespaces, default to the handler namespace.
+// The mode decides whether a k8s object in other namespace is rejected or
+// rewritten, the rejected operation returns a *NamespaceViolationError.
+func WithStrictNamespace(mode NamespaceMode, allowed ...string) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.StrictNamespace = StrictNamespaceConfig{Mode: mode, Allowed: allowed}
+	}
+}
+
+// StrictNamespaceConfig is the configuration of strict namespace mode.
+type StrictNamespaceConfig struct {
+	Mode NamespaceMode
+	// Allowed is the namespaces the handler is allowed to operate on,
+	// default to the handler namespace.
+	Allowed []string
+}
+
+// NamespaceViolationError is returned when a handler in strict namespace mode
+// refuses to operate on a k8s object.
+type NamespaceViolationError struct {
+	Verb      Verb
+	Kind      string
+	Namespace string
+	Name      string
+	// Allowed is the namespaces the handler is allowed to operate on.
+	Allowed []string
+	// ClusterScoped is true if the k8s object is cluster scope.
+	ClusterScoped bool
+}
+
+func (e *NamespaceViolationError) Error() string {
+	if e.ClusterScoped {
+		return fmt.Sprintf("%s %s/%s refused: cluster scope k8s resource is not allowed in strict namespace mode",
+			e.Verb, e.Kind, e.Name)
+	}
+	return fmt.Sprintf("%s %s/%s refused: namespace %q is not allowed, allowed namespaces: %s",
+		e.Verb, e.Kind, e.Name, e.Namespace, strings.Join(e.Allowed, ", "))
+}
+
+// IsNamespaceViolation returns true if the error is a *NamespaceViolationError.
+func IsNamespaceViolation(err error) bool {
+	var e *NamespaceViolationError
+	return errors.As(err, &e)
+}
+
+// StrictNamespace returns the interceptor that enforces the strict namespace mode.
+// namespace is the handler namespace, the k8s objects are rewritten to it in
+// NamespaceModeRewrite. allowed default to the handler namespace.
+func StrictNamespace(mode NamespaceMode, namespace string, allowed ...string) Interceptor {
+	if len(allowed) == 0 {
+		allowed = []string{namespace}
+	}
+	isAllowed := func(ns string) bool {
+		for _, a := range allowed {
+			if a == ns {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(ctx context.Context, op *Operation, invoke Invoker) error {
+		if mode == NamespaceModeDefault {
+			return invoke(ctx, op)
+		}
+		violation := &NamespaceViolationError{
+			Verb:      op.Verb,
+			Kind:      op.GVK.Kind,
+			Namespace: op.Namespace,
+			Name:      op.Name,
+			Allowed:   allowed,
+		}
+		// Cluster scope k8s object has no namespace.
+		if len(op.Namespace) == 0 {
+			violation.ClusterScoped = true
+			return violation
+		}
+		if isAllowed(op.Namespace) {
+			return invoke(ctx, op)
+		}
+		if mode != NamespaceModeRewrite || op.Object == nil || !isAllowed(namespace) {
+			return violation
+		}
+		switch op.Verb {
+		case VerbCreate, VerbUpdate, VerbApply:
+		default:
+			return violation
+		}
+		accessor, err := meta.Accessor(op.Object)
+		if err != nil {
+			return err
+		}
+		accessor.SetNamespace(namespace)
+		op.Namespace = namespace
+		return invoke(ctx, op)
+	}
+}
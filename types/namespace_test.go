@@ -0,0 +1,57 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStrictNamespace(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          NamespaceMode
+		allowed       []string
+		verb          Verb
+		namespace     string
+		wantErr       bool
+		wantNamespace string
+	}{
+		{name: "default mode", mode: NamespaceModeDefault, verb: VerbCreate, namespace: "other", wantNamespace: "other"},
+		{name: "allowed", mode: NamespaceModeReject, verb: VerbCreate, namespace: "test", wantNamespace: "test"},
+		{name: "allowed set", mode: NamespaceModeReject, allowed: []string{"test", "test2"}, verb: VerbDelete, namespace: "test2", wantNamespace: "test2"},
+		{name: "reject", mode: NamespaceModeReject, verb: VerbCreate, namespace: "other", wantErr: true},
+		{name: "reject cluster scope", mode: NamespaceModeRewrite, verb: VerbCreate, namespace: "", wantErr: true},
+		{name: "rewrite", mode: NamespaceModeRewrite, verb: VerbApply, namespace: "other", wantNamespace: "test"},
+		{name: "rewrite delete", mode: NamespaceModeRewrite, verb: VerbDelete, namespace: "other", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "mycm", Namespace: test.namespace}}
+			op := &Operation{Verb: test.verb, Namespace: test.namespace, Name: obj.Name, Object: obj}
+			invoked := false
+			err := Intercept(context.TODO(), []Interceptor{StrictNamespace(test.mode, "test", test.allowed...)}, op,
+				func(ctx context.Context, op *Operation) error {
+					invoked = true
+					return nil
+				})
+			if test.wantErr {
+				if !IsNamespaceViolation(err) {
+					t.Fatalf("expected NamespaceViolationError, got %v", err)
+				}
+				if invoked {
+					t.Fatal("operation should not be invoked")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if op.Namespace != test.wantNamespace || obj.Namespace != test.wantNamespace {
+				t.Fatalf("expected namespace %q, got %q (object %q)", test.wantNamespace, op.Namespace, obj.Namespace)
+			}
+		})
+	}
+}
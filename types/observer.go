@@ -0,0 +1,69 @@
+package types
+
+import (
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Observer observes the activities of a handler that can't be seen from the
+// requests sent to the kubernetes API server, such like watch reconnects,
+// informer cache sync and wait helpers. It's used to collect metrics, see
+// the package util/monitoring. All methods must be safe for concurrent use.
+type Observer interface {
+	// WatchReconnected is called when the watch connection closed by the
+	// kubernetes API server and the handler reconnects.
+	WatchReconnected(gvk schema.GroupVersionKind)
+	// InformerSynced is called after the informer waited for caches to sync.
+	InformerSynced(gvk schema.GroupVersionKind, synced bool)
+	// InformerEvent is called when the informer receives an event, event
+	// is one of "add", "update" and "delete".
+	InformerEvent(gvk schema.GroupVersionKind, event string)
+	// WaitDone is called when a wait helper, such like WaitReady, returns.
+	// condition is the condition waited for, such like "ready".
+	WaitDone(gvk schema.GroupVersionKind, condition string, duration time.Duration, err error)
+}
+
+// NopObserver is the default Observer that does nothing.
+type NopObserver struct{}
+
+func (NopObserver) WatchReconnected(schema.GroupVersionKind)                       {}
+func (NopObserver) InformerSynced(schema.GroupVersionKind, bool)                   {}
+func (NopObserver) InformerEvent(schema.GroupVersionKind, string)                  {}
+func (NopObserver) WaitDone(schema.GroupVersionKind, string, time.Duration, error) {}
+
+// WithObserver sets the observer of the handler.
+func WithObserver(observer Observer) HandlerOption {
+	return func(config *HandlerConfig) {
+		if observer != nil {
+			config.Observer = observer
+		}
+	}
+}
+
+// WithTransport wraps the http transport used by the handler to send requests
+// to the kubernetes API server, eg: collect request metrics, inject headers.
+// The wrappers are called in the order they were added, the last one is the
+// outermost one.
+func WithTransport(wrap func(rt http.RoundTripper) http.RoundTripper) HandlerOption {
+	return func(config *HandlerConfig) {
+		if wrap != nil {
+			config.TransportWrappers = append(config.TransportWrappers, wrap)
+		}
+	}
+}
+
+// ObserveInformer adds an event handler to the informer that reports the
+// informer events to the observer, it does nothing for NopObserver.
+func ObserveInformer(informer cache.SharedInformer, observer Observer, gvk schema.GroupVersionKind) {
+	if _, ok := observer.(NopObserver); ok || observer == nil {
+		return
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { observer.InformerEvent(gvk, "add") },
+		UpdateFunc: func(interface{}, interface{}) { observer.InformerEvent(gvk, "update") },
+		DeleteFunc: func(interface{}) { observer.InformerEvent(gvk, "delete") },
+	})
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+)
+
+// HandlerOption configures a handler, it's passed to the New()/NewOrDie()
+// function of every handler, eg:
+//
+//	handler, err := deployment.New(ctx, "", "test", types.WithLogger(logger))
+type HandlerOption func(*HandlerConfig)
+
+// HandlerConfig contains the optional configurations of a handler.
+type HandlerConfig struct {
+	// Logger is used by the handler to output logs, every log entry contains
+	// the structured key/values "gvk", "namespace", "name" and "verb".
+	// Default to the logger stored in the context passed to New(), or a
+	// no-op logger if the context doesn't contain one.
+	Logger logr.Logger
+
+	// Interceptors run around every mutating call, see Interceptor.
+	Interceptors []Interceptor
+
+	// StrictNamespace enables the strict namespace mode, see WithStrictNamespace.
+	StrictNamespace StrictNamespaceConfig
+	// Warnings handles the warnings returned by the kubernetes API server,
+	// see WithWarningHandler and WithFailOnDeprecation.
+	Warnings WarningConfig
+
+	// Observer observes the handler activities, default to NopObserver.
+	Observer Observer
+	// TransportWrappers wrap the http transport of the handler, see WithTransport.
+	// The first one is always WarningTransport.
+	TransportWrappers []func(rt http.RoundTripper) http.RoundTripper
+}
+
+// NewHandlerConfig returns the HandlerConfig with all options applied.
+func NewHandlerConfig(ctx context.Context, opts ...HandlerOption) *HandlerConfig {
+	config := &HandlerConfig{
+		Logger:            logr.FromContextOrDiscard(ctx),
+		Observer:          NopObserver{},
+		TransportWrappers: []func(rt http.RoundTripper) http.RoundTripper{WarningTransport},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(config)
+		}
+	}
+	return config
+}
+
+// WithLogger sets the logger used by the handler. Any logr.Logger works, such
+// like the logger returned by zapr, logrusr, klogr, funcr, etc.
+// Debug messages are logged at verbosity level 1.
+func WithLogger(logger logr.Logger) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.Logger = logger
+	}
+}
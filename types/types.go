@@ -123,3 +123,15 @@ type HandlerOptions struct {
 	UpdateOptions metav1.UpdateOptions
 	PatchOptions  metav1.PatchOptions
 }
+
+// OperationResult is the action result of a CreateOrUpdate or CreateOrPatch call.
+type OperationResult string
+
+const (
+	// OperationResultUnchanged means that the k8s resource has not been changed.
+	OperationResultUnchanged OperationResult = "unchanged"
+	// OperationResultCreated means that a new k8s resource is created.
+	OperationResultCreated OperationResult = "created"
+	// OperationResultUpdated means that an existing k8s resource is updated.
+	OperationResultUpdated OperationResult = "updated"
+)
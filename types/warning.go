@@ -0,0 +1,188 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// Warning is a warning returned by the kubernetes API server in the "Warning"
+// response header, such like the deprecated API version or the unknown field.
+type Warning struct {
+	// Code is the warning code, always 299 for kubernetes API server.
+	Code int
+	// Agent is the agent that added the warning, usually "-".
+	Agent string
+	// Text is the warning message.
+	Text string
+}
+
+// IsDeprecation returns true if the warning is a deprecation notice, eg:
+// "apps/v1beta1 Deployment is deprecated in v1.9+, unavailable in v1.16+; use apps/v1 Deployment".
+func (w Warning) IsDeprecation() bool {
+	return strings.Contains(strings.ToLower(w.Text), "deprecated")
+}
+
+func (w Warning) String() string { return w.Text }
+
+// WarningConfig is the configuration of the warnings returned by the
+// kubernetes API server, see WithWarningHandler and WithFailOnDeprecation.
+type WarningConfig struct {
+	Handler           func(op *Operation, warnings []Warning)
+	FailOnDeprecation bool
+}
+
+// WithWarningHandler sets the callback that receives the warnings of every
+// Create/Update/Apply/Patch/Delete operation, it's only called if the kubernetes
+// API server returned warnings.
+func WithWarningHandler(handler func(op *Operation, warnings []Warning)) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.Warnings.Handler = handler
+	}
+}
+
+// WithFailOnDeprecation turns the deprecation warnings returned by the kubernetes
+// API server into *DeprecationError, it's useful to catch the deprecated APIs in CI.
+// Note that the operation has been performed by the kubernetes API server when the
+// error returned, combine it with dry run to avoid changing the k8s objects.
+func WithFailOnDeprecation() HandlerOption {
+	return func(config *HandlerConfig) {
+		config.Warnings.FailOnDeprecation = true
+	}
+}
+
+// DeprecationError is returned by handlers created with WithFailOnDeprecation
+// when the kubernetes API server returned deprecation warnings.
+type DeprecationError struct {
+	Verb      Verb
+	Kind      string
+	Namespace string
+	Name      string
+	Warnings  []Warning
+}
+
+func (e *DeprecationError) Error() string {
+	texts := make([]string, 0, len(e.Warnings))
+	for _, w := range e.Warnings {
+		texts = append(texts, w.Text)
+	}
+	object := e.Name
+	if len(e.Namespace) != 0 {
+		object = e.Namespace + "/" + e.Name
+	}
+	return fmt.Sprintf("%s %s %q: deprecation warnings: %s", e.Verb, e.Kind, object, strings.Join(texts, "; "))
+}
+
+// IsDeprecationError returns true if the err is a *DeprecationError.
+func IsDeprecationError(err error) bool {
+	var e *DeprecationError
+	return errors.As(err, &e)
+}
+
+// WarningCollector collects the warnings returned by the kubernetes API server
+// for the requests sent with the context returned by CollectWarnings.
+type WarningCollector struct {
+	mu       sync.Mutex
+	warnings []Warning
+	parent   *WarningCollector
+}
+
+type warningCollectorKey struct{}
+
+// CollectWarnings returns a copy of ctx that collects the warnings returned by
+// the kubernetes API server, eg:
+//
+//	ctx, collector := types.CollectWarnings(ctx)
+//	handler.WithContext(ctx).Apply(filename)
+//	for _, w := range collector.Warnings() {
+//	    ...
+//	}
+//
+// The warnings are also collected by the collectors of the parent contexts.
+func CollectWarnings(ctx context.Context) (context.Context, *WarningCollector) {
+	parent, _ := ctx.Value(warningCollectorKey{}).(*WarningCollector)
+	collector := &WarningCollector{parent: parent}
+	return context.WithValue(ctx, warningCollectorKey{}, collector), collector
+}
+
+// Warnings returns the warnings collected.
+func (c *WarningCollector) Warnings() []Warning {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Warning(nil), c.warnings...)
+}
+
+func (c *WarningCollector) add(warnings ...Warning) {
+	for ; c != nil; c = c.parent {
+		c.mu.Lock()
+		c.warnings = append(c.warnings, warnings...)
+		c.mu.Unlock()
+	}
+}
+
+// WarningTransport wraps the http transport to deliver the warnings in the
+// "Warning" response headers to the WarningCollector stored in the request
+// context. Every handler wraps its transport with it.
+func WarningTransport(rt http.RoundTripper) http.RoundTripper {
+	return &warningTransport{delegate: rt}
+}
+
+type warningTransport struct {
+	delegate http.RoundTripper
+}
+
+func (t *warningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.delegate.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	collector, _ := req.Context().Value(warningCollectorKey{}).(*WarningCollector)
+	if collector == nil || len(resp.Header.Values("Warning")) == 0 {
+		return resp, err
+	}
+	// invalid warning headers are ignored, same as the client-go does.
+	headers, _ := utilnet.ParseWarningHeaders(resp.Header.Values("Warning"))
+	for _, h := range headers {
+		collector.add(Warning{Code: h.Code, Agent: h.Agent, Text: h.Text})
+	}
+	return resp, err
+}
+
+// Warnings returns the interceptor that collects the warnings of the operation
+// into op.Warnings, calls the warning handler and returns *DeprecationError if
+// FailOnDeprecation is set. Handlers run it after all other interceptors.
+func Warnings(config WarningConfig) Interceptor {
+	return func(ctx context.Context, op *Operation, invoke Invoker) error {
+		ctx, collector := CollectWarnings(ctx)
+		err := invoke(ctx, op)
+		if op.Warnings = collector.Warnings(); len(op.Warnings) == 0 {
+			return err
+		}
+		if config.Handler != nil {
+			config.Handler(op, op.Warnings)
+		}
+		if err != nil || !config.FailOnDeprecation {
+			return err
+		}
+		var deprecations []Warning
+		for _, w := range op.Warnings {
+			if w.IsDeprecation() {
+				deprecations = append(deprecations, w)
+			}
+		}
+		if len(deprecations) == 0 {
+			return nil
+		}
+		namespace, name := op.Namespace, op.Name
+		if accessor, e := meta.Accessor(op.Result); op.Result != nil && e == nil {
+			namespace, name = accessor.GetNamespace(), accessor.GetName()
+		}
+		return &DeprecationError{Verb: op.Verb, Kind: op.GVK.Kind, Namespace: namespace, Name: name, Warnings: deprecations}
+	}
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const deprecation = `299 - "batch/v1beta1 CronJob is deprecated in v1.21+, unavailable in v1.25+; use batch/v1 CronJob"`
+
+func TestWarnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Warning", deprecation)
+		w.Header().Add("Warning", `299 - "unknown field \"spec.foo\""`)
+		w.Header().Add("Warning", `invalid warning`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: WarningTransport(http.DefaultTransport)}
+
+	invoke := func(ctx context.Context, op *Operation) error {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	var handled []Warning
+	config := WarningConfig{Handler: func(op *Operation, warnings []Warning) { handled = warnings }}
+	ctx, collector := CollectWarnings(context.TODO())
+	op := &Operation{Verb: VerbCreate, Namespace: "test", Name: "mycj"}
+	if err := Intercept(ctx, []Interceptor{Warnings(config)}, op, invoke); err != nil {
+		t.Fatal(err)
+	}
+	if len(op.Warnings) != 2 || len(handled) != 2 {
+		t.Fatalf("expected 2 warnings, got %v and %v", op.Warnings, handled)
+	}
+	if !op.Warnings[0].IsDeprecation() || op.Warnings[1].IsDeprecation() {
+		t.Errorf("expected only the first warning is deprecation: %v", op.Warnings)
+	}
+	// the warnings are also collected by the collector of the parent context.
+	if len(collector.Warnings()) != 2 {
+		t.Errorf("expected 2 warnings collected by the parent collector, got %v", collector.Warnings())
+	}
+
+	config.FailOnDeprecation = true
+	op = &Operation{Verb: VerbCreate, Namespace: "test", Name: "mycj"}
+	err := Intercept(context.TODO(), []Interceptor{Warnings(config)}, op, invoke)
+	if !IsDeprecationError(err) {
+		t.Fatalf("expected deprecation error, got %v", err)
+	}
+	if e := err.(*DeprecationError); len(e.Warnings) != 1 || e.Name != "mycj" {
+		t.Errorf("unexpected deprecation error: %v", e)
+	}
+}
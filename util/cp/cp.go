@@ -0,0 +1,498 @@
+/*
+Package cp copies files and directories between the local filesystem and the
+containers of pods with the semantics of "kubectl cp". The data is streamed
+through tar over exec without temporary files, so the tar binary is required in
+the container:
+
+	err := cp.ToPod(ctx, config, clientset, "test", "nginx", "./html", "/usr/share/nginx/html", nil)
+	err = cp.FromPod(ctx, config, clientset, "test", "mysql", "/backup/db.sql", "./db.sql", &cp.Options{Resume: true})
+
+The permissions and modification times of the files are preserved, the
+entries of the archives received from the container that escape the
+destination directory, directly or through symbolic links, are rejected.
+
+The pod handler has CopyToPod() and CopyFromPod() built on it.
+*/
+package cp
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	utilexec "github.com/forbearing/k8s/util/exec"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientexec "k8s.io/client-go/util/exec"
+)
+
+// ErrUnsafePath is returned when an entry of the archive received from the
+// container escapes the destination directory.
+var ErrUnsafePath = errors.New("unsafe path in archive")
+
+// Options configures the copy.
+type Options struct {
+	// Container is the name of the container, default to the container
+	// selected by the "kubectl.kubernetes.io/default-container" annotation
+	// or the first container of the pod.
+	Container string
+	// Progress is called when the data of a file is copied.
+	Progress func(Progress)
+	// Resume continues the copy of a single regular file from the size of
+	// the partial destination file left by the previous copy, instead of
+	// copying it from the beginning. The partial file must be a prefix of
+	// the source file, it's not verified.
+	Resume bool
+}
+
+// Progress is the progress of copying a file.
+type Progress struct {
+	// File is the path of the source file.
+	File string
+	// Bytes is the number of bytes of the file copied, including the bytes
+	// copied by the previous copy if it's resumed.
+	Bytes int64
+	// Total is the size of the file.
+	Total int64
+}
+
+// ToPod copies the local file or directory src to dest in the container of the
+// pod. If dest is an existing directory in the container, src is copied into
+// it, otherwise src is copied as dest.
+func ToPod(ctx context.Context, config *rest.Config, clientset kubernetes.Interface,
+	namespace, pod, src, dest string, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &copier{ctx: ctx, config: config, clientset: clientset, namespace: namespace, pod: pod, opts: opts}
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	// only the non-zero exit code of "test -d" means dest is not a directory.
+	_, err = c.exec([]string{"test", "-d", dest}, nil, nil)
+	var exitErr clientexec.ExitError
+	switch {
+	case err == nil:
+		dest = path.Join(dest, filepath.Base(src))
+	case !errors.As(err, &exitErr):
+		return err
+	}
+
+	if opts.Resume && info.Mode().IsRegular() {
+		return c.resumeToPod(src, dest, info)
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(makeTar(writer, src, path.Base(dest), opts.Progress))
+	}()
+	command := []string{"tar", "-xmf", "-"}
+	if dir := path.Dir(dest); len(dir) != 0 {
+		command = append(command, "-C", dir)
+	}
+	_, err = c.exec(command, reader, nil)
+	// stop makeTar if the remote tar exited.
+	reader.CloseWithError(io.ErrClosedPipe)
+	return err
+}
+
+// FromPod copies the file or directory src in the container of the pod to the
+// local dest. If dest is an existing directory, src is copied into it,
+// otherwise src is copied as dest.
+func FromPod(ctx context.Context, config *rest.Config, clientset kubernetes.Interface,
+	namespace, pod, src, dest string, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &copier{ctx: ctx, config: config, clientset: clientset, namespace: namespace, pod: pod, opts: opts}
+	src = path.Clean(src)
+	if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		dest = filepath.Join(dest, path.Base(src))
+	}
+	if opts.Resume {
+		if info, err := os.Stat(dest); err == nil && info.Mode().IsRegular() {
+			if resumed, err := c.resumeFromPod(src, dest, info.Size()); resumed || err != nil {
+				return err
+			}
+		}
+	}
+
+	reader, writer := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := untar(reader, path.Base(src), dest, opts.Progress)
+		if err == nil {
+			// drain the padding of the archive.
+			_, err = io.Copy(ioutil.Discard, reader)
+		}
+		// stop the remote tar if the archive is rejected.
+		reader.CloseWithError(io.ErrClosedPipe)
+		done <- err
+	}()
+	_, err := c.exec([]string{"tar", "-cf", "-", "-C", path.Dir(src), path.Base(src)}, nil, writer)
+	writer.CloseWithError(err)
+	if untarErr := <-done; untarErr != nil && !errors.Is(untarErr, io.ErrClosedPipe) {
+		return untarErr
+	}
+	return err
+}
+
+type copier struct {
+	ctx       context.Context
+	config    *rest.Config
+	clientset kubernetes.Interface
+	namespace string
+	pod       string
+	opts      *Options
+}
+
+// exec executes the command, the stdout is returned if stdout is nil. The
+// stderr of the remote process is included in the error if it exits with
+// non-zero code.
+func (c *copier) exec(command []string, stdin io.Reader, stdout io.Writer) ([]byte, error) {
+	outBuf, errBuf := &bytes.Buffer{}, &bytes.Buffer{}
+	streams := &utilexec.Streams{Stdin: stdin, Stdout: stdout, Stderr: errBuf}
+	if stdout == nil {
+		streams.Stdout = outBuf
+	}
+	err := utilexec.Stream(c.ctx, c.config, c.clientset, c.namespace, c.pod, command, streams, &utilexec.Options{Container: c.opts.Container})
+	var exitErr clientexec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("%s %s/%s: %w: %s", command[0], c.namespace, c.pod, err, strings.TrimSpace(errBuf.String()))
+	}
+	return outBuf.Bytes(), err
+}
+
+// remoteSize returns the size of the regular file in the container, or -1 if
+// it's not a regular file.
+func (c *copier) remoteSize(file string) (int64, error) {
+	out, err := c.exec([]string{"sh", "-c", `test -f "$1" && wc -c < "$1"`, "sh", file}, nil, nil)
+	var exitErr clientexec.ExitError
+	if errors.As(err, &exitErr) {
+		return -1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+}
+
+// resumeToPod appends the rest of the local file to the partial file in the
+// container, and sets its permissions.
+func (c *copier) resumeToPod(src, dest string, info os.FileInfo) error {
+	offset, err := c.remoteSize(dest)
+	if err != nil {
+		return err
+	}
+	if offset < 0 || offset > info.Size() {
+		offset = 0
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	redirect := ">>"
+	if offset == 0 {
+		redirect = ">"
+	}
+	script := fmt.Sprintf(`cat %s "$1" && chmod "$2" "$1"`, redirect)
+	mode := strconv.FormatUint(uint64(info.Mode().Perm()), 8)
+	reader := newProgressReader(f, src, offset, info.Size(), c.opts.Progress)
+	_, err = c.exec([]string{"sh", "-c", script, "sh", dest, mode}, reader, nil)
+	return err
+}
+
+// resumeFromPod appends the rest of the file in the container to the partial
+// local file, it returns false if src is not a regular file.
+func (c *copier) resumeFromPod(src, dest string, offset int64) (bool, error) {
+	size, err := c.remoteSize(src)
+	if err != nil || size < 0 {
+		return false, err
+	}
+	if offset > size {
+		// the local file is not a prefix, copy it again.
+		return false, nil
+	}
+	f, err := os.OpenFile(dest, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return true, err
+	}
+	defer f.Close()
+	writer := newProgressWriter(f, src, offset, size, c.opts.Progress)
+	if offset == size {
+		writer.report()
+		return true, nil
+	}
+	_, err = c.exec([]string{"tail", "-c", fmt.Sprintf("+%d", offset+1), src}, nil, writer)
+	return true, err
+}
+
+// makeTar writes the local file or directory src to the archive as prefix,
+// the symbolic links are not followed.
+func makeTar(w io.Writer, src, prefix string, progress func(Progress)) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(src, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(prefix, filepath.ToSlash(rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(newProgressWriter(tw, file, 0, info.Size(), progress), f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// untar extracts the entries of the archive under prefix to dest, prefix is
+// replaced by dest. The entries and the symbolic links escaping dest are
+// rejected with ErrUnsafePath.
+func untar(r io.Reader, prefix, dest string, progress func(Progress)) error {
+	dest = filepath.Clean(dest)
+	realDest, err := realPath(dest)
+	if err != nil {
+		return err
+	}
+	type dirMode struct {
+		path   string
+		header *tar.Header
+	}
+	var dirs []dirMode
+	// symlinks are the symbolic links created by the archive, nothing is
+	// extracted at or below them, so they can't redirect the later entries
+	// outside dest.
+	symlinks := make(map[string]bool)
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(header.Name)
+		if name != prefix && !strings.HasPrefix(name, prefix+"/") {
+			return fmt.Errorf("%w: %q is not under %q", ErrUnsafePath, header.Name, prefix)
+		}
+		target := filepath.Join(dest, filepath.FromSlash(strings.TrimPrefix(name, prefix)))
+		if !within(dest, target) || !safeTarget(dest, realDest, target, symlinks) {
+			return fmt.Errorf("%w: %q", ErrUnsafePath, header.Name)
+		}
+
+		mode := header.FileInfo().Mode()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			// the permissions are set at last, the directory may be read-only.
+			dirs = append(dirs, dirMode{target, header})
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if err := writeFile(tr, target, mode.Perm(), header, progress); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			link := header.Linkname
+			if !filepath.IsAbs(link) {
+				parent, err := realPath(filepath.Dir(target))
+				if err != nil {
+					return err
+				}
+				link = filepath.Join(parent, link)
+			}
+			if !within(realDest, link) {
+				return fmt.Errorf("%w: link %q -> %q", ErrUnsafePath, header.Name, header.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			os.Remove(target)
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+			symlinks[target] = true
+		case tar.TypeLink:
+			name := path.Clean(header.Linkname)
+			link := filepath.Join(dest, filepath.FromSlash(strings.TrimPrefix(name, prefix)))
+			if (name != prefix && !strings.HasPrefix(name, prefix+"/")) || !within(dest, link) || !safeTarget(dest, realDest, link, symlinks) {
+				return fmt.Errorf("%w: link %q -> %q", ErrUnsafePath, header.Name, header.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			os.Remove(target)
+			if err := os.Link(link, target); err != nil {
+				return err
+			}
+		default:
+			// devices, fifos and so on are skipped like "kubectl cp".
+		}
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		os.Chmod(dirs[i].path, dirs[i].header.FileInfo().Mode().Perm())
+		os.Chtimes(dirs[i].path, dirs[i].header.ModTime, dirs[i].header.ModTime)
+	}
+	return nil
+}
+
+func writeFile(r io.Reader, target string, perm os.FileMode, header *tar.Header, progress func(Progress)) error {
+	// don't write through the existing symbolic link.
+	if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(newProgressWriter(f, header.Name, 0, header.Size, progress), r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// the permissions passed to OpenFile are masked by umask.
+	if err := os.Chmod(target, perm); err != nil {
+		return err
+	}
+	return os.Chtimes(target, header.ModTime, header.ModTime)
+}
+
+// within reports whether the target is dir or under dir.
+func within(dir, target string) bool {
+	rel, err := filepath.Rel(dir, filepath.Clean(target))
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// safeTarget reports whether the target is not at or below a symbolic link
+// created by the archive, and it's still under dest after resolving the
+// symbolic links of its existing parent directories. The dest itself is the
+// path given by the caller, it's not checked.
+func safeTarget(dest, realDest, target string, symlinks map[string]bool) bool {
+	if target == dest {
+		return true
+	}
+	for dir := target; dir != dest && within(dest, dir); dir = filepath.Dir(dir) {
+		if symlinks[dir] {
+			return false
+		}
+	}
+	parent, err := realPath(filepath.Dir(target))
+	if err != nil {
+		return false
+	}
+	return within(realDest, filepath.Join(parent, filepath.Base(target)))
+}
+
+// realPath returns the path with the symbolic links resolved, the trailing
+// elements not existing yet are kept as they are.
+func realPath(p string) (string, error) {
+	var missing []string
+	for {
+		real, err := filepath.EvalSymlinks(p)
+		if err == nil {
+			return filepath.Join(append([]string{real}, missing...)...), nil
+		}
+		parent := filepath.Dir(p)
+		if !os.IsNotExist(err) || parent == p {
+			return "", err
+		}
+		missing = append([]string{filepath.Base(p)}, missing...)
+		p = parent
+	}
+}
+
+// progressWriter reports the progress of the data written.
+type progressWriter struct {
+	io.Writer
+	progress Progress
+	fn       func(Progress)
+}
+
+func newProgressWriter(w io.Writer, file string, offset, total int64, fn func(Progress)) *progressWriter {
+	return &progressWriter{Writer: w, progress: Progress{File: file, Bytes: offset, Total: total}, fn: fn}
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.progress.Bytes += int64(n)
+	w.report()
+	return n, err
+}
+
+func (w *progressWriter) report() {
+	if w.fn != nil {
+		w.fn(w.progress)
+	}
+}
+
+// progressReader reports the progress of the data read.
+type progressReader struct {
+	io.Reader
+	progress Progress
+	fn       func(Progress)
+}
+
+func newProgressReader(r io.Reader, file string, offset, total int64, fn func(Progress)) *progressReader {
+	return &progressReader{Reader: r, progress: Progress{File: file, Bytes: offset, Total: total}, fn: fn}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.progress.Bytes += int64(n)
+	if n > 0 && r.fn != nil {
+		r.fn(r.progress)
+	}
+	return n, err
+}
@@ -0,0 +1,113 @@
+package cp
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTarRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "conf"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src, "conf", "nginx.conf"), []byte("worker_processes 1;"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src, "start.sh"), []byte("#!/bin/sh"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("conf/nginx.conf", filepath.Join(src, "nginx.conf")); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	var copied int64
+	if err := makeTar(buf, src, "html", func(p Progress) { copied = p.Bytes }); err != nil {
+		t.Fatal(err)
+	}
+	if copied == 0 {
+		t.Error("expected progress to be reported")
+	}
+	dest := filepath.Join(t.TempDir(), "backup")
+	if err := untar(buf, "html", dest, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dest, "nginx.conf"))
+	if err != nil || string(data) != "worker_processes 1;" {
+		t.Errorf("unexpected content through the symbolic link %q: %v", data, err)
+	}
+	for file, perm := range map[string]os.FileMode{"conf": 0o750, "conf/nginx.conf": 0o640, "start.sh": 0o755} {
+		info, err := os.Stat(filepath.Join(dest, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != perm {
+			t.Errorf("expected %s permissions %o, got %o", file, perm, info.Mode().Perm())
+		}
+	}
+}
+
+func TestUntarUnsafePath(t *testing.T) {
+	tests := []*tar.Header{
+		{Name: "html/../../etc/passwd", Typeflag: tar.TypeReg, Mode: 0o644},
+		{Name: "etc/passwd", Typeflag: tar.TypeReg, Mode: 0o644},
+		{Name: "html/passwd", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+		{Name: "html/passwd", Typeflag: tar.TypeSymlink, Linkname: "../../etc/passwd"},
+		{Name: "html/passwd", Typeflag: tar.TypeLink, Linkname: "etc/passwd"},
+	}
+	for _, header := range tests {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		tw.Close()
+		dest := filepath.Join(t.TempDir(), "html")
+		if err := untar(buf, "html", dest, nil); !errors.Is(err, ErrUnsafePath) {
+			t.Errorf("expected ErrUnsafePath for %q -> %q, got %v", header.Name, header.Linkname, err)
+		}
+	}
+}
+
+func TestUntarSymlinkEscape(t *testing.T) {
+	tests := [][]*tar.Header{
+		{
+			{Name: "p/sub", Typeflag: tar.TypeDir, Mode: 0o755},
+			{Name: "p/sub/up", Typeflag: tar.TypeSymlink, Linkname: ".."},
+			{Name: "p/sub/up/l", Typeflag: tar.TypeSymlink, Linkname: "../outside"},
+			{Name: "p/sub/up/l/evil", Typeflag: tar.TypeReg, Mode: 0o644},
+		},
+		{
+			{Name: "p/dir", Typeflag: tar.TypeSymlink, Linkname: "."},
+			{Name: "p/dir/evil", Typeflag: tar.TypeReg, Mode: 0o644},
+		},
+		{
+			{Name: "p/dir", Typeflag: tar.TypeSymlink, Linkname: "."},
+			{Name: "p/evil", Typeflag: tar.TypeLink, Linkname: "p/dir/evil"},
+		},
+	}
+	for _, headers := range tests {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for _, header := range headers {
+			if err := tw.WriteHeader(header); err != nil {
+				t.Fatal(err)
+			}
+		}
+		tw.Close()
+		root := t.TempDir()
+		dest := filepath.Join(root, "p")
+		if err := untar(buf, "p", dest, nil); !errors.Is(err, ErrUnsafePath) {
+			t.Errorf("expected ErrUnsafePath for %q, got %v", headers[len(headers)-1].Name, err)
+		}
+		if _, err := os.Lstat(filepath.Join(root, "outside")); !os.IsNotExist(err) {
+			t.Errorf("expected nothing written outside the destination, got %v", err)
+		}
+	}
+}
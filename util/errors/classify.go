@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// classError is the class of errors, it's used as the target of errors.Is
+// for the errors returned by handlers, see OperationError.
+type classError struct {
+	name  string
+	match func(err error) bool
+}
+
+func (e *classError) Error() string { return e.name }
+
+// The class errors are used as the target of errors.Is to classify the errors
+// returned by handlers, eg: errors.Is(err, utilerrors.ErrConflict).
+var (
+	ErrRetryable      error = &classError{"retryable error", IsRetryable}
+	ErrConflict       error = &classError{"conflict", IsConflict}
+	ErrForbidden      error = &classError{"forbidden", IsForbidden}
+	ErrQuotaExceeded  error = &classError{"quota exceeded", IsQuotaExceeded}
+	ErrImmutableField error = &classError{"immutable field", IsImmutableField}
+	ErrNoKindMatch    error = &classError{"no kind match", IsNoKindMatch}
+	ErrWebhookDenied  error = &classError{"admission webhook denied", IsWebhookDenied}
+)
+
+// IsRetryable returns true if the request may succeed when retried later, such
+// like the API server timeout, too many requests, service unavailable and the
+// connection errors. Conflict is not retryable without getting the latest k8s
+// object, see IsConflict.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+	if _, ok := apierrors.SuggestsClientDelay(err); ok {
+		return true
+	}
+	if utilnet.IsConnectionReset(err) || utilnet.IsConnectionRefused(err) || utilnet.IsProbableEOF(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsConflict returns true if the k8s object has been modified since it was read,
+// get the latest k8s object and try again.
+func IsConflict(err error) bool {
+	return apierrors.IsConflict(err)
+}
+
+// IsForbidden returns true if the request is forbidden by RBAC, the resource
+// quota or the admission controllers.
+func IsForbidden(err error) bool {
+	return apierrors.IsForbidden(err)
+}
+
+// IsQuotaExceeded returns true if the request is forbidden because the resource
+// quota of the namespace is exceeded.
+func IsQuotaExceeded(err error) bool {
+	return apierrors.IsForbidden(err) && strings.Contains(statusMessage(err), "exceeded quota")
+}
+
+// IsImmutableField returns true if the request is invalid because it changes an
+// immutable field, such like the selector of deployment.
+func IsImmutableField(err error) bool {
+	if !apierrors.IsInvalid(err) {
+		return false
+	}
+	if strings.Contains(statusMessage(err), "field is immutable") {
+		return true
+	}
+	for _, cause := range FieldCauses(err) {
+		if strings.Contains(cause.Message, "field is immutable") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNoKindMatch returns true if the kind or resource is not served by the
+// API server, such like the CRD is not installed or the API version is removed.
+func IsNoKindMatch(err error) bool {
+	var kindErr *meta.NoKindMatchError
+	var resourceErr *meta.NoResourceMatchError
+	return errors.As(err, &kindErr) || errors.As(err, &resourceErr)
+}
+
+// IsWebhookDenied returns true if the request is denied by a validating or
+// mutating admission webhook.
+func IsWebhookDenied(err error) bool {
+	message := statusMessage(err)
+	return strings.Contains(message, "admission webhook") && strings.Contains(message, "denied the request")
+}
+
+// FieldCauses returns the field-level causes of the validation error, eg:
+//
+//	{Type: "FieldValueInvalid", Field: "spec.replicas", Message: "Invalid value: -1: must be greater than or equal to 0"}
+//
+// It returns nil if the error is not a kubernetes API error or has no field causes.
+func FieldCauses(err error) []metav1.StatusCause {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return nil
+	}
+	var causes []metav1.StatusCause
+	for _, cause := range status.Status().Details.Causes {
+		if len(cause.Field) != 0 {
+			causes = append(causes, cause)
+		}
+	}
+	return causes
+}
+
+// statusMessage returns the message of the kubernetes API error.
+func statusMessage(err error) string {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return ""
+	}
+	return status.Status().Message
+}
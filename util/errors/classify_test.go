@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	deployGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	deployGR  = schema.GroupResource{Group: "apps", Resource: "deployments"}
+)
+
+func TestClassify(t *testing.T) {
+	immutable := apierrors.NewInvalid(deployGVK.GroupKind(), "nginx", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "selector"), "app=nginx", "field is immutable"),
+	})
+	webhook := apierrors.NewForbidden(deployGR, "nginx",
+		errors.New(`admission webhook "validate.example.com" denied the request: image not allowed`))
+	quota := apierrors.NewForbidden(deployGR, "nginx",
+		errors.New(`exceeded quota: compute, requested: cpu=2, used: cpu=1, limited: cpu=2`))
+
+	tests := []struct {
+		name     string
+		err      error
+		classify func(error) bool
+		class    error
+		expected bool
+	}{
+		{"conflict", apierrors.NewConflict(deployGR, "nginx", errors.New("modified")), IsConflict, ErrConflict, true},
+		{"not conflict", apierrors.NewNotFound(deployGR, "nginx"), IsConflict, ErrConflict, false},
+		{"retryable too many requests", apierrors.NewTooManyRequests("slow down", 1), IsRetryable, ErrRetryable, true},
+		{"retryable server timeout", apierrors.NewServerTimeout(deployGR, "create", 1), IsRetryable, ErrRetryable, true},
+		{"not retryable", apierrors.NewBadRequest("bad"), IsRetryable, ErrRetryable, false},
+		{"forbidden", quota, IsForbidden, ErrForbidden, true},
+		{"quota exceeded", quota, IsQuotaExceeded, ErrQuotaExceeded, true},
+		{"not quota exceeded", webhook, IsQuotaExceeded, ErrQuotaExceeded, false},
+		{"immutable field", immutable, IsImmutableField, ErrImmutableField, true},
+		{"not immutable field", apierrors.NewInvalid(deployGVK.GroupKind(), "nginx", nil), IsImmutableField, ErrImmutableField, false},
+		{"no kind match", &meta.NoKindMatchError{GroupKind: deployGVK.GroupKind()}, IsNoKindMatch, ErrNoKindMatch, true},
+		{"webhook denied", webhook, IsWebhookDenied, ErrWebhookDenied, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wrapped := Wrap("create", deployGVK, "test", "nginx", test.err)
+			if got := test.classify(test.err); got != test.expected {
+				t.Errorf("expected %v for the error, got %v", test.expected, got)
+			}
+			if got := test.classify(wrapped); got != test.expected {
+				t.Errorf("expected %v for the wrapped error, got %v", test.expected, got)
+			}
+			if got := errors.Is(fmt.Errorf("outer: %w", wrapped), test.class); got != test.expected {
+				t.Errorf("expected errors.Is %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestOperationError(t *testing.T) {
+	if Wrap("create", deployGVK, "test", "nginx", nil) != nil {
+		t.Fatal("expected nil for nil error")
+	}
+	err := Wrap("update", deployGVK, "test", "nginx", apierrors.NewNotFound(deployGR, "nginx"))
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the wrapped error is NotFound")
+	}
+	var opErr *OperationError
+	if !errors.As(err, &opErr) || opErr.Verb != "update" || opErr.Namespace != "test" || opErr.Name != "nginx" {
+		t.Fatalf("unexpected operation error: %#v", opErr)
+	}
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) {
+		t.Errorf("expected the underlying status error extracted by errors.As")
+	}
+	expected := `update Deployment "test/nginx": deployments.apps "nginx" not found`
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+	// already wrapped error is returned unmodified.
+	if Wrap("apply", deployGVK, "test", "nginx", err) != err {
+		t.Errorf("expected the wrapped error returned unmodified")
+	}
+}
+
+func TestFieldCauses(t *testing.T) {
+	err := Wrap("create", deployGVK, "test", "nginx", apierrors.NewInvalid(deployGVK.GroupKind(), "nginx", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "replicas"), -1, "must be greater than or equal to 0"),
+		field.Required(field.NewPath("spec", "template", "spec", "containers"), ""),
+	}))
+	causes := FieldCauses(err)
+	if len(causes) != 2 {
+		t.Fatalf("expected 2 causes, got %v", causes)
+	}
+	if causes[0].Field != "spec.replicas" || causes[0].Type != metav1.CauseTypeFieldValueInvalid {
+		t.Errorf("unexpected cause: %+v", causes[0])
+	}
+	if causes[1].Field != "spec.template.spec.containers" || causes[1].Type != metav1.CauseTypeFieldValueRequired {
+		t.Errorf("unexpected cause: %+v", causes[1])
+	}
+	if FieldCauses(errors.New("foo")) != nil {
+		t.Errorf("expected nil causes for non API error")
+	}
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// OperationError is the error returned by the Create/Update/Apply/Patch/Delete
+// and Get/List/Watch methods of handlers, it wraps the underlying error with
+// the k8s object context. The underlying error can be checked by apierrors.IsNotFound and
+// friends, or extracted by errors.As, eg:
+//
+//	_, err := handler.Create(filename)
+//	var opErr *utilerrors.OperationError
+//	if errors.As(err, &opErr) {
+//	    fmt.Println(opErr.Verb, opErr.GVK, opErr.Namespace, opErr.Name)
+//	}
+//	if errors.Is(err, utilerrors.ErrConflict) {
+//	    ...
+//	}
+type OperationError struct {
+	Verb string
+	GVK  schema.GroupVersionKind
+	// Namespace is empty for cluster scope k8s resources.
+	Namespace string
+	// Name is empty if the k8s object is created with generateName.
+	Name string
+	Err  error
+}
+
+func (e *OperationError) Error() string {
+	object := e.Name
+	if len(e.Namespace) != 0 {
+		object = e.Namespace + "/" + e.Name
+	}
+	return fmt.Sprintf("%s %s %q: %v", e.Verb, e.GVK.Kind, object, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *OperationError) Unwrap() error { return e.Err }
+
+// Is reports whether the underlying error matches the class error target,
+// such like ErrConflict and ErrRetryable.
+func (e *OperationError) Is(target error) bool {
+	class, ok := target.(*classError)
+	return ok && class.match(e.Err)
+}
+
+// Wrap wraps err with the k8s object context into *OperationError. It returns
+// nil if err is nil, and returns err unmodified if it's already wrapped.
+func Wrap(verb string, gvk schema.GroupVersionKind, namespace, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var opErr *OperationError
+	if errors.As(err, &opErr) {
+		return err
+	}
+	return &OperationError{Verb: verb, GVK: gvk, Namespace: namespace, Name: name, Err: err}
+}
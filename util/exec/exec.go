@@ -0,0 +1,318 @@
+/*
+Package exec executes commands in the containers of pods and captures the
+stdout, stderr and exit code of the remote processes:
+
+	result, err := exec.Exec(ctx, config, clientset, "test", "nginx", []string{"nginx", "-t"}, &exec.Options{Timeout: time.Minute})
+	fmt.Println(result.ExitCode, string(result.Stderr))
+
+Stream connects the streams and the terminal to the remote process, and Attach
+attaches them to the main process of a running container.
+
+The pod handler has Exec(), ExecByLabel() and Attach(), the deployment,
+statefulset, daemonset and job handlers have ExecAll() that runs the command
+in every pod.
+*/
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+	clientexec "k8s.io/client-go/util/exec"
+)
+
+// DefaultContainerAnnotation is the annotation that selects the default
+// container of the pod, it's the same as kubectl.
+const DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// Options configures the command execution.
+type Options struct {
+	// Container is the name of the container, default to the container
+	// selected by the "kubectl.kubernetes.io/default-container" annotation
+	// or the first container of the pod.
+	Container string
+	// Stdin is sent to the stdin of the remote process if it's not nil.
+	Stdin []byte
+	// Timeout kills the connection to the remote process when expired, zero
+	// means no timeout. The remote process may continue to run.
+	Timeout time.Duration
+	// Concurrency is the maximum number of pods that the command runs in at
+	// the same time by ExecAll, zero means no limit.
+	Concurrency int
+}
+
+// Result is the result of the command.
+type Result struct {
+	Namespace string
+	Pod       string
+	Container string
+	Stdout    []byte
+	Stderr    []byte
+	// ExitCode is the exit code of the remote process.
+	ExitCode int
+	// Err is the error occurred in the pod, only set by ExecAll.
+	Err error
+}
+
+// Streams are the stdin, stdout and stderr of the remote process, the nil
+// ones are not connected.
+type Streams struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	// TTY allocates a terminal for the remote process, the stderr is merged
+	// into the stdout by the terminal. To attach to a container, it must
+	// match the tty of the container.
+	TTY bool
+	// TerminalSizeQueue resizes the terminal if it's not nil.
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
+
+// Exec executes the command in the container of the pod and waits for it to
+// exit. A non-zero exit code of the remote process is not an error, it's
+// returned by Result.ExitCode. The error is returned if the command can't be
+// executed, such like the container is not running, or the timeout expired.
+func Exec(ctx context.Context, config *rest.Config, clientset kubernetes.Interface,
+	namespace, pod string, command []string, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	streams := &Streams{Stdout: stdout, Stderr: stderr}
+	if opts.Stdin != nil {
+		streams.Stdin = bytes.NewReader(opts.Stdin)
+	}
+	container, err := stream(ctx, config, clientset, namespace, pod, command, streams, opts)
+	if len(container) == 0 {
+		return nil, err
+	}
+	result := &Result{Namespace: namespace, Pod: pod, Container: container, Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	return result, setExitCode(result, err)
+}
+
+// setExitCode sets the exit code of the result if the remote process exited,
+// the non-zero exit code is not an error. Other errors are returned.
+func setExitCode(result *Result, err error) error {
+	var exitErr clientexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		result.ExitCode = exitErr.ExitStatus()
+		return nil
+	}
+	return err
+}
+
+// Stream executes the command in the container of the pod and connects the
+// streams to the remote process, it waits for the process to exit. Unlike
+// Exec, the non-zero exit code is returned as an error of type
+// k8s.io/client-go/util/exec.ExitError. opts.Stdin is ignored.
+func Stream(ctx context.Context, config *rest.Config, clientset kubernetes.Interface,
+	namespace, pod string, command []string, streams *Streams, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	_, err := stream(ctx, config, clientset, namespace, pod, command, streams, opts)
+	return err
+}
+
+// Attach attaches the streams to the main process of the running container of
+// the pod like "kubectl attach", it returns when the streams are closed or the
+// process exits. The container must be started with stdin and tty to attach
+// the stdin and the terminal. opts.Stdin is ignored.
+func Attach(ctx context.Context, config *rest.Config, clientset kubernetes.Interface,
+	namespace, pod string, streams *Streams, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	_, err := stream(ctx, config, clientset, namespace, pod, nil, streams, opts)
+	return err
+}
+
+// stream returns the container the command executed in, it's empty if the
+// command isn't executed. It attaches to the container if command is nil.
+func stream(ctx context.Context, config *rest.Config, clientset kubernetes.Interface,
+	namespace, pod string, command []string, streams *Streams, opts *Options) (string, error) {
+	container := opts.Container
+	if len(container) == 0 {
+		p, err := clientset.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		if container = DefaultContainer(p); len(container) == 0 {
+			return "", fmt.Errorf("pod %s/%s has no container", namespace, pod)
+		}
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	// the stderr is merged into the stdout by the terminal.
+	stderr := streams.Stderr
+	if streams.TTY {
+		stderr = nil
+	}
+	req := clientset.CoreV1().RESTClient().Post().
+		Namespace(namespace).
+		Resource("pods").
+		Name(pod)
+	if command == nil {
+		req = req.SubResource("attach").VersionedParams(&corev1.PodAttachOptions{
+			Container: container,
+			Stdin:     streams.Stdin != nil,
+			Stdout:    streams.Stdout != nil,
+			Stderr:    stderr != nil,
+			TTY:       streams.TTY,
+		}, scheme.ParameterCodec)
+	} else {
+		req = req.SubResource("exec").VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     streams.Stdin != nil,
+			Stdout:    streams.Stdout != nil,
+			Stderr:    stderr != nil,
+			TTY:       streams.TTY,
+		}, scheme.ParameterCodec)
+	}
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return "", err
+	}
+	closer := &closableUpgrader{Upgrader: upgrader}
+	executor, err := remotecommand.NewSPDYExecutorForTransports(transport, closer, http.MethodPost, req.URL())
+	if err != nil {
+		return "", err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- executor.Stream(remotecommand.StreamOptions{
+			Stdin:             streams.Stdin,
+			Stdout:            streams.Stdout,
+			Stderr:            stderr,
+			Tty:               streams.TTY,
+			TerminalSizeQueue: streams.TerminalSizeQueue,
+		})
+	}()
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// the executor doesn't support context, close the connection to stop it.
+		closer.Close()
+		<-done
+		err = fmt.Errorf("%s/%s: %w", namespace, pod, ctx.Err())
+	}
+	return container, err
+}
+
+// ExecAll executes the command in every running pod selected by the selector
+// concurrently, and returns the results sorted by pod name. The error of every
+// pod is returned by Result.Err, the error is returned only if the pods can't
+// be listed.
+func ExecAll(ctx context.Context, config *rest.Config, clientset kubernetes.Interface,
+	namespace string, selector labels.Selector, command []string, opts *Options) ([]*Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	podList, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		if podList.Items[i].Status.Phase == corev1.PodRunning && podList.Items[i].DeletionTimestamp == nil {
+			pods = append(pods, &podList.Items[i])
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 || concurrency > len(pods) {
+		concurrency = len(pods)
+	}
+	var (
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, concurrency)
+		results = make([]*Result, len(pods))
+	)
+	for i, pod := range pods {
+		podOpts := *opts
+		if len(podOpts.Container) == 0 {
+			podOpts.Container = DefaultContainer(pod)
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, pod *corev1.Pod) {
+			defer func() { <-sem; wg.Done() }()
+			result, err := Exec(ctx, config, clientset, pod.Namespace, pod.Name, command, &podOpts)
+			if result == nil {
+				result = &Result{Namespace: pod.Namespace, Pod: pod.Name, Container: podOpts.Container}
+			}
+			result.Err = err
+			results[i] = result
+		}(i, pod)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// DefaultContainer returns the name of the default container of the pod.
+func DefaultContainer(pod *corev1.Pod) string {
+	if name := pod.Annotations[DefaultContainerAnnotation]; len(name) != 0 {
+		return name
+	}
+	if len(pod.Spec.Containers) != 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
+
+// closableUpgrader records the upgraded connection so that it can be closed
+// to stop the executor.
+type closableUpgrader struct {
+	spdy.Upgrader
+
+	mu     sync.Mutex
+	conn   httpstream.Connection
+	closed bool
+}
+
+func (u *closableUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := u.Upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.conn = conn
+	if u.closed {
+		conn.Close()
+	}
+	return conn, nil
+}
+
+// Close closes the upgraded connection, or the connection upgraded later.
+func (u *closableUpgrader) Close() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.closed = true
+	if u.conn != nil {
+		u.conn.Close()
+	}
+}
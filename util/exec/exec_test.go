@@ -0,0 +1,81 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clientexec "k8s.io/client-go/util/exec"
+)
+
+func TestDefaultContainer(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx"}, {Name: "sidecar"}}}}
+	if name := DefaultContainer(pod); name != "nginx" {
+		t.Errorf("expected the first container, got %q", name)
+	}
+	pod.Annotations = map[string]string{DefaultContainerAnnotation: "sidecar"}
+	if name := DefaultContainer(pod); name != "sidecar" {
+		t.Errorf("expected the annotated container, got %q", name)
+	}
+	if name := DefaultContainer(&corev1.Pod{}); name != "" {
+		t.Errorf("expected no container, got %q", name)
+	}
+}
+
+func TestExecAllSkipsNotRunningPods(t *testing.T) {
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-1", Namespace: "test", Labels: map[string]string{"app": "nginx"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	clientset := fake.NewSimpleClientset(pending)
+	selector := labels.SelectorFromSet(labels.Set{"app": "nginx"})
+	results, err := ExecAll(context.TODO(), &rest.Config{}, clientset, "test", selector, []string{"true"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no results, got %d", len(results))
+	}
+}
+
+func TestExecNoContainer(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "test"}}
+	clientset := fake.NewSimpleClientset(pod)
+	result, err := Exec(context.TODO(), &rest.Config{}, clientset, "test", "nginx", []string{"true"}, nil)
+	if err == nil || result != nil {
+		t.Fatalf("expected error without result, got %v, %v", result, err)
+	}
+}
+
+func TestSetExitCode(t *testing.T) {
+	failed := errors.New("container not running")
+	tests := []struct {
+		name     string
+		err      error
+		exitCode int
+		expected error
+	}{
+		{name: "success"},
+		{name: "exit code", err: clientexec.CodeExitError{Err: errors.New("command terminated with exit code 2"), Code: 2}, exitCode: 2},
+		{name: "wrapped exit code", err: fmt.Errorf("test/nginx: %w", clientexec.CodeExitError{Err: errors.New("exit 1"), Code: 1}), exitCode: 1},
+		{name: "other error", err: failed, expected: failed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := &Result{}
+			if err := setExitCode(result, test.err); err != test.expected {
+				t.Errorf("expected error %v, got %v", test.expected, err)
+			}
+			if result.ExitCode != test.exitCode {
+				t.Errorf("expected exit code %d, got %d", test.exitCode, result.ExitCode)
+			}
+		})
+	}
+}
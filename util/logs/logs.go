@@ -0,0 +1,313 @@
+/*
+Package logs streams the logs of all containers of the pods selected by a label
+selector, like stern. It follows the new pods and the restarted containers, and
+delivers the lines through a channel or writes them to a writer prefixed with
+the pod and container names:
+
+	podSelector, _ := selector.ForObject(deploy)
+	err := logs.Stream(ctx, clientset, "test", podSelector, &logs.Options{Follow: true, Writer: os.Stdout})
+
+The typed handlers of deployment, statefulset, daemonset, job and pod have
+StreamLogs() methods built on it.
+*/
+package logs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Line is a log line of a container.
+type Line struct {
+	Namespace string
+	Pod       string
+	Container string
+	// Timestamp is parsed from the beginning of the line if the logs are
+	// requested with timestamps, zero otherwise.
+	Timestamp time.Time
+	// Text is the log line without the timestamp and the trailing newline.
+	Text string
+}
+
+func (l Line) String() string {
+	if l.Timestamp.IsZero() {
+		return fmt.Sprintf("%s %s %s", l.Pod, l.Container, l.Text)
+	}
+	return fmt.Sprintf("%s %s %s %s", l.Pod, l.Container, l.Timestamp.Format(time.RFC3339Nano), l.Text)
+}
+
+// Options configures the log streaming.
+type Options struct {
+	// Container is the regular expression of the container names, default to
+	// all containers.
+	Container string
+	// InitContainers streams the logs of the init containers too.
+	InitContainers bool
+	// Previous streams the logs of the previous terminated containers, it
+	// doesn't follow.
+	Previous bool
+	// Follow streams the logs until the context is done, the new pods and the
+	// restarted containers are picked up. The errors of the single streams are
+	// ignored and retried on the next pod update when following.
+	Follow bool
+	// Since only returns the logs newer than the duration, zero means all.
+	Since time.Duration
+	// TailLines only returns the last lines of the logs of each container,
+	// nil means all.
+	TailLines *int64
+	// Timestamps requests the logs with timestamps, they are parsed into
+	// Line.Timestamp.
+	Timestamps bool
+
+	// Lines receives the lines if it's not nil, otherwise the lines are
+	// written to Writer prefixed with the pod and container names.
+	Lines chan<- Line
+	// Writer default to os.Stdout.
+	Writer io.Writer
+	// Color colours the prefix of every pod and container, off by default.
+	Color bool
+}
+
+// Stream streams the logs of the pods selected by the selector in the namespace.
+// Without Follow, it returns after the logs of all pods are streamed, with the
+// first error occurred. With Follow, it returns when ctx is done.
+func Stream(ctx context.Context, clientset kubernetes.Interface, namespace string, selector labels.Selector, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	s := &streamer{
+		ctx:       ctx,
+		clientset: clientset,
+		opts:      opts,
+		active:    make(map[string]bool),
+		writer:    opts.Writer,
+	}
+	if s.writer == nil {
+		s.writer = os.Stdout
+	}
+	if len(opts.Container) != 0 {
+		var err error
+		if s.container, err = regexp.Compile(opts.Container); err != nil {
+			return fmt.Errorf("invalid container regexp: %w", err)
+		}
+	}
+
+	pods := clientset.CoreV1().Pods(namespace)
+	if !opts.Follow || opts.Previous {
+		podList, err := pods.List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return err
+		}
+		for i := range podList.Items {
+			s.tailPod(&podList.Items[i])
+		}
+		s.wg.Wait()
+		return s.err
+	}
+
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selector.String()
+			return pods.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector.String()
+			return pods.Watch(ctx, options)
+		},
+	}
+	_, informer := cache.NewInformer(lw, &corev1.Pod{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { s.tailPod(obj.(*corev1.Pod)) },
+		UpdateFunc: func(_, obj interface{}) { s.tailPod(obj.(*corev1.Pod)) },
+	})
+	informer.Run(ctx.Done())
+	s.wg.Wait()
+	return nil
+}
+
+type streamer struct {
+	ctx       context.Context
+	clientset kubernetes.Interface
+	opts      *Options
+	container *regexp.Regexp
+
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+	active map[string]bool
+	err    error
+	writer io.Writer
+}
+
+// tailPod starts streaming the containers of the pod that are not streamed.
+func (s *streamer) tailPod(pod *corev1.Pod) {
+	var statuses []corev1.ContainerStatus
+	if s.opts.InitContainers {
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	}
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	for _, status := range statuses {
+		if s.container != nil && !s.container.MatchString(status.Name) {
+			continue
+		}
+		if s.opts.Previous {
+			if status.LastTerminationState.Terminated == nil {
+				continue
+			}
+		} else if status.State.Running == nil && status.State.Terminated == nil {
+			// the container is waiting, it's streamed once started.
+			continue
+		}
+		// the restarted container is streamed again.
+		key := fmt.Sprintf("%s/%s/%s/%s/%d", pod.Namespace, pod.Name, pod.UID, status.Name, status.RestartCount)
+		s.mu.Lock()
+		if s.active[key] {
+			s.mu.Unlock()
+			continue
+		}
+		s.active[key] = true
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func(namespace, name, container string) {
+			defer s.wg.Done()
+			err := s.tail(namespace, name, container)
+			if err == nil || s.ctx.Err() != nil {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.opts.Follow {
+				// retry on the next pod update.
+				delete(s.active, key)
+				return
+			}
+			if s.err == nil {
+				s.err = fmt.Errorf("%s/%s: %w", name, container, err)
+			}
+		}(pod.Namespace, pod.Name, status.Name)
+	}
+}
+
+// tail streams the logs of the container.
+func (s *streamer) tail(namespace, pod, container string) error {
+	logOptions := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     s.opts.Follow && !s.opts.Previous,
+		Previous:   s.opts.Previous,
+		TailLines:  s.opts.TailLines,
+		Timestamps: s.opts.Timestamps,
+	}
+	if s.opts.Since > 0 {
+		seconds := int64(s.opts.Since.Seconds())
+		logOptions.SinceSeconds = &seconds
+	}
+	rc, err := s.clientset.CoreV1().Pods(namespace).GetLogs(pod, logOptions).Stream(s.ctx)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	err = ReadLines(rc, s.opts.Timestamps, func(line Line) bool {
+		line.Namespace, line.Pod, line.Container = namespace, pod, container
+		return s.deliver(line)
+	})
+	if err == nil && s.ctx.Err() != nil {
+		return s.ctx.Err()
+	}
+	return err
+}
+
+// ReadLines reads the log lines from r until EOF or fn returns false, the
+// timestamps at the beginning of the lines are parsed if timestamps is true.
+// The lines are not limited in length.
+func ReadLines(r io.Reader, timestamps bool, fn func(line Line) bool) error {
+	reader := bufio.NewReader(r)
+	for {
+		text, err := reader.ReadString('\n')
+		if len(text) != 0 {
+			line := Line{Text: strings.TrimRight(text, "\r\n")}
+			if timestamps {
+				line.Timestamp, line.Text = ParseTimestamp(line.Text)
+			}
+			if !fn(line) {
+				return nil
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ParseTimestamp parses the RFC3339 timestamp added by the kubelet at the
+// beginning of the log line, it returns zero time and the line unchanged if
+// the line doesn't start with a timestamp.
+func ParseTimestamp(text string) (time.Time, string) {
+	i := strings.IndexByte(text, ' ')
+	if i < 0 {
+		// the empty line only has the timestamp.
+		i = len(text)
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, text[:i])
+	if err != nil {
+		return time.Time{}, text
+	}
+	if i == len(text) {
+		return timestamp, ""
+	}
+	return timestamp, text[i+1:]
+}
+
+// deliver sends the line to the channel or writes it to the writer, it returns
+// false if the context is done.
+func (s *streamer) deliver(line Line) bool {
+	if s.opts.Lines != nil {
+		select {
+		case s.opts.Lines <- line:
+			return true
+		case <-s.ctx.Done():
+			return false
+		}
+	}
+	podPrefix, containerPrefix := line.Pod, line.Container
+	if s.opts.Color {
+		podPrefix, containerPrefix = colorize(line.Pod, line.Pod), colorize(line.Pod+"/"+line.Container, line.Container)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if line.Timestamp.IsZero() {
+		fmt.Fprintf(s.writer, "%s %s %s\n", podPrefix, containerPrefix, line.Text)
+	} else {
+		fmt.Fprintf(s.writer, "%s %s %s %s\n", podPrefix, containerPrefix, line.Timestamp.Format(time.RFC3339Nano), line.Text)
+	}
+	return s.ctx.Err() == nil
+}
+
+// colors are the ANSI colors of the prefixes, except black and white.
+var colors = []int{31, 32, 33, 34, 35, 36, 91, 92, 93, 94, 95, 96}
+
+// colorize colours the text by the color chosen by the key.
+func colorize(key, text string) string {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", colors[h.Sum32()%uint32(len(colors))], text)
+}
@@ -0,0 +1,83 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newPod(name string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Labels: map[string]string{"app": "nginx"}}}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: c})
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name: c, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}})
+	}
+	return pod
+}
+
+func TestStream(t *testing.T) {
+	waiting := newPod("nginx-3", "nginx")
+	waiting.Status.ContainerStatuses[0].State = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}
+	clientset := fake.NewSimpleClientset(newPod("nginx-1", "nginx", "sidecar"), newPod("nginx-2", "nginx"), waiting)
+
+	selector := labels.SelectorFromSet(labels.Set{"app": "nginx"})
+
+	lines := make(chan Line, 10)
+	if err := Stream(context.TODO(), clientset, "test", selector, &Options{Lines: lines}); err != nil {
+		t.Fatal(err)
+	}
+	close(lines)
+	var got []string
+	for line := range lines {
+		got = append(got, line.String())
+	}
+	sort.Strings(got)
+	expected := []string{"nginx-1 nginx fake logs", "nginx-1 sidecar fake logs", "nginx-2 nginx fake logs"}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected lines %q, got %q", expected, got)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := Stream(context.TODO(), clientset, "test", selector, &Options{Container: "^side", Writer: buf}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "nginx-1 sidecar fake logs\n" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	data := "2022-08-01T10:00:00.123456789Z starting nginx\n2022-08-01T10:00:01Z \nnot a timestamp\nlast line without newline"
+	var lines []Line
+	if err := ReadLines(strings.NewReader(data), true, func(line Line) bool {
+		lines = append(lines, line)
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d", len(lines))
+	}
+	expected := time.Date(2022, 8, 1, 10, 0, 0, 123456789, time.UTC)
+	if !lines[0].Timestamp.Equal(expected) || lines[0].Text != "starting nginx" {
+		t.Errorf("unexpected line %+v", lines[0])
+	}
+	if lines[1].Timestamp.IsZero() || lines[1].Text != "" {
+		t.Errorf("unexpected empty line %+v", lines[1])
+	}
+	if !lines[2].Timestamp.IsZero() || lines[2].Text != "not a timestamp" {
+		t.Errorf("unexpected line without timestamp %+v", lines[2])
+	}
+	if lines[3].Text != "last line without newline" {
+		t.Errorf("unexpected last line %+v", lines[3])
+	}
+}
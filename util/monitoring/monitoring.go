@@ -0,0 +1,171 @@
+/*
+Package monitoring exposes the prometheus metrics of handlers, it's opt-in
+and all metrics are registered on the registry provided by caller.
+
+	registry := prometheus.NewRegistry()
+	metrics, err := monitoring.New(registry)
+	if err != nil {
+	    ...
+	}
+	handler, err := deployment.New(ctx, "", "test", metrics.HandlerOption())
+
+Metrics:
+
+	k8s_handler_requests_total                 {verb, group, version, resource, code}
+	k8s_handler_request_duration_seconds       {verb, group, version, resource}
+	k8s_handler_active_watches                 {group, version, resource}
+	k8s_handler_watch_reconnects_total         {group, version, kind}
+	k8s_handler_informer_synced                {group, version, kind}
+	k8s_handler_informer_events_total          {group, version, kind, event}
+	k8s_handler_wait_duration_seconds          {group, version, kind, condition, result}
+
+The workqueue metrics are reported after metrics.SetWorkqueueProvider() is
+called, before the workqueues are created:
+
+	k8s_handler_workqueue_depth                             {name}
+	k8s_handler_workqueue_adds_total                        {name}
+	k8s_handler_workqueue_queue_duration_seconds            {name}
+	k8s_handler_workqueue_work_duration_seconds             {name}
+	k8s_handler_workqueue_unfinished_work_seconds           {name}
+	k8s_handler_workqueue_longest_running_processor_seconds {name}
+	k8s_handler_workqueue_retries_total                     {name}
+*/
+package monitoring
+
+import (
+	"errors"
+	"time"
+
+	"github.com/forbearing/k8s/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const metricsNamespace = "k8s_handler"
+
+// Metrics collects the prometheus metrics of handlers, one Metrics can be
+// shared by any number of handlers.
+type Metrics struct {
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	activeWatches   *prometheus.GaugeVec
+	watchReconnects *prometheus.CounterVec
+	informerSynced  *prometheus.GaugeVec
+	informerEvents  *prometheus.CounterVec
+	waitDuration    *prometheus.HistogramVec
+
+	workqueueDepth          *prometheus.GaugeVec
+	workqueueAdds           *prometheus.CounterVec
+	workqueueLatency        *prometheus.HistogramVec
+	workqueueWorkDuration   *prometheus.HistogramVec
+	workqueueUnfinishedWork *prometheus.GaugeVec
+	workqueueLongestRunning *prometheus.GaugeVec
+	workqueueRetries        *prometheus.CounterVec
+}
+
+var _ types.Observer = &Metrics{}
+
+// New creates the handler metrics and registers them on the registerer.
+func New(registerer prometheus.Registerer) (*Metrics, error) {
+	if registerer == nil {
+		return nil, errors.New("prometheus registerer is nil")
+	}
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "Number of requests sent to the kubernetes API server, partitioned by verb, GVR and status code.",
+		}, []string{"verb", "group", "version", "resource", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests sent to the kubernetes API server, partitioned by verb and GVR.",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+		}, []string{"verb", "group", "version", "resource"}),
+		activeWatches: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "active_watches",
+			Help:      "Number of watch connections currently open to the kubernetes API server.",
+		}, []string{"group", "version", "resource"}),
+		watchReconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "watch_reconnects_total",
+			Help:      "Number of watch reconnects after the watch closed by the kubernetes API server.",
+		}, []string{"group", "version", "kind"}),
+		informerSynced: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "informer_synced",
+			Help:      "Whether the informer caches synced (1) or failed to sync (0).",
+		}, []string{"group", "version", "kind"}),
+		informerEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "informer_events_total",
+			Help:      "Number of events delivered by informers, partitioned by event type.",
+		}, []string{"group", "version", "kind", "event"}),
+		waitDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "wait_duration_seconds",
+			Help:      "Duration of wait helpers such like WaitReady, partitioned by condition and result.",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12),
+		}, []string{"group", "version", "kind", "condition", "result"}),
+	}
+	newWorkqueueMetrics(m)
+	for _, c := range []prometheus.Collector{
+		m.requests,
+		m.requestDuration,
+		m.activeWatches,
+		m.watchReconnects,
+		m.informerSynced,
+		m.informerEvents,
+		m.waitDuration,
+		m.workqueueDepth,
+		m.workqueueAdds,
+		m.workqueueLatency,
+		m.workqueueWorkDuration,
+		m.workqueueUnfinishedWork,
+		m.workqueueLongestRunning,
+		m.workqueueRetries,
+	} {
+		if err := registerer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// HandlerOption returns the handler option that wraps the handler http
+// transport to collect request metrics and sets the handler observer.
+func (m *Metrics) HandlerOption() types.HandlerOption {
+	return func(config *types.HandlerConfig) {
+		types.WithTransport(m.WrapTransport)(config)
+		types.WithObserver(m)(config)
+	}
+}
+
+// WatchReconnected implements types.Observer.
+func (m *Metrics) WatchReconnected(gvk schema.GroupVersionKind) {
+	m.watchReconnects.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind).Inc()
+}
+
+// InformerSynced implements types.Observer.
+func (m *Metrics) InformerSynced(gvk schema.GroupVersionKind, synced bool) {
+	value := 0.0
+	if synced {
+		value = 1
+	}
+	m.informerSynced.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind).Set(value)
+}
+
+// InformerEvent implements types.Observer.
+func (m *Metrics) InformerEvent(gvk schema.GroupVersionKind, event string) {
+	m.informerEvents.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind, event).Inc()
+}
+
+// WaitDone implements types.Observer.
+func (m *Metrics) WaitDone(gvk schema.GroupVersionKind, condition string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.waitDuration.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind, condition, result).Observe(duration.Seconds())
+}
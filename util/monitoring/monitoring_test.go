@@ -0,0 +1,111 @@
+package monitoring
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseRequest(t *testing.T) {
+	tests := []struct {
+		method   string
+		url      string
+		expected requestInfo
+	}{
+		{http.MethodGet, "/api/v1/namespaces/test/pods/nginx", requestInfo{"get", "", "v1", "pods"}},
+		{http.MethodGet, "/api/v1/namespaces/test/pods", requestInfo{"list", "", "v1", "pods"}},
+		{http.MethodGet, "/api/v1/namespaces/test/pods/nginx/log", requestInfo{"get", "", "v1", "pods/log"}},
+		{http.MethodGet, "/apis/apps/v1/deployments?watch=true", requestInfo{"watch", "apps", "v1", "deployments"}},
+		{http.MethodGet, "/api/v1/watch/namespaces/test/pods", requestInfo{"watch", "", "v1", "pods"}},
+		{http.MethodGet, "/api/v1/namespaces/test", requestInfo{"get", "", "v1", "namespaces"}},
+		{http.MethodPut, "/api/v1/namespaces/test/finalize", requestInfo{"update", "", "v1", "namespaces/finalize"}},
+		{http.MethodPost, "/apis/apps/v1/namespaces/test/deployments", requestInfo{"create", "apps", "v1", "deployments"}},
+		{http.MethodPut, "/apis/apps/v1/namespaces/test/deployments/nginx/scale", requestInfo{"update", "apps", "v1", "deployments/scale"}},
+		{http.MethodPatch, "/api/v1/nodes/node1", requestInfo{"patch", "", "v1", "nodes"}},
+		{http.MethodDelete, "/apis/apps/v1/namespaces/test/deployments/nginx", requestInfo{"delete", "apps", "v1", "deployments"}},
+		{http.MethodDelete, "/apis/apps/v1/namespaces/test/deployments", requestInfo{"deletecollection", "apps", "v1", "deployments"}},
+		{http.MethodGet, "/version", requestInfo{"get", "", "", ""}},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.url, nil)
+		if info := parseRequest(req); info != test.expected {
+			t.Errorf("%s %s: expected %+v, got %+v", test.method, test.url, test.expected, info)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	m, err := New(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: m.WrapTransport(http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL + "/apis/apps/v1/namespaces/test/deployments?watch=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := testutil.ToFloat64(m.activeWatches.WithLabelValues("apps", "v1", "deployments")); v != 1 {
+		t.Fatalf("expected 1 active watch, got %v", v)
+	}
+	ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body.Close()
+	if v := testutil.ToFloat64(m.activeWatches.WithLabelValues("apps", "v1", "deployments")); v != 0 {
+		t.Fatalf("expected 0 active watch, got %v", v)
+	}
+	if v := testutil.ToFloat64(m.requests.WithLabelValues("watch", "apps", "v1", "deployments", "200")); v != 1 {
+		t.Fatalf("expected 1 request, got %v", v)
+	}
+
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	m.WatchReconnected(gvk)
+	m.InformerSynced(gvk, true)
+	if v := testutil.ToFloat64(m.watchReconnects.WithLabelValues("apps", "v1", "Deployment")); v != 1 {
+		t.Fatalf("expected 1 watch reconnect, got %v", v)
+	}
+	if v := testutil.ToFloat64(m.informerSynced.WithLabelValues("apps", "v1", "Deployment")); v != 1 {
+		t.Fatalf("expected informer synced, got %v", v)
+	}
+
+	if _, err := New(registry); err == nil {
+		t.Fatal("expected error when registering metrics twice")
+	}
+}
+
+func TestWorkqueueProvider(t *testing.T) {
+	m, err := New(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := workqueueProvider{m}
+	depth := provider.NewDepthMetric("test")
+	adds := provider.NewAddsMetric("test")
+	latency := provider.NewLatencyMetric("test")
+	depth.Inc()
+	depth.Inc()
+	depth.Dec()
+	adds.Inc()
+	latency.Observe(0.1)
+
+	if v := testutil.ToFloat64(m.workqueueDepth.WithLabelValues("test")); v != 1 {
+		t.Fatalf("expected depth 1, got %v", v)
+	}
+	if v := testutil.ToFloat64(m.workqueueAdds.WithLabelValues("test")); v != 1 {
+		t.Fatalf("expected 1 add, got %v", v)
+	}
+	if n := testutil.CollectAndCount(m.workqueueLatency); n != 1 {
+		t.Fatalf("expected 1 latency metric, got %d", n)
+	}
+}
@@ -0,0 +1,145 @@
+package monitoring
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WrapTransport wraps the http transport to collect the request count and
+// latency by verb/GVR/status code, and the number of active watches.
+// It can be used with types.WithTransport or rest.Config.Wrap directly.
+func (m *Metrics) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &roundTripper{metrics: m, delegate: rt}
+}
+
+type roundTripper struct {
+	metrics  *Metrics
+	delegate http.RoundTripper
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	info := parseRequest(req)
+	start := time.Now()
+	resp, err := rt.delegate.RoundTrip(req)
+	rt.metrics.requestDuration.WithLabelValues(info.verb, info.group, info.version, info.resource).
+		Observe(time.Since(start).Seconds())
+
+	code := "<error>"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	rt.metrics.requests.WithLabelValues(info.verb, info.group, info.version, info.resource, code).Inc()
+
+	// The watch connection is open until the response body is closed.
+	if err == nil && info.verb == "watch" && resp.StatusCode == http.StatusOK {
+		gauge := rt.metrics.activeWatches.WithLabelValues(info.group, info.version, info.resource)
+		gauge.Inc()
+		resp.Body = &watchBody{ReadCloser: resp.Body, done: gauge.Dec}
+	}
+	return resp, err
+}
+
+// watchBody calls done once when the watch response body is closed.
+type watchBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (b *watchBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
+
+// requestInfo is the verb and GVR of a request sent to kubernetes API server.
+type requestInfo struct {
+	verb     string
+	group    string
+	version  string
+	resource string
+}
+
+// parseRequest parses the verb and GVR from the request url, eg:
+//
+//	GET    /api/v1/namespaces/test/pods/nginx          get pods
+//	GET    /apis/apps/v1/deployments?watch=true        watch deployments
+//	DELETE /apis/apps/v1/namespaces/test/deployments   deletecollection deployments
+//	PUT    /apis/apps/v1/namespaces/test/deployments/nginx/scale
+//	                                                   update deployments/scale
+//
+// The resource of non-resource requests such like "/version" is empty.
+func parseRequest(req *http.Request) requestInfo {
+	info := requestInfo{}
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		info.version = parts[1]
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		info.group, info.version = parts[1], parts[2]
+		parts = parts[3:]
+	default:
+		// non-resource request.
+		info.verb = strings.ToLower(req.Method)
+		return info
+	}
+
+	watch := false
+	// deprecated watch path: /api/v1/watch/namespaces/test/pods
+	if len(parts) != 0 && parts[0] == "watch" {
+		watch = true
+		parts = parts[1:]
+	}
+	// "/api/v1/namespaces/{name}" and "/api/v1/namespaces/{name}/{status,finalize}"
+	// are the namespace resource itself, like the kubernetes API server does.
+	if len(parts) >= 3 && parts[0] == "namespaces" && parts[2] != "status" && parts[2] != "finalize" {
+		parts = parts[2:]
+	}
+	var name, subresource string
+	if len(parts) != 0 {
+		info.resource = parts[0]
+	}
+	if len(parts) >= 2 {
+		name = parts[1]
+	}
+	if len(parts) >= 3 {
+		subresource = parts[2]
+		info.resource += "/" + subresource
+	}
+	if v := req.URL.Query().Get("watch"); v == "true" || v == "1" {
+		watch = true
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		switch {
+		case watch:
+			info.verb = "watch"
+		case len(name) != 0:
+			info.verb = "get"
+		default:
+			info.verb = "list"
+		}
+	case http.MethodPost:
+		info.verb = "create"
+	case http.MethodPut:
+		info.verb = "update"
+	case http.MethodPatch:
+		info.verb = "patch"
+	case http.MethodDelete:
+		if len(name) != 0 {
+			info.verb = "delete"
+		} else {
+			info.verb = "deletecollection"
+		}
+	default:
+		info.verb = strings.ToLower(req.Method)
+	}
+	return info
+}
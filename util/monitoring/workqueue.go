@@ -0,0 +1,90 @@
+package monitoring
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// SetWorkqueueProvider sets the workqueue metrics provider of client-go, so
+// the workqueues created after it, such like the rate limiting queues of the
+// controllers, report their metrics. The provider of client-go can only be
+// set once, the later calls are ignored by client-go.
+func (m *Metrics) SetWorkqueueProvider() {
+	workqueue.SetProvider(workqueueProvider{m})
+}
+
+// workqueueProvider implements workqueue.MetricsProvider, the metrics are
+// partitioned by the name of the workqueue.
+type workqueueProvider struct {
+	m *Metrics
+}
+
+var _ workqueue.MetricsProvider = workqueueProvider{}
+
+func (p workqueueProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return p.m.workqueueDepth.WithLabelValues(name)
+}
+
+func (p workqueueProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return p.m.workqueueAdds.WithLabelValues(name)
+}
+
+func (p workqueueProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return p.m.workqueueLatency.WithLabelValues(name)
+}
+
+func (p workqueueProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return p.m.workqueueWorkDuration.WithLabelValues(name)
+}
+
+func (p workqueueProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.m.workqueueUnfinishedWork.WithLabelValues(name)
+}
+
+func (p workqueueProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.m.workqueueLongestRunning.WithLabelValues(name)
+}
+
+func (p workqueueProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return p.m.workqueueRetries.WithLabelValues(name)
+}
+
+func newWorkqueueMetrics(m *Metrics) {
+	m.workqueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "workqueue_depth",
+		Help:      "Current depth of the workqueue.",
+	}, []string{"name"})
+	m.workqueueAdds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "workqueue_adds_total",
+		Help:      "Number of adds handled by the workqueue.",
+	}, []string{"name"})
+	m.workqueueLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "workqueue_queue_duration_seconds",
+		Help:      "How long an item stays in the workqueue before being requested.",
+		Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 10),
+	}, []string{"name"})
+	m.workqueueWorkDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "workqueue_work_duration_seconds",
+		Help:      "How long processing an item from the workqueue takes.",
+		Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 10),
+	}, []string{"name"})
+	m.workqueueUnfinishedWork = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "workqueue_unfinished_work_seconds",
+		Help:      "How many seconds of work has been done that is in progress and hasn't been observed by work_duration.",
+	}, []string{"name"})
+	m.workqueueLongestRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "workqueue_longest_running_processor_seconds",
+		Help:      "How many seconds the longest running processor of the workqueue has been running.",
+	}, []string{"name"})
+	m.workqueueRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "workqueue_retries_total",
+		Help:      "Number of retries handled by the workqueue.",
+	}, []string{"name"})
+}
@@ -0,0 +1,360 @@
+/*
+Package podtemplate compares pod templates and explains what changed between
+them, such like the rollouts of a deployment:
+
+	diff := podtemplate.Compare(&oldRS.Spec.Template, &newRS.Spec.Template)
+	for _, change := range diff.Changes {
+	    fmt.Println(change) // container nginx: image modified: nginx:1.21 -> nginx:1.23
+	}
+*/
+package podtemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+)
+
+// ChangeType is the type of the change.
+type ChangeType string
+
+const (
+	Added    ChangeType = "added"
+	Removed  ChangeType = "removed"
+	Modified ChangeType = "modified"
+)
+
+// Field is the changed field of the pod template.
+type Field string
+
+const (
+	FieldLabel          Field = "label"
+	FieldAnnotation     Field = "annotation"
+	FieldContainer      Field = "container"
+	FieldInitContainer  Field = "initContainer"
+	FieldImage          Field = "image"
+	FieldCommand        Field = "command"
+	FieldArgs           Field = "args"
+	FieldEnv            Field = "env"
+	FieldEnvFrom        Field = "envFrom"
+	FieldResources      Field = "resources"
+	FieldLivenessProbe  Field = "livenessProbe"
+	FieldReadinessProbe Field = "readinessProbe"
+	FieldStartupProbe   Field = "startupProbe"
+	FieldPorts          Field = "ports"
+	FieldVolumeMount    Field = "volumeMount"
+	FieldVolume         Field = "volume"
+	// FieldOther is the other fields of the container not listed above.
+	FieldOther Field = "other"
+	// FieldSpec is the fields of the pod spec other than containers and volumes,
+	// the Name of the change is the json name of the field, such like "nodeSelector".
+	FieldSpec Field = "spec"
+)
+
+// Change is a change of the pod template.
+type Change struct {
+	// Container is the name of the container changed, empty for the changes
+	// of the pod.
+	Container string
+	Field     Field
+	// Name is the key of the changed item, such like the name of the env, the
+	// volume, the label, or the resource such like "limits.cpu".
+	Name string
+	Type ChangeType
+	// From and To are the values before and after the change, the complex
+	// values are in compact json.
+	From string
+	To   string
+}
+
+func (c Change) String() string {
+	var b strings.Builder
+	if len(c.Container) != 0 {
+		fmt.Fprintf(&b, "container %s: ", c.Container)
+	}
+	b.WriteString(string(c.Field))
+	if len(c.Name) != 0 {
+		fmt.Fprintf(&b, " %s", c.Name)
+	}
+	fmt.Fprintf(&b, " %s", c.Type)
+	switch c.Type {
+	case Added:
+		fmt.Fprintf(&b, ": %s", c.To)
+	case Removed:
+		fmt.Fprintf(&b, ": %s", c.From)
+	case Modified:
+		fmt.Fprintf(&b, ": %s -> %s", c.From, c.To)
+	}
+	return b.String()
+}
+
+// Diff is the structured difference between two pod templates.
+type Diff struct {
+	Changes []Change
+}
+
+// Empty reports whether the pod templates are the same.
+func (d *Diff) Empty() bool { return d == nil || len(d.Changes) == 0 }
+
+// Images returns the image changes, a rollout caused by other changes has no
+// image changes.
+func (d *Diff) Images() []Change {
+	if d == nil {
+		return nil
+	}
+	var changes []Change
+	for _, c := range d.Changes {
+		if c.Field == FieldImage {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+func (d *Diff) String() string {
+	if d.Empty() {
+		return "no changes"
+	}
+	lines := make([]string, 0, len(d.Changes))
+	for _, c := range d.Changes {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Compare returns the difference from the pod template "from" to "to". The
+// "pod-template-hash" label added to the replicasets is ignored.
+func Compare(from, to *corev1.PodTemplateSpec) *Diff {
+	if from == nil {
+		from = &corev1.PodTemplateSpec{}
+	}
+	if to == nil {
+		to = &corev1.PodTemplateSpec{}
+	}
+	d := &Diff{}
+	d.compareMap("", FieldLabel, withoutHash(from.Labels), withoutHash(to.Labels))
+	d.compareMap("", FieldAnnotation, from.Annotations, to.Annotations)
+	d.compareContainers(FieldInitContainer, from.Spec.InitContainers, to.Spec.InitContainers)
+	d.compareContainers(FieldContainer, from.Spec.Containers, to.Spec.Containers)
+	d.compareVolumes(from.Spec.Volumes, to.Spec.Volumes)
+	d.compareSpec(&from.Spec, &to.Spec)
+	return d
+}
+
+func withoutHash(labels map[string]string) map[string]string {
+	if _, ok := labels[appsv1.DefaultDeploymentUniqueLabelKey]; !ok {
+		return labels
+	}
+	result := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if k != appsv1.DefaultDeploymentUniqueLabelKey {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+func (d *Diff) add(c Change) { d.Changes = append(d.Changes, c) }
+
+// compareMap compares the key/values in the order of keys.
+func (d *Diff) compareMap(container string, field Field, from, to map[string]string) {
+	for _, k := range sortedKeys(from, to) {
+		v1, ok1 := from[k]
+		v2, ok2 := to[k]
+		switch {
+		case !ok1:
+			d.add(Change{Container: container, Field: field, Name: k, Type: Added, To: v2})
+		case !ok2:
+			d.add(Change{Container: container, Field: field, Name: k, Type: Removed, From: v1})
+		case v1 != v2:
+			d.add(Change{Container: container, Field: field, Name: k, Type: Modified, From: v1, To: v2})
+		}
+	}
+}
+
+// compareValue compares the complex values by semantic equality.
+func (d *Diff) compareValue(container string, field Field, name string, from, to interface{}) {
+	if apiequality.Semantic.DeepEqual(from, to) {
+		return
+	}
+	fromStr, toStr := toJSON(from), toJSON(to)
+	switch {
+	case isEmpty(from):
+		d.add(Change{Container: container, Field: field, Name: name, Type: Added, To: toStr})
+	case isEmpty(to):
+		d.add(Change{Container: container, Field: field, Name: name, Type: Removed, From: fromStr})
+	default:
+		d.add(Change{Container: container, Field: field, Name: name, Type: Modified, From: fromStr, To: toStr})
+	}
+}
+
+func (d *Diff) compareContainers(field Field, from, to []corev1.Container) {
+	fromMap := make(map[string]*corev1.Container, len(from))
+	for i := range from {
+		fromMap[from[i].Name] = &from[i]
+	}
+	toMap := make(map[string]*corev1.Container, len(to))
+	for i := range to {
+		toMap[to[i].Name] = &to[i]
+	}
+	for _, c := range from {
+		if _, ok := toMap[c.Name]; !ok {
+			d.add(Change{Field: field, Name: c.Name, Type: Removed, From: c.Image})
+		}
+	}
+	for i := range to {
+		c2 := &to[i]
+		c1, ok := fromMap[c2.Name]
+		if !ok {
+			d.add(Change{Field: field, Name: c2.Name, Type: Added, To: c2.Image})
+			continue
+		}
+		d.compareContainer(c1, c2)
+	}
+}
+
+func (d *Diff) compareContainer(from, to *corev1.Container) {
+	name := to.Name
+	if from.Image != to.Image {
+		d.add(Change{Container: name, Field: FieldImage, Type: Modified, From: from.Image, To: to.Image})
+	}
+	d.compareValue(name, FieldCommand, "", from.Command, to.Command)
+	d.compareValue(name, FieldArgs, "", from.Args, to.Args)
+	d.compareMap(name, FieldEnv, envMap(from.Env), envMap(to.Env))
+	d.compareValue(name, FieldEnvFrom, "", from.EnvFrom, to.EnvFrom)
+	d.compareMap(name, FieldResources, resourceMap(from.Resources), resourceMap(to.Resources))
+	d.compareValue(name, FieldLivenessProbe, "", from.LivenessProbe, to.LivenessProbe)
+	d.compareValue(name, FieldReadinessProbe, "", from.ReadinessProbe, to.ReadinessProbe)
+	d.compareValue(name, FieldStartupProbe, "", from.StartupProbe, to.StartupProbe)
+	d.compareValue(name, FieldPorts, "", from.Ports, to.Ports)
+	d.compareMap(name, FieldVolumeMount, mountMap(from.VolumeMounts), mountMap(to.VolumeMounts))
+
+	// the other fields of the container.
+	c1, c2 := from.DeepCopy(), to.DeepCopy()
+	for _, c := range []*corev1.Container{c1, c2} {
+		c.Image, c.Command, c.Args, c.Env, c.EnvFrom = "", nil, nil, nil, nil
+		c.Resources, c.Ports, c.VolumeMounts = corev1.ResourceRequirements{}, nil, nil
+		c.LivenessProbe, c.ReadinessProbe, c.StartupProbe = nil, nil, nil
+	}
+	d.compareValue(name, FieldOther, "", c1, c2)
+}
+
+func (d *Diff) compareVolumes(from, to []corev1.Volume) {
+	fromMap := make(map[string]corev1.Volume, len(from))
+	for _, v := range from {
+		fromMap[v.Name] = v
+	}
+	toMap := make(map[string]corev1.Volume, len(to))
+	for _, v := range to {
+		toMap[v.Name] = v
+	}
+	names := make(map[string]string, len(fromMap)+len(toMap))
+	for _, v := range append(from[:len(from):len(from)], to...) {
+		names[v.Name] = ""
+	}
+	for _, k := range sortedKeys(names) {
+		v1, ok1 := fromMap[k]
+		v2, ok2 := toMap[k]
+		switch {
+		case !ok1:
+			d.add(Change{Field: FieldVolume, Name: k, Type: Added, To: toJSON(v2.VolumeSource)})
+		case !ok2:
+			d.add(Change{Field: FieldVolume, Name: k, Type: Removed, From: toJSON(v1.VolumeSource)})
+		default:
+			d.compareValue("", FieldVolume, k, v1.VolumeSource, v2.VolumeSource)
+		}
+	}
+}
+
+// compareSpec compares the fields of the pod spec other than containers and
+// volumes, by the json names of the fields.
+func (d *Diff) compareSpec(from, to *corev1.PodSpec) {
+	s1, s2 := from.DeepCopy(), to.DeepCopy()
+	for _, s := range []*corev1.PodSpec{s1, s2} {
+		s.Containers, s.InitContainers, s.Volumes = nil, nil, nil
+	}
+	if apiequality.Semantic.DeepEqual(s1, s2) {
+		return
+	}
+	v1, v2 := reflect.ValueOf(s1).Elem(), reflect.ValueOf(s2).Elem()
+	for i := 0; i < v1.NumField(); i++ {
+		name := strings.Split(v1.Type().Field(i).Tag.Get("json"), ",")[0]
+		d.compareValue("", FieldSpec, name, v1.Field(i).Interface(), v2.Field(i).Interface())
+	}
+}
+
+func envMap(envs []corev1.EnvVar) map[string]string {
+	m := make(map[string]string, len(envs))
+	for _, env := range envs {
+		if env.ValueFrom != nil {
+			m[env.Name] = toJSON(env.ValueFrom)
+		} else {
+			m[env.Name] = env.Value
+		}
+	}
+	return m
+}
+
+func resourceMap(r corev1.ResourceRequirements) map[string]string {
+	m := make(map[string]string, len(r.Limits)+len(r.Requests))
+	for k, v := range r.Limits {
+		m["limits."+string(k)] = v.String()
+	}
+	for k, v := range r.Requests {
+		m["requests."+string(k)] = v.String()
+	}
+	return m
+}
+
+func mountMap(mounts []corev1.VolumeMount) map[string]string {
+	m := make(map[string]string, len(mounts))
+	for _, mount := range mounts {
+		m[mount.MountPath] = toJSON(mount)
+	}
+	return m
+}
+
+// sortedKeys returns the sorted union of the keys of the maps.
+func sortedKeys(maps ...map[string]string) []string {
+	set := make(map[string]struct{})
+	for _, m := range maps {
+		for k := range m {
+			set[k] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// isEmpty reports whether the value is nil or zero.
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil()
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
@@ -0,0 +1,68 @@
+package podtemplate
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTemplate() *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nginx", "pod-template-hash": "5d59d67564"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "nginx",
+				Image: "nginx:1.21",
+				Env:   []corev1.EnvVar{{Name: "MODE", Value: "prod"}},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				},
+			}},
+			Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	from := newTemplate()
+	if diff := Compare(from, newTemplate()); !diff.Empty() {
+		t.Fatalf("expected no changes, got %s", diff)
+	}
+
+	to := newTemplate()
+	delete(to.Labels, "pod-template-hash")
+	c := &to.Spec.Containers[0]
+	c.Image = "nginx:1.23"
+	c.Env = []corev1.EnvVar{{Name: "MODE", Value: "debug"}, {Name: "LOG_LEVEL", Value: "info"}}
+	c.Resources.Limits[corev1.ResourceCPU] = resource.MustParse("0.5") // the same quantity
+	c.Resources.Limits[corev1.ResourceMemory] = resource.MustParse("128Mi")
+	c.ReadinessProbe = &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{}}}
+	to.Spec.Volumes = nil
+	to.Spec.Containers = append(to.Spec.Containers, corev1.Container{Name: "sidecar", Image: "busybox"})
+	to.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+
+	expected := []string{
+		"container nginx: image modified: nginx:1.21 -> nginx:1.23",
+		"container nginx: env LOG_LEVEL added: info",
+		"container nginx: env MODE modified: prod -> debug",
+		"container nginx: resources limits.memory added: 128Mi",
+		`container nginx: readinessProbe added: {"tcpSocket":{"port":0}}`,
+		"container sidecar added: busybox",
+		"volume data removed: {\"emptyDir\":{}}",
+		`spec nodeSelector added: {"disk":"ssd"}`,
+	}
+	diff := Compare(from, to)
+	if len(diff.Changes) != len(expected) {
+		t.Fatalf("expected %d changes, got:\n%s", len(expected), diff)
+	}
+	for i, change := range diff.Changes {
+		if change.String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], change.String())
+		}
+	}
+	if images := diff.Images(); len(images) != 1 || images[0].To != "nginx:1.23" {
+		t.Errorf("unexpected image changes: %v", images)
+	}
+}
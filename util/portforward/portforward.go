@@ -0,0 +1,478 @@
+/*
+Package portforward forwards local ports to a pod, or to a ready pod backing a
+service or a workload. It supports multiple ports, random local ports, named
+ports and service ports, and reconnects to the new pod if the pod is replaced:
+
+	target := &portforward.Target{Selector: selector, Service: svc}
+	f, err := portforward.Forward(ctx, config, clientset, "test", target, []string{":http", "9090:9090"}, &portforward.Options{Reconnect: true})
+	if err := f.WaitReady(); err != nil {
+		return err
+	}
+	ports, _ := f.Ports()
+	fmt.Println(ports[0].Local) // the random local port
+
+The pod, deployment and service handlers have ForwardPorts() built on it.
+*/
+package portforward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientportforward "k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+var (
+	// ErrNoReadyPod is returned when no ready pod is selected by the target.
+	ErrNoReadyPod = errors.New("no ready pod")
+	// ErrLostConnection is returned when the connection to the pod is lost and
+	// Options.Reconnect is false.
+	ErrLostConnection = errors.New("lost connection to pod")
+)
+
+const (
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// Target is the pod the ports are forwarded to.
+type Target struct {
+	// Pod is the name of the pod.
+	Pod string
+	// Selector selects a running and ready pod if Pod is empty.
+	Selector labels.Selector
+	// Service translates the remote ports as the ports of the service to
+	// their target ports if it's not nil.
+	Service *corev1.Service
+}
+
+// Options configures the port forwarding.
+type Options struct {
+	// Addresses are the local addresses to listen on, default to localhost.
+	Addresses []string
+	// Reconnect reconnects to the pod, or a new pod selected by the target,
+	// when the connection is lost or the pod is deleted, until the context is
+	// done. The local ports are kept.
+	Reconnect bool
+	// Out and ErrOut receive the messages of the forwarder, default to discard.
+	Out    io.Writer
+	ErrOut io.Writer
+}
+
+// Port is a forwarded port.
+type Port struct {
+	Local  uint16
+	Remote uint16
+}
+
+// Forwarder forwards the ports until the context is done or Close is called.
+type Forwarder struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	config    *rest.Config
+	clientset kubernetes.Interface
+	namespace string
+	target    *Target
+	specs     []portSpec
+	opts      *Options
+
+	ready     chan struct{}
+	readyOnce sync.Once
+	done      chan struct{}
+
+	mu    sync.Mutex
+	pod   string
+	ports []Port
+	err   error
+}
+
+// portSpec is the "[local:]remote" port, the remote port may be a name.
+type portSpec struct {
+	local  uint16
+	remote string
+}
+
+// Forward starts forwarding the ports to the target in background. The ports
+// are in the format of "kubectl port-forward": "8080:80" forwards the local
+// port 8080 to 80, "80" forwards the local port 80 to 80, ":80" or "0:80"
+// forwards a random local port to 80. The remote port can be the name of a
+// container port, or the port or the name of a service port if
+// target.Service is set.
+func Forward(ctx context.Context, config *rest.Config, clientset kubernetes.Interface,
+	namespace string, target *Target, ports []string, opts *Options) (*Forwarder, error) {
+	if target == nil || (len(target.Pod) == 0 && target.Selector == nil) {
+		return nil, errors.New("no target pod or selector")
+	}
+	if len(ports) == 0 {
+		return nil, errors.New("no ports")
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	specs, err := parsePorts(ports)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	f := &Forwarder{
+		ctx:       ctx,
+		cancel:    cancel,
+		config:    config,
+		clientset: clientset,
+		namespace: namespace,
+		target:    target,
+		specs:     specs,
+		opts:      opts,
+		ready:     make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go f.run()
+	return f, nil
+}
+
+// Ready is closed when the ports are forwarded for the first time.
+func (f *Forwarder) Ready() <-chan struct{} { return f.ready }
+
+// Done is closed when the forwarding stopped.
+func (f *Forwarder) Done() <-chan struct{} { return f.done }
+
+// WaitReady waits for the ports to be forwarded, it returns the error if the
+// forwarding stopped before.
+func (f *Forwarder) WaitReady() error {
+	select {
+	case <-f.ready:
+		return nil
+	case <-f.done:
+		if err := f.Err(); err != nil {
+			return err
+		}
+		return context.Canceled
+	}
+}
+
+// Wait waits for the forwarding to stop and returns the error.
+func (f *Forwarder) Wait() error {
+	<-f.done
+	return f.Err()
+}
+
+// Close stops the forwarding and waits for it.
+func (f *Forwarder) Close() {
+	f.cancel()
+	<-f.done
+}
+
+// Err returns the error that stopped the forwarding, it's nil if the context
+// is done or the forwarder is closed.
+func (f *Forwarder) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// Ports returns the forwarded ports, the random local ports are resolved.
+func (f *Forwarder) Ports() ([]Port, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.ports == nil {
+		return nil, errors.New("ports are not forwarded")
+	}
+	return append([]Port(nil), f.ports...), nil
+}
+
+// Pod returns the name of the pod the ports are forwarded to currently.
+func (f *Forwarder) Pod() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pod
+}
+
+func (f *Forwarder) run() {
+	defer close(f.done)
+	defer f.cancel()
+	backoff := minBackoff
+	for {
+		ready, err := f.forward()
+		if f.ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = ErrLostConnection
+		}
+		if !f.opts.Reconnect {
+			f.mu.Lock()
+			f.err = err
+			f.mu.Unlock()
+			return
+		}
+		if f.opts.ErrOut != nil {
+			fmt.Fprintf(f.opts.ErrOut, "reconnecting in %s: %v\n", backoff, err)
+		}
+		if ready {
+			backoff = minBackoff
+		}
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// forward forwards the ports to the pod selected by the target until the
+// connection is lost, the pod is deleted or the context is done. It reports
+// whether the ports were forwarded.
+func (f *Forwarder) forward() (bool, error) {
+	pod, err := f.selectPod()
+	if err != nil {
+		return false, err
+	}
+	ports := make([]string, 0, len(f.specs))
+	for _, spec := range f.specs {
+		remote, err := resolvePort(spec.remote, pod, f.target.Service)
+		if err != nil {
+			return false, err
+		}
+		ports = append(ports, fmt.Sprintf("%d:%d", spec.local, remote))
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(f.config)
+	if err != nil {
+		return false, err
+	}
+	req := f.clientset.CoreV1().RESTClient().Post().
+		Namespace(pod.Namespace).
+		Resource("pods").
+		Name(pod.Name).
+		SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	addresses := f.opts.Addresses
+	if len(addresses) == 0 {
+		addresses = []string{"localhost"}
+	}
+	out, errOut := f.opts.Out, f.opts.ErrOut
+	if out == nil {
+		out = ioutil.Discard
+	}
+	if errOut == nil {
+		errOut = ioutil.Discard
+	}
+	stopCh, readyCh := make(chan struct{}), make(chan struct{})
+	pf, err := clientportforward.NewOnAddresses(dialer, addresses, ports, stopCh, readyCh, out, errOut)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithCancel(f.ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		// the forwarder doesn't stop when the pod is deleted.
+		f.watchPod(ctx, pod)
+		close(stopCh)
+	}()
+	ready := false
+	go func() {
+		defer wg.Done()
+		select {
+		case <-readyCh:
+		case <-ctx.Done():
+			return
+		}
+		forwarded, err := pf.GetPorts()
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.pod = pod.Name
+		f.ports = f.ports[:0]
+		for i, port := range forwarded {
+			f.ports = append(f.ports, Port{Local: port.Local, Remote: port.Remote})
+			// keep the local ports when reconnecting.
+			f.specs[i].local = port.Local
+		}
+		ready = true
+		f.mu.Unlock()
+		f.readyOnce.Do(func() { close(f.ready) })
+	}()
+
+	err = pf.ForwardPorts()
+	cancel()
+	wg.Wait()
+	return ready, err
+}
+
+// selectPod returns the pod of the target.
+func (f *Forwarder) selectPod() (*corev1.Pod, error) {
+	pods := f.clientset.CoreV1().Pods(f.namespace)
+	if len(f.target.Pod) != 0 {
+		pod, err := pods.Get(f.ctx, f.target.Pod, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			return nil, fmt.Errorf("pod %s/%s is not running", pod.Namespace, pod.Name)
+		}
+		return pod, nil
+	}
+	podList, err := pods.List(f.ctx, metav1.ListOptions{LabelSelector: f.target.Selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return selectReadyPod(podList.Items, f.target.Selector)
+}
+
+// selectReadyPod returns the oldest running and ready pod.
+func selectReadyPod(pods []corev1.Pod, selector labels.Selector) (*corev1.Pod, error) {
+	var ready []*corev1.Pod
+	for i := range pods {
+		if isReady(&pods[i]) {
+			ready = append(ready, &pods[i])
+		}
+	}
+	if len(ready) == 0 {
+		return nil, fmt.Errorf("%w selected by %q", ErrNoReadyPod, selector)
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		if !ready[i].CreationTimestamp.Equal(&ready[j].CreationTimestamp) {
+			return ready[i].CreationTimestamp.Before(&ready[j].CreationTimestamp)
+		}
+		return ready[i].Name < ready[j].Name
+	})
+	return ready[0], nil
+}
+
+func isReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// watchPod returns when the pod is deleted, stops running, or ctx is done.
+func (f *Forwarder) watchPod(ctx context.Context, pod *corev1.Pod) {
+	watcher, err := f.clientset.CoreV1().Pods(pod.Namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", pod.Name).String(),
+		ResourceVersion: pod.ResourceVersion,
+	})
+	if err != nil {
+		<-ctx.Done()
+		return
+	}
+	defer watcher.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				// the watch is closed by the server, rely on the connection.
+				<-ctx.Done()
+				return
+			}
+			switch event.Type {
+			case watch.Deleted:
+				return
+			case watch.Modified:
+				p, ok := event.Object.(*corev1.Pod)
+				if ok && (p.UID != pod.UID || p.DeletionTimestamp != nil || p.Status.Phase != corev1.PodRunning) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// parsePorts parses the ports in the format "[local:]remote".
+func parsePorts(ports []string) ([]portSpec, error) {
+	specs := make([]portSpec, 0, len(ports))
+	for _, port := range ports {
+		local, remote := port, port
+		if i := strings.Index(port, ":"); i >= 0 {
+			local, remote = port[:i], port[i+1:]
+		}
+		if len(remote) == 0 {
+			return nil, fmt.Errorf("invalid port %q: empty remote port", port)
+		}
+		spec := portSpec{remote: remote}
+		if len(local) != 0 {
+			n, err := strconv.ParseUint(local, 10, 16)
+			if err != nil {
+				if local == remote {
+					// the named port is forwarded from a random local port.
+					specs = append(specs, spec)
+					continue
+				}
+				return nil, fmt.Errorf("invalid local port %q: %w", port, err)
+			}
+			spec.local = uint16(n)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// resolvePort resolves the remote port to the container port of the pod, the
+// port is translated to the target port first if svc is not nil.
+func resolvePort(remote string, pod *corev1.Pod, svc *corev1.Service) (uint16, error) {
+	port := intstr.Parse(remote)
+	if svc != nil {
+		found := false
+		for _, sp := range svc.Spec.Ports {
+			if (port.Type == intstr.Int && sp.Port == port.IntVal) || (port.Type == intstr.String && sp.Name == port.StrVal) {
+				port = sp.TargetPort
+				if port.Type == intstr.Int && port.IntVal == 0 {
+					port = intstr.FromInt(int(sp.Port))
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("service %s/%s has no port %q", svc.Namespace, svc.Name, remote)
+		}
+	}
+	if port.Type == intstr.Int {
+		if port.IntVal <= 0 || port.IntVal > 65535 {
+			return 0, fmt.Errorf("invalid remote port %q", remote)
+		}
+		return uint16(port.IntVal), nil
+	}
+	for _, c := range pod.Spec.Containers {
+		for _, cp := range c.Ports {
+			if cp.Name == port.StrVal {
+				return uint16(cp.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %s/%s has no port named %q", pod.Namespace, pod.Name, port.StrVal)
+}
@@ -0,0 +1,97 @@
+package portforward
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestParsePorts(t *testing.T) {
+	specs, err := parsePorts([]string{"8080:80", "80", ":http", "0:9090", "metrics"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []portSpec{{8080, "80"}, {80, "80"}, {0, "http"}, {0, "9090"}, {0, "metrics"}}
+	for i := range expected {
+		if specs[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], specs[i])
+		}
+	}
+	for _, port := range []string{"8080:", "abc:80", "70000:80"} {
+		if _, err := parsePorts([]string{port}); err == nil {
+			t.Errorf("expected error for %q", port)
+		}
+	}
+}
+
+func TestResolvePort(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Name:  "nginx",
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+	}}}}
+	svc := &corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+		{Name: "web", Port: 80, TargetPort: intstr.FromString("http")},
+		{Name: "metrics", Port: 9090, TargetPort: intstr.FromInt(9100)},
+		{Name: "admin", Port: 8443},
+	}}}
+	tests := []struct {
+		remote   string
+		svc      *corev1.Service
+		expected uint16
+	}{
+		{"80", nil, 80},
+		{"http", nil, 8080},
+		{"80", svc, 8080},
+		{"web", svc, 8080},
+		{"metrics", svc, 9100},
+		{"8443", svc, 8443},
+	}
+	for _, test := range tests {
+		port, err := resolvePort(test.remote, pod, test.svc)
+		if err != nil {
+			t.Errorf("resolve %q: %v", test.remote, err)
+			continue
+		}
+		if port != test.expected {
+			t.Errorf("resolve %q: expected %d, got %d", test.remote, test.expected, port)
+		}
+	}
+	if _, err := resolvePort("grpc", pod, nil); err == nil {
+		t.Error("expected error for unknown named port")
+	}
+	if _, err := resolvePort("8080", pod, svc); err == nil {
+		t.Error("expected error for unknown service port")
+	}
+}
+
+func TestSelectReadyPod(t *testing.T) {
+	newPod := func(name string, age time.Duration, ready bool) corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(time.Now().Add(-age))},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+	selector := labels.Everything()
+	pod, err := selectReadyPod([]corev1.Pod{newPod("nginx-1", time.Hour, false), newPod("nginx-2", time.Minute, true), newPod("nginx-3", 2*time.Minute, true)}, selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pod.Name != "nginx-3" {
+		t.Errorf("expected the oldest ready pod, got %s", pod.Name)
+	}
+	if _, err := selectReadyPod([]corev1.Pod{newPod("nginx-1", time.Hour, false)}, selector); !errors.Is(err, ErrNoReadyPod) {
+		t.Errorf("expected ErrNoReadyPod, got %v", err)
+	}
+}
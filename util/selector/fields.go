@@ -0,0 +1,224 @@
+package selector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/forbearing/k8s/types"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+/*
+reference:
+	https://kubernetes.io/docs/concepts/overview/working-with-objects/field-selectors/
+	https://github.com/kubernetes/kubernetes/blob/master/pkg/registry/core/pod/strategy.go (ToSelectableFields)
+*/
+
+// KindEvent is the Event Kind name, Event is not supported by a typed handler
+// but it's the most common k8s resource selected by field.
+const KindEvent = "Event"
+
+// metadataFields are the fields supported by all k8s resources.
+var metadataFields = []string{"metadata.name", "metadata.namespace"}
+
+// SupportedFields contains the fields supported by field selector for every
+// k8s resource Kind, besides "metadata.name" and "metadata.namespace" which
+// are supported by all k8s resources. Custom resources only support
+// "metadata.name" and "metadata.namespace".
+//
+// You can add the fields supported by your k8s resources.
+var SupportedFields = map[string][]string{
+	types.KindPod: {
+		"spec.nodeName",
+		"spec.restartPolicy",
+		"spec.schedulerName",
+		"spec.serviceAccountName",
+		"status.phase",
+		"status.podIP",
+		"status.nominatedNodeName",
+	},
+	types.KindNode:                  {"spec.unschedulable"},
+	types.KindNamespace:             {"status.phase"},
+	types.KindSecret:                {"type"},
+	types.KindReplicaSet:            {"status.replicas"},
+	types.KindReplicationController: {"status.replicas"},
+	types.KindJob:                   {"status.successful"},
+	KindEvent: {
+		"involvedObject.kind",
+		"involvedObject.namespace",
+		"involvedObject.name",
+		"involvedObject.uid",
+		"involvedObject.apiVersion",
+		"involvedObject.resourceVersion",
+		"involvedObject.fieldPath",
+		"reason",
+		"reportingComponent",
+		"source",
+		"type",
+	},
+}
+
+// builtinGroups are the API groups of the built-in k8s resource Kinds, the
+// custom resources may have the same Kind in other groups.
+var builtinGroups = map[string]string{
+	types.KindClusterRole:           "rbac.authorization.k8s.io",
+	types.KindClusterRoleBinding:    "rbac.authorization.k8s.io",
+	types.KindConfigMap:             "",
+	types.KindCronJob:               "batch",
+	types.KindDaemonSet:             "apps",
+	types.KindDeployment:            "apps",
+	types.KindIngress:               "networking.k8s.io",
+	types.KindIngressClass:          "networking.k8s.io",
+	types.KindJob:                   "batch",
+	types.KindNamespace:             "",
+	types.KindNetworkPolicy:         "networking.k8s.io",
+	types.KindNode:                  "",
+	types.KindPersistentVolume:      "",
+	types.KindPersistentVolumeClaim: "",
+	types.KindPod:                   "",
+	types.KindReplicaSet:            "apps",
+	types.KindReplicationController: "",
+	types.KindRole:                  "rbac.authorization.k8s.io",
+	types.KindRoleBinding:           "rbac.authorization.k8s.io",
+	types.KindSecret:                "",
+	types.KindService:               "",
+	types.KindServiceAccount:        "",
+	types.KindStatefulSet:           "apps",
+	types.KindStorageClass:          "storage.k8s.io",
+	KindEvent:                       "",
+}
+
+// FieldSelector builds a field selector string that can be passed to any
+// ListByField()/WatchByField() method, eg:
+//
+//	sel, err := selector.Fields(types.KindPod).
+//	    Equals("spec.nodeName", "node1").
+//	    NotEquals("status.phase", "Running").
+//	    Build()
+//	// sel: "spec.nodeName=node1,status.phase!=Running"
+//	handler.ListByField(sel)
+//
+// The field is validated against the fields supported by the k8s resource Kind,
+// see SupportedFields.
+type FieldSelector struct {
+	kind         string
+	requirements []fields.Selector
+	errs         []error
+}
+
+// Fields returns an empty FieldSelector for the k8s resource Kind, such like
+// "Pod", "Node", "Secret", etc. If kind is empty, the field is not validated.
+func Fields(kind string) *FieldSelector {
+	return &FieldSelector{kind: kind}
+}
+
+// Equals adds the requirement "field=value".
+func (s *FieldSelector) Equals(field, value string) *FieldSelector {
+	return s.add(field, selection.Equals, value)
+}
+
+// NotEquals adds the requirement "field!=value".
+func (s *FieldSelector) NotEquals(field, value string) *FieldSelector {
+	return s.add(field, selection.NotEquals, value)
+}
+
+// Err returns the validation error of all requirements.
+func (s *FieldSelector) Err() error {
+	return utilerrors.NewAggregate(s.errs)
+}
+
+// Build returns the field selector string or the validation error.
+func (s *FieldSelector) Build() (string, error) {
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	return s.String(), nil
+}
+
+// MustBuild is like Build but panics if there is any validation error.
+func (s *FieldSelector) MustBuild() string {
+	sel, err := s.Build()
+	if err != nil {
+		panic(err)
+	}
+	return sel
+}
+
+// String returns the field selector string.
+func (s *FieldSelector) String() string {
+	return fields.AndSelectors(s.requirements...).String()
+}
+
+func (s *FieldSelector) add(field string, operator selection.Operator, value string) *FieldSelector {
+	if err := validateField(s.kind, field); err != nil {
+		s.errs = append(s.errs, err)
+	}
+	switch operator {
+	case selection.NotEquals:
+		s.requirements = append(s.requirements, fields.OneTermNotEqualSelector(field, value))
+	default:
+		s.requirements = append(s.requirements, fields.OneTermEqualSelector(field, value))
+	}
+	return s
+}
+
+// ValidateFieldSelector checks whether the raw field selector string is valid
+// and every field is supported by the k8s resource Kind.
+// If kind is empty, only the field selector syntax is checked.
+func ValidateFieldSelector(kind, selector string) error {
+	sel, err := fields.ParseSelector(selector)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, r := range sel.Requirements() {
+		if err := validateField(kind, r.Field); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// ValidateFieldSelectorForGVK is like ValidateFieldSelector, but the fields
+// are validated by the Kind only if gvk is a built-in k8s resource, or its Kind
+// is added to SupportedFields. The field selector of the other GVKs, such like
+// the custom resources that have the same Kind as a built-in k8s resource, is
+// only checked by syntax.
+func ValidateFieldSelectorForGVK(gvk schema.GroupVersionKind, selector string) error {
+	kind := gvk.Kind
+	if group, ok := builtinGroups[kind]; ok {
+		if group != gvk.Group {
+			kind = ""
+		}
+	} else if _, ok := SupportedFields[kind]; !ok {
+		kind = ""
+	}
+	return ValidateFieldSelector(kind, selector)
+}
+
+func validateField(kind, field string) error {
+	if len(field) == 0 {
+		return fmt.Errorf("field must not be empty")
+	}
+	if len(kind) == 0 {
+		return nil
+	}
+	for _, f := range metadataFields {
+		if f == field {
+			return nil
+		}
+	}
+	supported := SupportedFields[kind]
+	for _, f := range supported {
+		if f == field {
+			return nil
+		}
+	}
+	all := append(append([]string(nil), metadataFields...), supported...)
+	sort.Strings(all)
+	return fmt.Errorf("field %q is not supported by %s, supported fields: %s", field, kind, strings.Join(all, ", "))
+}
@@ -0,0 +1,163 @@
+package selector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+/*
+reference:
+	https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors
+*/
+
+// LabelSelector builds a label selector string that can be passed to any
+// ListByLabel()/WatchByLabel() method, eg:
+//
+//	sel, err := selector.Labels().
+//	    Equals("app", "nginx").
+//	    In("env", "prod", "staging").
+//	    DoesNotExist("canary").
+//	    Build()
+//	// sel: "app=nginx,env in (prod,staging),!canary"
+//	handler.ListByLabel(sel)
+//
+// There is an "And" relationship between all requirements. Every requirement
+// is validated by the label syntax when it's added, call Build() or Err() to
+// get the validation error.
+type LabelSelector struct {
+	requirements []labelRequirement
+	errs         []error
+}
+
+type labelRequirement struct {
+	key      string
+	operator selection.Operator
+	values   []string
+}
+
+// Labels returns an empty LabelSelector which selects everything.
+func Labels() *LabelSelector {
+	return &LabelSelector{}
+}
+
+// Equals adds the requirement "key=value".
+func (s *LabelSelector) Equals(key, value string) *LabelSelector {
+	return s.add(key, selection.Equals, value)
+}
+
+// NotEquals adds the requirement "key!=value".
+func (s *LabelSelector) NotEquals(key, value string) *LabelSelector {
+	return s.add(key, selection.NotEquals, value)
+}
+
+// In adds the requirement "key in (value1,value2)".
+func (s *LabelSelector) In(key string, values ...string) *LabelSelector {
+	return s.add(key, selection.In, values...)
+}
+
+// NotIn adds the requirement "key notin (value1,value2)".
+func (s *LabelSelector) NotIn(key string, values ...string) *LabelSelector {
+	return s.add(key, selection.NotIn, values...)
+}
+
+// Exists adds the requirement "key", select the k8s objects that have the label key.
+func (s *LabelSelector) Exists(key string) *LabelSelector {
+	return s.add(key, selection.Exists)
+}
+
+// DoesNotExist adds the requirement "!key", select the k8s objects that don't
+// have the label key.
+func (s *LabelSelector) DoesNotExist(key string) *LabelSelector {
+	return s.add(key, selection.DoesNotExist)
+}
+
+// Set adds the requirement "key=value" for every item of the map.
+func (s *LabelSelector) Set(set map[string]string) *LabelSelector {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s.Equals(key, set[key])
+	}
+	return s
+}
+
+// And adds all requirements of the other LabelSelector.
+func (s *LabelSelector) And(other *LabelSelector) *LabelSelector {
+	if other == nil {
+		return s
+	}
+	s.requirements = append(s.requirements, other.requirements...)
+	s.errs = append(s.errs, other.errs...)
+	return s
+}
+
+// Err returns the validation error of all requirements.
+func (s *LabelSelector) Err() error {
+	return utilerrors.NewAggregate(s.errs)
+}
+
+// Build returns the label selector string or the validation error.
+func (s *LabelSelector) Build() (string, error) {
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	return s.String(), nil
+}
+
+// MustBuild is like Build but panics if there is any validation error.
+func (s *LabelSelector) MustBuild() string {
+	sel, err := s.Build()
+	if err != nil {
+		panic(err)
+	}
+	return sel
+}
+
+// String returns the label selector string.
+// Invalid requirements are kept as is, so the kubernetes API server will reject
+// the selector instead of selecting more k8s objects than expected.
+func (s *LabelSelector) String() string {
+	var sl []string
+	for _, r := range s.requirements {
+		sl = append(sl, r.String())
+	}
+	return strings.Join(sl, ",")
+}
+
+func (s *LabelSelector) add(key string, operator selection.Operator, values ...string) *LabelSelector {
+	// NewRequirement validates the label key and values.
+	if _, err := labels.NewRequirement(key, operator, values); err != nil {
+		s.errs = append(s.errs, err)
+	}
+	s.requirements = append(s.requirements, labelRequirement{key: key, operator: operator, values: values})
+	return s
+}
+
+func (r labelRequirement) String() string {
+	switch r.operator {
+	case selection.Exists:
+		return r.key
+	case selection.DoesNotExist:
+		return "!" + r.key
+	case selection.In, selection.NotIn:
+		values := append([]string(nil), r.values...)
+		sort.Strings(values)
+		return fmt.Sprintf("%s %s (%s)", r.key, r.operator, strings.Join(values, ","))
+	default:
+		return fmt.Sprintf("%s%s%s", r.key, r.operator, strings.Join(r.values, ","))
+	}
+}
+
+// ValidateLabelSelector checks whether the raw label selector string is valid.
+func ValidateLabelSelector(selector string) error {
+	_, err := labels.Parse(selector)
+	return err
+}
@@ -0,0 +1,38 @@
+package selector
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ForObject returns the label selector of the pods of the workload,
+// supported types are *appsv1.Deployment, *appsv1.StatefulSet, *appsv1.DaemonSet,
+// *appsv1.ReplicaSet, *batchv1.Job, *corev1.ReplicationController and *corev1.Service.
+func ForObject(obj runtime.Object) (labels.Selector, error) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return metav1.LabelSelectorAsSelector(o.Spec.Selector)
+	case *appsv1.StatefulSet:
+		return metav1.LabelSelectorAsSelector(o.Spec.Selector)
+	case *appsv1.DaemonSet:
+		return metav1.LabelSelectorAsSelector(o.Spec.Selector)
+	case *appsv1.ReplicaSet:
+		return metav1.LabelSelectorAsSelector(o.Spec.Selector)
+	case *batchv1.Job:
+		return metav1.LabelSelectorAsSelector(o.Spec.Selector)
+	case *corev1.ReplicationController:
+		return labels.SelectorFromSet(o.Spec.Selector), nil
+	case *corev1.Service:
+		if len(o.Spec.Selector) == 0 {
+			return nil, fmt.Errorf("service %q has no selector", o.Name)
+		}
+		return labels.SelectorFromSet(o.Spec.Selector), nil
+	}
+	return nil, fmt.Errorf("unsupported type %T to select pods", obj)
+}
@@ -0,0 +1,93 @@
+package selector
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestForObject(t *testing.T) {
+	labelSelector := &metav1.LabelSelector{
+		MatchLabels:      map[string]string{"app": "nginx"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"web"}}},
+	}
+	tests := []struct {
+		name     string
+		obj      runtime.Object
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "deployment",
+			obj:      &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Selector: labelSelector}},
+			expected: "app=nginx,tier in (web)",
+		},
+		{
+			name:     "statefulset",
+			obj:      &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Selector: labelSelector}},
+			expected: "app=nginx,tier in (web)",
+		},
+		{
+			name:     "daemonset",
+			obj:      &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{Selector: labelSelector}},
+			expected: "app=nginx,tier in (web)",
+		},
+		{
+			name:     "replicaset",
+			obj:      &appsv1.ReplicaSet{Spec: appsv1.ReplicaSetSpec{Selector: labelSelector}},
+			expected: "app=nginx,tier in (web)",
+		},
+		{
+			name:     "job",
+			obj:      &batchv1.Job{Spec: batchv1.JobSpec{Selector: labelSelector}},
+			expected: "app=nginx,tier in (web)",
+		},
+		{
+			name:     "replicationcontroller",
+			obj:      &corev1.ReplicationController{Spec: corev1.ReplicationControllerSpec{Selector: map[string]string{"app": "nginx"}}},
+			expected: "app=nginx",
+		},
+		{
+			name:     "service",
+			obj:      &corev1.Service{Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "nginx"}}},
+			expected: "app=nginx",
+		},
+		{
+			name:    "service without selector",
+			obj:     &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "external"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid selector",
+			obj:     &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Bad"}}}}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported type",
+			obj:     &corev1.Pod{},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sel, err := ForObject(test.obj)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got selector %q", sel)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sel.String() != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, sel.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/forbearing/k8s/types"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestLabelSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector *LabelSelector
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			selector: Labels(),
+			expected: "",
+		},
+		{
+			name:     "equality",
+			selector: Labels().Equals("name", "myapp").NotEquals("role", "devops"),
+			expected: "name=myapp,role!=devops",
+		},
+		{
+			name:     "set based",
+			selector: Labels().In("env", "prod", "dev").NotIn("tier", "frontend").Exists("app").DoesNotExist("canary"),
+			expected: "env in (dev,prod),tier notin (frontend),app,!canary",
+		},
+		{
+			name:     "map",
+			selector: Labels().Set(map[string]string{"b": "2", "a": "1"}),
+			expected: "a=1,b=2",
+		},
+		{
+			name:     "and",
+			selector: Labels().Equals("a", "1").And(Labels().Exists("b")),
+			expected: "a=1,b",
+		},
+		{
+			name:     "invalid key",
+			selector: Labels().Equals("name=", "myapp"),
+			wantErr:  true,
+		},
+		{
+			name:     "invalid value",
+			selector: Labels().Equals("name", "my app"),
+			wantErr:  true,
+		},
+		{
+			name:     "in without values",
+			selector: Labels().In("env"),
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sel, err := test.selector.Build()
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got selector %q", sel)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sel != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, sel)
+			}
+			if err := ValidateLabelSelector(sel); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestFieldSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector *FieldSelector
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "pod",
+			selector: Fields(types.KindPod).Equals("spec.nodeName", "node1").NotEquals("status.phase", "Running"),
+			expected: "spec.nodeName=node1,status.phase!=Running",
+		},
+		{
+			name:     "metadata",
+			selector: Fields(types.KindDeployment).Equals("metadata.name", "mydep"),
+			expected: "metadata.name=mydep",
+		},
+		{
+			name:     "escaped value",
+			selector: Fields("").Equals("metadata.name", "a,b"),
+			expected: `metadata.name=a\,b`,
+		},
+		{
+			name:     "unsupported field",
+			selector: Fields(types.KindDeployment).Equals("spec.nodeName", "node1"),
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sel, err := test.selector.Build()
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got selector %q", sel)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sel != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, sel)
+			}
+		})
+	}
+
+	if err := ValidateFieldSelector(types.KindPod, "spec.nodeName=node1,status.phase=Running"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateFieldSelector(types.KindPod, "spec.nodename=node1"); err == nil {
+		t.Fatal("expected error for unsupported field")
+	}
+}
+
+func TestValidateFieldSelectorForGVK(t *testing.T) {
+	tests := []struct {
+		name     string
+		gvk      schema.GroupVersionKind
+		selector string
+		wantErr  bool
+	}{
+		{
+			name:     "pod",
+			gvk:      schema.GroupVersionKind{Version: "v1", Kind: types.KindPod},
+			selector: "spec.nodeName=node1",
+		},
+		{
+			name:     "unsupported field of pod",
+			gvk:      schema.GroupVersionKind{Version: "v1", Kind: types.KindPod},
+			selector: "spec.nodename=node1",
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported field of job",
+			gvk:      schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: types.KindJob},
+			selector: "spec.queue=default",
+			wantErr:  true,
+		},
+		{
+			name:     "custom resource with the kind of a built-in resource",
+			gvk:      schema.GroupVersionKind{Group: "batch.volcano.sh", Version: "v1alpha1", Kind: types.KindJob},
+			selector: "spec.queue=default",
+		},
+		{
+			name:     "custom resource",
+			gvk:      schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"},
+			selector: "spec.foo=bar",
+		},
+		{
+			name:     "invalid syntax of custom resource",
+			gvk:      schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"},
+			selector: "spec.foo==bar=",
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateFieldSelectorForGVK(test.gvk, test.selector)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// failedWaitingReasons are the waiting reasons of the containers that never
+// recover without changing the pod.
+var failedWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+}
+
+// pullingWaitingReasons are the waiting reasons of the containers whose image
+// can't be pulled yet, they are usually transient, such like a registry blip,
+// and the kubelet keeps retrying.
+var pullingWaitingReasons = map[string]bool{
+	"ErrImagePull":     true,
+	"ImagePullBackOff": true,
+}
+
+func inProgress(format string, args ...interface{}) (*Result, error) {
+	return &Result{Status: InProgressStatus, Message: fmt.Sprintf(format, args...)}, nil
+}
+
+func current(format string, args ...interface{}) (*Result, error) {
+	return &Result{Status: CurrentStatus, Message: fmt.Sprintf(format, args...)}, nil
+}
+
+func failed(format string, args ...interface{}) (*Result, error) {
+	return &Result{Status: FailedStatus, Message: fmt.Sprintf(format, args...)}, nil
+}
+
+func replicas(r *int32) int32 {
+	if r == nil {
+		return 1
+	}
+	return *r
+}
+
+func deploymentRule(u *unstructured.Unstructured) (*Result, error) {
+	if result := checkGeneration(u); result != nil {
+		return result, nil
+	}
+	deploy := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, deploy); err != nil {
+		return nil, err
+	}
+	for _, c := range deploy.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return failed("progress deadline exceeded: %s", c.Message)
+		}
+	}
+	desired, status := replicas(deploy.Spec.Replicas), deploy.Status
+	switch {
+	case status.UpdatedReplicas < desired:
+		return inProgress("updated: %d/%d", status.UpdatedReplicas, desired)
+	case status.Replicas > status.UpdatedReplicas:
+		return inProgress("pending termination: %d", status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return inProgress("available: %d/%d", status.AvailableReplicas, status.UpdatedReplicas)
+	case status.ReadyReplicas < desired:
+		return inProgress("ready: %d/%d", status.ReadyReplicas, desired)
+	}
+	return current("deployment is available, replicas: %d", desired)
+}
+
+func statefulSetRule(u *unstructured.Unstructured) (*Result, error) {
+	if result := checkGeneration(u); result != nil {
+		return result, nil
+	}
+	sts := &appsv1.StatefulSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, sts); err != nil {
+		return nil, err
+	}
+	desired, status := replicas(sts.Spec.Replicas), sts.Status
+	if status.ObservedGeneration == 0 {
+		return inProgress("waiting for statefulset to be observed")
+	}
+	if status.ReadyReplicas < desired {
+		return inProgress("ready: %d/%d", status.ReadyReplicas, desired)
+	}
+	// the pods of OnDelete statefulset are updated when they are deleted manually.
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return current("statefulset is ready, replicas: %d", desired)
+	}
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil && *ru.Partition > 0 {
+		if expected := desired - *ru.Partition; status.UpdatedReplicas < expected {
+			return inProgress("partitioned rollout: %d/%d updated", status.UpdatedReplicas, expected)
+		}
+		return current("partitioned rollout complete, %d updated", status.UpdatedReplicas)
+	}
+	if status.UpdatedReplicas < desired {
+		return inProgress("updated: %d/%d", status.UpdatedReplicas, desired)
+	}
+	if status.UpdateRevision != status.CurrentRevision {
+		return inProgress("waiting for revision %s to be current", status.UpdateRevision)
+	}
+	return current("statefulset is ready, replicas: %d", desired)
+}
+
+func daemonSetRule(u *unstructured.Unstructured) (*Result, error) {
+	if result := checkGeneration(u); result != nil {
+		return result, nil
+	}
+	ds := &appsv1.DaemonSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, ds); err != nil {
+		return nil, err
+	}
+	status := ds.Status
+	if status.ObservedGeneration == 0 {
+		return inProgress("waiting for daemonset to be observed")
+	}
+	desired := status.DesiredNumberScheduled
+	switch {
+	case status.CurrentNumberScheduled < desired:
+		return inProgress("scheduled: %d/%d", status.CurrentNumberScheduled, desired)
+	case status.UpdatedNumberScheduled < desired:
+		return inProgress("updated: %d/%d", status.UpdatedNumberScheduled, desired)
+	case status.NumberAvailable < desired:
+		return inProgress("available: %d/%d", status.NumberAvailable, desired)
+	case status.NumberReady < desired:
+		return inProgress("ready: %d/%d", status.NumberReady, desired)
+	}
+	return current("daemonset is available, pods: %d", desired)
+}
+
+func replicaSetRule(u *unstructured.Unstructured) (*Result, error) {
+	if result := checkGeneration(u); result != nil {
+		return result, nil
+	}
+	desired, _, err := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok, _ := unstructured.NestedFieldNoCopy(u.Object, "spec", "replicas"); !ok {
+		desired = 1
+	}
+	conditions, err := getConditions(u)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := conditions["ReplicaFailure"]; ok && c.status == "True" {
+		return inProgress(c.describe("replica failure"))
+	}
+	ready, _, _ := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+	available, _, _ := unstructured.NestedInt64(u.Object, "status", "availableReplicas")
+	switch {
+	case ready < desired:
+		return inProgress("ready: %d/%d", ready, desired)
+	case available < desired:
+		return inProgress("available: %d/%d", available, desired)
+	}
+	return current("all replicas are available, replicas: %d", desired)
+}
+
+func jobRule(u *unstructured.Unstructured) (*Result, error) {
+	job := &batchv1.Job{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, job); err != nil {
+		return nil, err
+	}
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return current("job completed, succeeded: %d", job.Status.Succeeded)
+		case batchv1.JobFailed:
+			return failed("job failed: %s: %s", c.Reason, c.Message)
+		}
+	}
+	return inProgress("job in progress, active: %d, succeeded: %d, failed: %d",
+		job.Status.Active, job.Status.Succeeded, job.Status.Failed)
+}
+
+func pvcRule(u *unstructured.Unstructured) (*Result, error) {
+	phase, _, err := unstructured.NestedString(u.Object, "status", "phase")
+	if err != nil {
+		return nil, err
+	}
+	switch corev1.PersistentVolumeClaimPhase(phase) {
+	case corev1.ClaimBound:
+		return current("persistentvolumeclaim is bound")
+	case corev1.ClaimLost:
+		return failed("persistentvolumeclaim lost its persistentvolume")
+	}
+	return inProgress("persistentvolumeclaim is not bound, phase: %s", phase)
+}
+
+func serviceRule(u *unstructured.Unstructured) (*Result, error) {
+	svcType, _, err := unstructured.NestedString(u.Object, "spec", "type")
+	if err != nil {
+		return nil, err
+	}
+	if corev1.ServiceType(svcType) != corev1.ServiceTypeLoadBalancer {
+		return current("service is ready")
+	}
+	ingress, _, err := unstructured.NestedSlice(u.Object, "status", "loadBalancer", "ingress")
+	if err != nil {
+		return nil, err
+	}
+	if len(ingress) == 0 {
+		return inProgress("waiting for loadbalancer ingress")
+	}
+	return current("loadbalancer is ready")
+}
+
+func podRule(u *unstructured.Unstructured) (*Result, error) {
+	pod := &corev1.Pod{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, pod); err != nil {
+		return nil, err
+	}
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return current("pod completed successfully")
+	case corev1.PodFailed:
+		return failed("pod failed: %s", pod.Status.Reason)
+	}
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		w := cs.State.Waiting
+		switch {
+		case w == nil:
+		case failedWaitingReasons[w.Reason]:
+			return failed("container %s is %s: %s", cs.Name, w.Reason, w.Message)
+		case pullingWaitingReasons[w.Reason]:
+			return inProgress("container %s is %s: %s", cs.Name, w.Reason, w.Message)
+		}
+	}
+	for _, c := range pod.Status.Conditions {
+		switch {
+		case c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse:
+			return inProgress("pod is not scheduled: %s", c.Message)
+		case c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue:
+			return current("pod is ready")
+		}
+	}
+	var notReady []string
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !cs.Ready {
+			notReady = append(notReady, cs.Name)
+		}
+	}
+	if len(notReady) != 0 {
+		return inProgress("pod is %s, containers not ready: %s", pod.Status.Phase, strings.Join(notReady, ", "))
+	}
+	return inProgress("pod is %s", pod.Status.Phase)
+}
@@ -0,0 +1,213 @@
+/*
+Package status computes the status of any k8s object, built-in or custom, like
+kstatus does (sigs.k8s.io/cli-utils/pkg/kstatus):
+
+	result, err := status.Compute(obj)
+	fmt.Println(result.Status, result.Message) // InProgress Updated: 1/3
+
+The status is computed by the rule of the kind if registered, otherwise by the
+generic rule that checks the metadata.generation, status.observedGeneration and
+the standard conditions "Stalled", "Reconciling", "Ready" and "Available".
+Register rules for CRDs whose status can't be computed by the generic rule:
+
+	status.Register(schema.GroupKind{Group: "example.com", Kind: "Database"}, func(obj *unstructured.Unstructured) (*status.Result, error) {
+	    ...
+	})
+*/
+package status
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// Status is the computed status of a k8s object.
+type Status string
+
+const (
+	// InProgressStatus means the k8s object is being reconciled.
+	InProgressStatus Status = "InProgress"
+	// CurrentStatus means the k8s object is fully reconciled, such like the
+	// deployment is rolled out and available.
+	CurrentStatus Status = "Current"
+	// FailedStatus means the reconciliation of the k8s object failed, such like
+	// the job failed or the deployment exceeded its progress deadline.
+	FailedStatus Status = "Failed"
+	// TerminatingStatus means the k8s object is being deleted.
+	TerminatingStatus Status = "Terminating"
+	// NotFoundStatus means the k8s object doesn't exist.
+	NotFoundStatus Status = "NotFound"
+)
+
+// Result is the computed status and a human readable message.
+type Result struct {
+	Status  Status
+	Message string
+}
+
+func (r *Result) String() string {
+	if len(r.Message) == 0 {
+		return string(r.Status)
+	}
+	return fmt.Sprintf("%s: %s", r.Status, r.Message)
+}
+
+// RuleFunc computes the status of the k8s object of a kind. It's only called
+// for the existing k8s object that is not being deleted.
+type RuleFunc func(obj *unstructured.Unstructured) (*Result, error)
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[schema.GroupKind]RuleFunc{
+		{Group: "apps", Kind: "Deployment"}:            deploymentRule,
+		{Group: "apps", Kind: "StatefulSet"}:           statefulSetRule,
+		{Group: "apps", Kind: "DaemonSet"}:             daemonSetRule,
+		{Group: "batch", Kind: "Job"}:                  jobRule,
+		{Group: "", Kind: "PersistentVolumeClaim"}:     pvcRule,
+		{Group: "", Kind: "Service"}:                   serviceRule,
+		{Group: "", Kind: "Pod"}:                       podRule,
+		{Group: "apps", Kind: "ReplicaSet"}:            replicaSetRule,
+		{Group: "", Kind: "ReplicationController"}:     replicaSetRule,
+		{Group: "policy", Kind: "PodDisruptionBudget"}: genericRule,
+	}
+)
+
+// Register registers the rule of the kind, it replaces the rule registered
+// before, including the built-in rules.
+func Register(gk schema.GroupKind, rule RuleFunc) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[gk] = rule
+}
+
+// Compute computes the status of the k8s object. obj can be *unstructured.Unstructured
+// or a typed k8s object, such like *appsv1.Deployment, nil obj means NotFound.
+func Compute(obj runtime.Object) (*Result, error) {
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return &Result{Status: NotFoundStatus, Message: "resource not found"}, nil
+	}
+	if u.GetDeletionTimestamp() != nil {
+		return &Result{Status: TerminatingStatus, Message: "resource is being deleted"}, nil
+	}
+
+	rulesMu.RLock()
+	rule, ok := rules[u.GroupVersionKind().GroupKind()]
+	rulesMu.RUnlock()
+	if !ok {
+		rule = genericRule
+	}
+	return rule(u)
+}
+
+// toUnstructured converts the typed k8s object to unstructured, the GVK of
+// typed k8s objects is found from the client-go scheme if TypeMeta is empty.
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	switch o := obj.(type) {
+	case nil:
+		return nil, nil
+	case *unstructured.Unstructured:
+		if o == nil {
+			return nil, nil
+		}
+		return o, nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	if u.GroupVersionKind().Empty() {
+		gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+		if err != nil {
+			return nil, err
+		}
+		u.SetGroupVersionKind(gvks[0])
+	}
+	return u, nil
+}
+
+// genericRule computes the status by the generation and the standard conditions.
+func genericRule(u *unstructured.Unstructured) (*Result, error) {
+	if result := checkGeneration(u); result != nil {
+		return result, nil
+	}
+	conditions, err := getConditions(u)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := conditions["Stalled"]; ok && c.status == "True" {
+		return &Result{Status: FailedStatus, Message: c.describe("Stalled")}, nil
+	}
+	if c, ok := conditions["Reconciling"]; ok && c.status == "True" {
+		return &Result{Status: InProgressStatus, Message: c.describe("Reconciling")}, nil
+	}
+	for _, t := range []string{"Ready", "Available"} {
+		if c, ok := conditions[t]; ok && c.status != "True" {
+			return &Result{Status: InProgressStatus, Message: c.describe(t + " is " + c.status)}, nil
+		}
+	}
+	return &Result{Status: CurrentStatus, Message: "resource is current"}, nil
+}
+
+// checkGeneration returns InProgress if the latest generation of the k8s object
+// is not observed by its controller, it returns nil if status.observedGeneration
+// doesn't exist.
+func checkGeneration(u *unstructured.Unstructured) *Result {
+	observed, ok, err := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	if err != nil || !ok {
+		return nil
+	}
+	if observed < u.GetGeneration() {
+		return &Result{Status: InProgressStatus, Message: fmt.Sprintf("waiting for generation %d to be observed", u.GetGeneration())}
+	}
+	return nil
+}
+
+type condition struct {
+	status  string
+	reason  string
+	message string
+}
+
+// describe returns the message of the condition, default to the prefix.
+func (c condition) describe(prefix string) string {
+	parts := []string{prefix}
+	if len(c.reason) != 0 {
+		parts = append(parts, c.reason)
+	}
+	if len(c.message) != 0 {
+		parts = append(parts, c.message)
+	}
+	return strings.Join(parts, ": ")
+}
+
+// getConditions returns status.conditions by condition type.
+func getConditions(u *unstructured.Unstructured) (map[string]condition, error) {
+	items, _, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if err != nil {
+		return nil, err
+	}
+	conditions := make(map[string]condition, len(items))
+	for _, item := range items {
+		c, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		t, _ := c["type"].(string)
+		status, _ := c["status"].(string)
+		reason, _ := c["reason"].(string)
+		message, _ := c["message"].(string)
+		conditions[t] = condition{status: status, reason: reason, message: message}
+	}
+	return conditions, nil
+}
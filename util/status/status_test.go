@@ -0,0 +1,137 @@
+package status
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newObject(apiVersion, kind string, spec, status map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "test", "generation": int64(2)},
+		"spec":       spec,
+		"status":     status,
+	}}
+}
+
+func conditions(items ...map[string]interface{}) []interface{} {
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		result = append(result, item)
+	}
+	return result
+}
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      runtime.Object
+		expected Status
+		message  string
+	}{
+		{"not found", nil, NotFoundStatus, "resource not found"},
+		{"deployment old generation", newObject("apps/v1", "Deployment", nil,
+			map[string]interface{}{"observedGeneration": int64(1)}), InProgressStatus, "waiting for generation 2 to be observed"},
+		{"deployment updating", newObject("apps/v1", "Deployment", map[string]interface{}{"replicas": int64(3)},
+			map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(3), "updatedReplicas": int64(1)}), InProgressStatus, "updated: 1/3"},
+		{"deployment available", newObject("apps/v1", "Deployment", map[string]interface{}{"replicas": int64(3)},
+			map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(3), "updatedReplicas": int64(3),
+				"readyReplicas": int64(3), "availableReplicas": int64(3)}), CurrentStatus, "deployment is available, replicas: 3"},
+		{"deployment deadline exceeded", newObject("apps/v1", "Deployment", nil,
+			map[string]interface{}{"observedGeneration": int64(2), "conditions": conditions(map[string]interface{}{
+				"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded", "message": "timed out"})}),
+			FailedStatus, "progress deadline exceeded: timed out"},
+		{"statefulset partition", newObject("apps/v1", "StatefulSet", map[string]interface{}{"replicas": int64(3),
+			"updateStrategy": map[string]interface{}{"type": "RollingUpdate", "rollingUpdate": map[string]interface{}{"partition": int64(2)}}},
+			map[string]interface{}{"observedGeneration": int64(2), "readyReplicas": int64(3), "updatedReplicas": int64(1)}),
+			CurrentStatus, "partitioned rollout complete, 1 updated"},
+		{"statefulset revision", newObject("apps/v1", "StatefulSet", nil,
+			map[string]interface{}{"observedGeneration": int64(2), "readyReplicas": int64(1), "updatedReplicas": int64(1),
+				"currentRevision": "web-1", "updateRevision": "web-2"}), InProgressStatus, "waiting for revision web-2 to be current"},
+		{"daemonset scheduling", newObject("apps/v1", "DaemonSet", nil,
+			map[string]interface{}{"observedGeneration": int64(2), "desiredNumberScheduled": int64(3), "currentNumberScheduled": int64(2)}),
+			InProgressStatus, "scheduled: 2/3"},
+		{"job failed", newObject("batch/v1", "Job", nil, map[string]interface{}{"conditions": conditions(map[string]interface{}{
+			"type": "Failed", "status": "True", "reason": "BackoffLimitExceeded", "message": "reached backoff limit"})}),
+			FailedStatus, "job failed: BackoffLimitExceeded: reached backoff limit"},
+		{"job running", newObject("batch/v1", "Job", nil, map[string]interface{}{"active": int64(1)}),
+			InProgressStatus, "job in progress, active: 1, succeeded: 0, failed: 0"},
+		{"pvc pending", newObject("v1", "PersistentVolumeClaim", nil, map[string]interface{}{"phase": "Pending"}),
+			InProgressStatus, "persistentvolumeclaim is not bound, phase: Pending"},
+		{"pvc bound", newObject("v1", "PersistentVolumeClaim", nil, map[string]interface{}{"phase": "Bound"}),
+			CurrentStatus, "persistentvolumeclaim is bound"},
+		{"service clusterip", newObject("v1", "Service", map[string]interface{}{"type": "ClusterIP"}, nil),
+			CurrentStatus, "service is ready"},
+		{"service loadbalancer", newObject("v1", "Service", map[string]interface{}{"type": "LoadBalancer"}, nil),
+			InProgressStatus, "waiting for loadbalancer ingress"},
+		{"pod crashloop", newObject("v1", "Pod", nil, map[string]interface{}{"phase": "Running", "containerStatuses": []interface{}{
+			map[string]interface{}{"name": "nginx", "state": map[string]interface{}{"waiting": map[string]interface{}{
+				"reason": "CrashLoopBackOff", "message": "back-off restarting"}}}}}),
+			FailedStatus, "container nginx is CrashLoopBackOff: back-off restarting"},
+		{"pod image pull backoff", newObject("v1", "Pod", nil, map[string]interface{}{"phase": "Pending", "containerStatuses": []interface{}{
+			map[string]interface{}{"name": "nginx", "state": map[string]interface{}{"waiting": map[string]interface{}{
+				"reason": "ImagePullBackOff", "message": "Back-off pulling image"}}}}}),
+			InProgressStatus, "container nginx is ImagePullBackOff: Back-off pulling image"},
+		{"pod invalid image name", newObject("v1", "Pod", nil, map[string]interface{}{"phase": "Pending", "containerStatuses": []interface{}{
+			map[string]interface{}{"name": "nginx", "state": map[string]interface{}{"waiting": map[string]interface{}{
+				"reason": "InvalidImageName", "message": "couldn't parse image reference"}}}}}),
+			FailedStatus, "container nginx is InvalidImageName: couldn't parse image reference"},
+		{"pod ready", newObject("v1", "Pod", nil, map[string]interface{}{"phase": "Running", "conditions": conditions(
+			map[string]interface{}{"type": "Ready", "status": "True"})}), CurrentStatus, "pod is ready"},
+		{"custom ready false", newObject("example.com/v1", "Database", nil, map[string]interface{}{
+			"observedGeneration": int64(2), "conditions": conditions(map[string]interface{}{
+				"type": "Ready", "status": "False", "reason": "Provisioning"})}),
+			InProgressStatus, "Ready is False: Provisioning"},
+		{"custom stalled", newObject("example.com/v1", "Database", nil, map[string]interface{}{
+			"conditions": conditions(map[string]interface{}{"type": "Stalled", "status": "True", "message": "invalid spec"})}),
+			FailedStatus, "Stalled: invalid spec"},
+		{"custom without status", newObject("example.com/v1", "Database", nil, nil), CurrentStatus, "resource is current"},
+		{"typed deployment", &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: new(int32)}},
+			CurrentStatus, "deployment is available, replicas: 0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := Compute(test.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Status != test.expected || result.Message != test.message {
+				t.Errorf("expected %s: %s, got %s", test.expected, test.message, result)
+			}
+		})
+	}
+
+	terminating := newObject("v1", "Pod", nil, nil)
+	now := metav1.Now()
+	terminating.SetDeletionTimestamp(&now)
+	if result, _ := Compute(terminating); result.Status != TerminatingStatus {
+		t.Errorf("expected Terminating, got %s", result)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	gk := schema.GroupKind{Group: "example.com", Kind: "Backup"}
+	Register(gk, func(obj *unstructured.Unstructured) (*Result, error) {
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		if phase == "Completed" {
+			return &Result{Status: CurrentStatus}, nil
+		}
+		return &Result{Status: FailedStatus, Message: phase}, nil
+	})
+
+	result, err := Compute(newObject("example.com/v1", "Backup", nil, map[string]interface{}{"phase": "Completed"}))
+	if err != nil || result.Status != CurrentStatus {
+		t.Errorf("expected Current, got %v, %v", result, err)
+	}
+
+	_, _, err = ForCurrent().Func(newObject("example.com/v1", "Backup", nil, map[string]interface{}{"phase": "Error"}))
+	if !IsFailed(err) || err.Error() != "Failed: Error" {
+		t.Errorf("expected FailedError, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package status
+
+import (
+	"errors"
+
+	utilwait "github.com/forbearing/k8s/util/wait"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// FailedError is returned by the wait condition of ForCurrent if the status
+// of the k8s object is Failed.
+type FailedError struct {
+	Result *Result
+}
+
+func (e *FailedError) Error() string { return e.Result.String() }
+
+// IsFailed reports whether the error is caused by the Failed status.
+func IsFailed(err error) bool {
+	var failedErr *FailedError
+	return errors.As(err, &failedErr)
+}
+
+// ForCurrent returns the wait condition that the status of the k8s objects is
+// Current, it stops waiting with *FailedError if the status is Failed, eg:
+//
+//	handler.WaitFor("nginx", status.ForCurrent(), utilwait.WithTimeout(5*time.Minute))
+func ForCurrent() utilwait.Condition {
+	return utilwait.Condition{Name: "status=Current", Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		result, err := Compute(obj)
+		if err != nil {
+			return false, "", err
+		}
+		switch result.Status {
+		case CurrentStatus:
+			return true, "", nil
+		case FailedStatus:
+			return false, "", &FailedError{Result: result}
+		}
+		return false, result.String(), nil
+	}}
+}
@@ -0,0 +1,117 @@
+/*
+Package tracing traces the handlers with OpenTelemetry, it's opt-in and every
+Create/Update/Apply/Patch/Delete call creates a span, the http requests sent
+to the kubernetes API server are traced as the child spans.
+
+	handler, err := deployment.New(ctx, "", "test", tracing.HandlerOption(tracerProvider))
+	// the span stored in ctx is the parent span.
+	handler.WithContext(ctx).Apply(filename)
+
+The span is propagated from the ctx of handler, use WithContext to pass the
+per-call ctx. If the tracer provider is nil, the tracer provider of the span
+stored in ctx is used, so the handler is only traced when the caller is traced.
+*/
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/forbearing/k8s/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// instrumentationName is the name of the tracer.
+const instrumentationName = "github.com/forbearing/k8s"
+
+// Attribute keys of the operation span.
+const (
+	VerbKey            = attribute.Key("k8s.verb")
+	GroupKey           = attribute.Key("k8s.group")
+	VersionKey         = attribute.Key("k8s.version")
+	KindKey            = attribute.Key("k8s.kind")
+	NamespaceKey       = attribute.Key("k8s.namespace")
+	NameKey            = attribute.Key("k8s.name")
+	SubresourceKey     = attribute.Key("k8s.subresource")
+	DryRunKey          = attribute.Key("k8s.dry_run")
+	ResultKey          = attribute.Key("k8s.result")
+	ResourceVersionKey = attribute.Key("k8s.resource_version")
+	FilenameKey        = attribute.Key("k8s.filename")
+)
+
+// HandlerOption returns the handler option that traces every mutating
+// operation and the http requests sent to the kubernetes API server.
+func HandlerOption(tp trace.TracerProvider) types.HandlerOption {
+	return func(config *types.HandlerConfig) {
+		types.WithTransport(WrapTransport(tp))(config)
+		types.WithInterceptors(Interceptor(tp))(config)
+	}
+}
+
+// Interceptor returns the interceptor that creates a span for every
+// Create/Update/Apply/Patch/Delete call, the span is named "<verb> <kind>",
+// such like "create Deployment".
+func Interceptor(tp trace.TracerProvider) types.Interceptor {
+	return func(ctx context.Context, op *types.Operation, invoke types.Invoker) error {
+		ctx, span := tracer(ctx, tp).Start(ctx, fmt.Sprintf("%s %s", op.Verb, op.GVK.Kind),
+			trace.WithAttributes(
+				VerbKey.String(string(op.Verb)),
+				GroupKey.String(op.GVK.Group),
+				VersionKey.String(op.GVK.Version),
+				KindKey.String(op.GVK.Kind),
+				NamespaceKey.String(op.Namespace),
+				DryRunKey.Bool(op.DryRun),
+			))
+		defer span.End()
+
+		err := invoke(ctx, op)
+		// the name and namespace is only known after invoke, such like the
+		// k8s object created with generateName or rewritten by interceptors.
+		name, namespace := op.Name, op.Namespace
+		if op.Result != nil {
+			if accessor, e := meta.Accessor(op.Result); e == nil {
+				name, namespace = accessor.GetName(), accessor.GetNamespace()
+				span.SetAttributes(ResourceVersionKey.String(accessor.GetResourceVersion()))
+			}
+		}
+		span.SetAttributes(NameKey.String(name), NamespaceKey.String(namespace))
+		if len(op.Subresource) != 0 {
+			span.SetAttributes(SubresourceKey.String(op.Subresource))
+		}
+		if err != nil {
+			span.SetAttributes(ResultKey.String("error"))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+		span.SetAttributes(ResultKey.String("success"))
+		return nil
+	}
+}
+
+// StartSpan starts a span named name from ctx with the tracer provider, it's
+// used to trace the multi-object operations, such like ApplyF and DeleteF.
+func StartSpan(ctx context.Context, tp trace.TracerProvider, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer(ctx, tp).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records the error to the span if err is not nil and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracer returns the tracer of the tracer provider, default to the tracer
+// provider of the span stored in ctx.
+func tracer(ctx context.Context, tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = trace.SpanFromContext(ctx).TracerProvider()
+	}
+	return tp.Tracer(instrumentationName)
+}
@@ -0,0 +1,141 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/forbearing/k8s/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTracerProvider() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestInterceptor(t *testing.T) {
+	tp, recorder := newTracerProvider()
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+
+	op := &types.Operation{
+		Verb:      types.VerbCreate,
+		GVK:       appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		Namespace: "test",
+		DryRun:    true,
+		Object:    &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{GenerateName: "nginx-"}},
+	}
+	// the tracer provider of the span stored in ctx is used.
+	err := types.Intercept(ctx, []types.Interceptor{Interceptor(nil)}, op, func(ctx context.Context, op *types.Operation) error {
+		op.Result = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "nginx-abcde", Namespace: "test", ResourceVersion: "1"}}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "create Deployment" {
+		t.Errorf("expected span name %q, got %q", "create Deployment", span.Name())
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("expected the span is the child of the parent span")
+	}
+	attrs := attributes(span)
+	for key, expected := range map[attribute.Key]attribute.Value{
+		VerbKey:            attribute.StringValue("create"),
+		KindKey:            attribute.StringValue("Deployment"),
+		NamespaceKey:       attribute.StringValue("test"),
+		NameKey:            attribute.StringValue("nginx-abcde"),
+		DryRunKey:          attribute.BoolValue(true),
+		ResultKey:          attribute.StringValue("success"),
+		ResourceVersionKey: attribute.StringValue("1"),
+	} {
+		if attrs[key] != expected {
+			t.Errorf("expected attribute %s=%v, got %v", key, expected.Emit(), attrs[key].Emit())
+		}
+	}
+
+	// the error is recorded.
+	op = &types.Operation{Verb: types.VerbDelete, GVK: op.GVK, Namespace: "test", Name: "nginx"}
+	err = types.Intercept(context.Background(), []types.Interceptor{Interceptor(tp)}, op, func(context.Context, *types.Operation) error {
+		return errors.New("forbidden")
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	span = recorder.Ended()[2]
+	if span.Status().Code != codes.Error {
+		t.Errorf("expected span status error, got %v", span.Status().Code)
+	}
+	if v := attributes(span)[ResultKey]; v != attribute.StringValue("error") {
+		t.Errorf("expected result error, got %v", v.Emit())
+	}
+}
+
+func TestWrapTransport(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	tp, recorder := newTracerProvider()
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	defer parent.End()
+
+	client := &http.Client{Transport: WrapTransport(tp)(http.DefaultTransport)}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/apis/apps/v1/namespaces/test/deployments/nginx", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(req.Header.Get("traceparent")) != 0 {
+		t.Errorf("the original request must not be modified")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "HTTP GET" {
+		t.Errorf("expected span name %q, got %q", "HTTP GET", span.Name())
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("expected the span is the child of the parent span")
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("expected span status error for 404, got %v", span.Status().Code)
+	}
+	if len(traceparent) == 0 {
+		t.Errorf("expected the trace context propagated to the server")
+	}
+}
@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WrapTransport returns the transport wrapper that creates a client span for
+// every http request sent to the kubernetes API server, the span is the child
+// of the span stored in the request context and is propagated to the API
+// server by the global text map propagator. It can be used with
+// types.WithTransport or rest.Config.Wrap directly.
+func WrapTransport(tp trace.TracerProvider) func(rt http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &roundTripper{tp: tp, delegate: rt}
+	}
+}
+
+type roundTripper struct {
+	tp       trace.TracerProvider
+	delegate http.RoundTripper
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer(req.Context(), rt.tp).Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPClientAttributesFromHTTPRequest(req)...))
+	defer span.End()
+
+	// the RoundTripper must not modify the request.
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := rt.delegate.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(resp.StatusCode)...)
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(resp.StatusCode, trace.SpanKindClient))
+	return resp, nil
+}
@@ -0,0 +1,141 @@
+package wait
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ConditionFunc checks whether the k8s object satisfies the condition. The
+// reason explains why the condition is not satisfied, such like "1/3 replicas
+// updated", it's reported by the progress callback and the timeout error.
+// Returning an error stops waiting, eg: the job failed.
+type ConditionFunc func(obj *unstructured.Unstructured) (done bool, reason string, err error)
+
+// Condition is the condition waited for.
+type Condition struct {
+	// Name describes the condition, such like "condition=Ready" or "delete".
+	Name string
+	// Func checks the existing k8s objects, it's not used by deletion.
+	Func ConditionFunc
+	// Deletion is true if the condition is the k8s objects are deleted.
+	Deletion bool
+}
+
+// ForDelete returns the condition that the k8s objects are deleted, it works
+// like "kubectl wait --for=delete".
+func ForDelete() Condition {
+	return Condition{Name: "delete", Deletion: true}
+}
+
+// ForCondition returns the condition that the status condition conditionType
+// of the k8s object has the status, status default to "True". It works like
+// "kubectl wait --for=condition=Available=True". The condition is ignored if
+// its observedGeneration is older than the generation of the k8s object.
+func ForCondition(conditionType, status string) Condition {
+	if len(status) == 0 {
+		status = "True"
+	}
+	name := fmt.Sprintf("condition=%s=%s", conditionType, status)
+	return Condition{Name: name, Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		if err != nil {
+			return false, "", err
+		}
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if !ok || !strings.EqualFold(fmt.Sprint(condition["type"]), conditionType) {
+				continue
+			}
+			if observed, ok, _ := unstructured.NestedInt64(condition, "observedGeneration"); ok && observed < obj.GetGeneration() {
+				return false, fmt.Sprintf("condition %s is not observed for generation %d", conditionType, obj.GetGeneration()), nil
+			}
+			if strings.EqualFold(fmt.Sprint(condition["status"]), status) {
+				return true, "", nil
+			}
+			reason := fmt.Sprintf("condition %s is %v", conditionType, condition["status"])
+			if message, ok := condition["message"].(string); ok && len(message) != 0 {
+				reason += ": " + message
+			}
+			return false, reason, nil
+		}
+		return false, fmt.Sprintf("condition %s not found", conditionType), nil
+	}}
+}
+
+// ForJSONPath returns the condition that the value of the JSONPath expression
+// of the k8s object equals to value, it works like
+// "kubectl wait --for=jsonpath='{.status.phase}'=Running".
+func ForJSONPath(expression, value string) (Condition, error) {
+	if !strings.HasPrefix(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	if err := jsonpath.New("wait").Parse(expression); err != nil {
+		return Condition{}, err
+	}
+	name := fmt.Sprintf("jsonpath=%s=%s", expression, value)
+	return Condition{Name: name, Func: func(obj *unstructured.Unstructured) (bool, string, error) {
+		// the JSONPath parser is not safe for concurrent use.
+		parser := jsonpath.New("wait").AllowMissingKeys(true)
+		if err := parser.Parse(expression); err != nil {
+			return false, "", err
+		}
+		results, err := parser.FindResults(obj.Object)
+		if err != nil {
+			return false, "", err
+		}
+		if len(results) == 0 || len(results[0]) == 0 {
+			return false, fmt.Sprintf("%s not found", expression), nil
+		}
+		buf := &bytes.Buffer{}
+		for _, result := range results[0] {
+			buf.Reset()
+			if err := parser.PrintResults(buf, []reflect.Value{result}); err != nil {
+				return false, "", err
+			}
+			if buf.String() != value {
+				return false, fmt.Sprintf("%s is %q", expression, buf.String()), nil
+			}
+		}
+		return true, "", nil
+	}}, nil
+}
+
+// ParseFor parses the condition in the format of "kubectl wait --for", eg:
+//
+//	delete
+//	condition=Available
+//	condition=Ready=False
+//	jsonpath={.status.phase}=Running
+func ParseFor(s string) (Condition, error) {
+	switch {
+	case strings.EqualFold(s, "delete"):
+		return ForDelete(), nil
+	case strings.HasPrefix(s, "condition="):
+		parts := strings.SplitN(strings.TrimPrefix(s, "condition="), "=", 2)
+		if len(parts[0]) == 0 {
+			return Condition{}, fmt.Errorf("invalid condition %q: the condition type is empty", s)
+		}
+		if len(parts) == 2 {
+			return ForCondition(parts[0], parts[1]), nil
+		}
+		return ForCondition(parts[0], ""), nil
+	case strings.HasPrefix(s, "jsonpath="):
+		// the JSONPath expression may contain "=", such like {.status.conditions[?(@.type=="Ready")].status}
+		s = strings.TrimPrefix(s, "jsonpath=")
+		sep := "}="
+		if !strings.HasPrefix(s, "{") {
+			sep = "="
+		}
+		i := strings.LastIndex(s, sep)
+		if i < 0 {
+			return Condition{}, fmt.Errorf("invalid jsonpath condition %q, expected {expression}=value", s)
+		}
+		return ForJSONPath(s[:i+len(sep)-1], s[i+len(sep):])
+	}
+	return Condition{}, fmt.Errorf("invalid condition %q, expected delete, condition=type[=status] or jsonpath={expression}=value", s)
+}
@@ -0,0 +1,220 @@
+/*
+Package wait waits for k8s objects to satisfy a condition, it works like
+"kubectl wait" and is driven by watches instead of polling.
+
+	cond, _ := wait.ParseFor("condition=Available")
+	err := handler.WaitFor("nginx", cond, wait.WithTimeout(5*time.Minute))
+
+The dynamic handler and all typed handlers have WaitFor() and WaitForByLabel()
+methods, For() can be used with any dynamic.ResourceInterface.
+*/
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// ErrDeleted is returned if the k8s object waited for by name is deleted before
+// the condition is satisfied.
+var ErrDeleted = errors.New("k8s object was deleted while waiting")
+
+// Target selects the k8s objects waited for, by name or by label selector.
+type Target struct {
+	Name          string
+	LabelSelector string
+}
+
+func (t Target) String() string {
+	if len(t.Name) != 0 {
+		return t.Name
+	}
+	return fmt.Sprintf("selector %q", t.LabelSelector)
+}
+
+// Progress is the wait progress reported to the progress callback.
+type Progress struct {
+	// Condition is the name of the condition waited for.
+	Condition string
+	// Total is the number of k8s objects selected, Satisfied is the number of
+	// k8s objects that satisfy the condition.
+	Total     int
+	Satisfied int
+	// Pending contains the reasons of the k8s objects that don't satisfy the
+	// condition, such like "test/nginx: 1/3 replicas updated", sorted by name.
+	Pending []string
+}
+
+func (p Progress) String() string {
+	s := fmt.Sprintf("%d/%d satisfied %s", p.Satisfied, p.Total, p.Condition)
+	if len(p.Pending) != 0 {
+		s += ", pending: " + strings.Join(p.Pending, ", ")
+	}
+	return s
+}
+
+// TimeoutError is returned if the timeout expired or the context is done before
+// the condition is satisfied, it contains the last progress.
+type TimeoutError struct {
+	Target   Target
+	Progress Progress
+	Err      error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for %s: %s", e.Target, e.Progress)
+}
+
+// Unwrap returns the context error, such like context.DeadlineExceeded.
+func (e *TimeoutError) Unwrap() error { return e.Err }
+
+// Option configures the wait.
+type Option func(*options)
+
+type options struct {
+	timeout  time.Duration
+	progress func(Progress)
+}
+
+// WithTimeout sets the timeout of the wait, zero means no timeout and the
+// wait only returns when the condition is satisfied or the context is done.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithProgress sets the callback that is called when the progress changed.
+func WithProgress(progress func(Progress)) Option {
+	return func(o *options) { o.progress = progress }
+}
+
+// For waits until the k8s objects selected by target satisfy the condition.
+// The k8s objects are listed and watched, the watch is re-established if
+// it's closed by the kubernetes API server.
+//
+// If target selects k8s objects by labels, all the selected k8s objects must
+// satisfy the condition and at least one k8s object is selected, except for
+// deletion. If target selects the k8s object by name, it waits for the k8s
+// object to be created, and returns ErrDeleted if it's deleted after created.
+func For(ctx context.Context, client dynamic.ResourceInterface, target Target, cond Condition, opts ...Option) error {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if !cond.Deletion && cond.Func == nil {
+		return fmt.Errorf("invalid condition %q: condition func is nil", cond.Name)
+	}
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	var fieldSelector string
+	if len(target.Name) != 0 {
+		fieldSelector = fields.OneTermEqualSelector("metadata.name", target.Name).String()
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector, options.LabelSelector = fieldSelector, target.LabelSelector
+			return client.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector, options.LabelSelector = fieldSelector, target.LabelSelector
+			return client.Watch(ctx, options)
+		},
+	}
+
+	// return the error immediately if the k8s objects can't be listed, such
+	// like forbidden, otherwise the informer retries until timeout.
+	if _, err := lw.List(metav1.ListOptions{Limit: 1}); err != nil {
+		return err
+	}
+
+	var (
+		store    cache.Store
+		progress = Progress{Condition: cond.Name}
+		seen     bool
+	)
+	check := func() (bool, error) {
+		done, current, err := evaluate(store.List(), cond)
+		if err != nil {
+			return false, err
+		}
+		// the k8s object waited for by name is deleted.
+		if seen && current.Total == 0 && len(target.Name) != 0 && !cond.Deletion {
+			return false, fmt.Errorf("%s: %w", target.Name, ErrDeleted)
+		}
+		seen = seen || current.Total != 0
+		if !reflect.DeepEqual(current, progress) {
+			progress = current
+			if o.progress != nil {
+				o.progress(progress)
+			}
+		}
+		return done, nil
+	}
+	precondition := func(s cache.Store) (bool, error) {
+		store = s
+		return check()
+	}
+	condition := func(watch.Event) (bool, error) { return check() }
+
+	_, err := watchtools.UntilWithSync(ctx, lw, &unstructured.Unstructured{}, precondition, condition)
+	if err != nil && ctx.Err() != nil {
+		return &TimeoutError{Target: target, Progress: progress, Err: ctx.Err()}
+	}
+	return err
+}
+
+// evaluate checks the k8s objects against the condition.
+func evaluate(objs []interface{}, cond Condition) (bool, Progress, error) {
+	progress := Progress{Condition: cond.Name, Total: len(objs)}
+	for _, o := range objs {
+		obj, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		key := obj.GetName()
+		if len(obj.GetNamespace()) != 0 {
+			key = obj.GetNamespace() + "/" + key
+		}
+		if cond.Deletion {
+			progress.Pending = append(progress.Pending, key+": not deleted")
+			continue
+		}
+		done, reason, err := cond.Func(obj)
+		if err != nil {
+			return false, progress, fmt.Errorf("%s: %w", key, err)
+		}
+		if done {
+			progress.Satisfied++
+			continue
+		}
+		if len(reason) == 0 {
+			reason = "not satisfied"
+		}
+		progress.Pending = append(progress.Pending, key+": "+reason)
+	}
+	sort.Strings(progress.Pending)
+	if progress.Total == 0 && !cond.Deletion {
+		progress.Pending = []string{"no k8s objects found"}
+	}
+	if cond.Deletion {
+		return progress.Total == 0, progress, nil
+	}
+	return progress.Total != 0 && progress.Satisfied == progress.Total, progress, nil
+}
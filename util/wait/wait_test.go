@@ -0,0 +1,149 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+var gvr = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+func newDeployment(name string, generation int64, conditions ...interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":       name,
+			"namespace":  "test",
+			"generation": generation,
+			"labels":     map[string]interface{}{"app": "nginx"},
+		},
+		"status": map[string]interface{}{
+			"observedGeneration": generation,
+			"conditions":         conditions,
+		},
+	}}
+	return obj
+}
+
+func available(status string) interface{} {
+	return map[string]interface{}{"type": "Available", "status": status, "message": "minimum replicas"}
+}
+
+func TestConditions(t *testing.T) {
+	tests := []struct {
+		name     string
+		cond     string
+		obj      *unstructured.Unstructured
+		expected bool
+		reason   string
+	}{
+		{"condition true", "condition=Available", newDeployment("nginx", 1, available("True")), true, ""},
+		{"condition false", "condition=available", newDeployment("nginx", 1, available("False")), false, "condition available is False: minimum replicas"},
+		{"condition status", "condition=Available=False", newDeployment("nginx", 1, available("False")), true, ""},
+		{"condition not found", "condition=Progressing", newDeployment("nginx", 1, available("True")), false, "condition Progressing not found"},
+		{"jsonpath", "jsonpath={.metadata.labels.app}=nginx", newDeployment("nginx", 1), true, ""},
+		{"jsonpath filter", `jsonpath={.status.conditions[?(@.type=="Available")].status}=True`, newDeployment("nginx", 1, available("True")), true, ""},
+		{"jsonpath mismatch", "jsonpath=.metadata.name=apache", newDeployment("nginx", 1), false, `{.metadata.name} is "nginx"`},
+		{"jsonpath missing", "jsonpath={.status.phase}=Running", newDeployment("nginx", 1), false, "{.status.phase} not found"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cond, err := ParseFor(test.cond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			done, reason, err := cond.Func(test.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if done != test.expected || reason != test.reason {
+				t.Errorf("expected (%v, %q), got (%v, %q)", test.expected, test.reason, done, reason)
+			}
+		})
+	}
+
+	// stale condition of old generation.
+	obj := newDeployment("nginx", 2, map[string]interface{}{"type": "Ready", "status": "True", "observedGeneration": int64(1)})
+	if done, _, _ := ForCondition("Ready", "").Func(obj); done {
+		t.Errorf("expected the stale condition is ignored")
+	}
+
+	for _, s := range []string{"", "condition=", "jsonpath={.status.phase}", "ready"} {
+		if _, err := ParseFor(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+	if cond, err := ParseFor("delete"); err != nil || !cond.Deletion {
+		t.Errorf("expected deletion condition, got %v, %v", cond, err)
+	}
+}
+
+func newClient(objs ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "DeploymentList"}, objs...)
+}
+
+func TestFor(t *testing.T) {
+	client := newClient(newDeployment("nginx", 1, available("False")))
+	resource := client.Resource(gvr).Namespace("test")
+
+	var progresses []Progress
+	err := For(context.TODO(), resource, Target{LabelSelector: "app=nginx"}, ForCondition("Available", ""),
+		WithTimeout(10*time.Second), WithProgress(func(p Progress) {
+			progresses = append(progresses, p)
+			// make the deployment available after the first progress reported.
+			if p.Satisfied == 0 {
+				go resource.Update(context.TODO(), newDeployment("nginx", 1, available("True")), metav1.UpdateOptions{})
+			}
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progresses) != 2 || progresses[0].Satisfied != 0 || progresses[1].Satisfied != 1 {
+		t.Errorf("unexpected progresses: %v", progresses)
+	}
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		resource.Delete(context.TODO(), "nginx", metav1.DeleteOptions{})
+	}()
+	if err = For(context.TODO(), resource, Target{Name: "nginx"}, ForDelete(), WithTimeout(10*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestForTimeout(t *testing.T) {
+	client := newClient(newDeployment("nginx", 1, available("False")))
+	resource := client.Resource(gvr).Namespace("test")
+
+	err := For(context.TODO(), resource, Target{Name: "nginx"}, ForCondition("Available", ""), WithTimeout(200*time.Millisecond))
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "test/nginx: condition Available is False") {
+		t.Errorf("expected the reason in the error, got %q", err.Error())
+	}
+}
+
+func TestForDeleted(t *testing.T) {
+	client := newClient(newDeployment("nginx", 1, available("False")))
+	resource := client.Resource(gvr).Namespace("test")
+
+	err := For(context.TODO(), resource, Target{Name: "nginx"}, ForCondition("Available", ""),
+		WithTimeout(10*time.Second), WithProgress(func(p Progress) {
+			go resource.Delete(context.TODO(), "nginx", metav1.DeleteOptions{})
+		}))
+	if !errors.Is(err, ErrDeleted) {
+		t.Fatalf("expected ErrDeleted, got %v", err)
+	}
+}